@@ -0,0 +1,205 @@
+// Package beacon implements a distributed randomness beacon in the style of
+// drand, on top of the threshold BLS signatures of sign/tbls.
+//
+// The nodes of the beacon hold the shares of a key generated by a
+// distributed key generation, such as share/dkg/pedersen, whose public
+// polynomial is known to all of them. At each round, every node signs the
+// digest of the round with its share, and any t valid partial signatures are
+// recovered into the signature of the round. The randomness of the round is
+// the hash of that signature, which anyone knowing the public key of the
+// group can verify.
+//
+// In a chained scheme the digest of a round covers the signature of the
+// previous round, so that the rounds form a chain back to the genesis, while
+// in an unchained scheme it only covers the round number, which lets the
+// signature of a future round be used as the key of a timelock encryption.
+package beacon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/sign/tbls"
+)
+
+// ErrBrokenChain is returned by VerifyChain when the previous signature of a
+// beacon is not the signature of the round before.
+var ErrBrokenChain = errors.New("beacon: previous signature does not match")
+
+// Beacon is the output of a round of the beacon.
+type Beacon struct {
+	// Round is the number of the round.
+	Round uint64
+	// PreviousSig is the signature of the previous round, or the genesis
+	// seed for the first round. It is empty for the unchained schemes.
+	PreviousSig []byte
+	// Signature is the threshold signature of the digest of the round.
+	Signature []byte
+}
+
+// Randomness returns the randomness of the beacon.
+func (b *Beacon) Randomness() []byte {
+	return RandomnessFromSignature(b.Signature)
+}
+
+// RandomnessFromSignature returns the randomness of a round given its
+// signature, the SHA-256 hash of the signature.
+func RandomnessFromSignature(sig []byte) []byte {
+	h := sha256.Sum256(sig)
+	return h[:]
+}
+
+// Scheme produces and verifies the rounds of a beacon.
+type Scheme struct {
+	name     string
+	chained  bool
+	suite    pairing.Suite
+	keyGroup kyber.Group
+	sigGroup kyber.Group
+	tbls     sign.ThresholdScheme
+}
+
+// NewChainedScheme returns the chained scheme of drand, pedersen-bls-chained,
+// with public keys on G1 and signatures on G2.
+func NewChainedScheme(suite pairing.Suite) *Scheme {
+	return &Scheme{
+		name:     "pedersen-bls-chained",
+		chained:  true,
+		suite:    suite,
+		keyGroup: suite.G1(),
+		sigGroup: suite.G2(),
+		tbls:     tbls.NewThresholdSchemeOnG2(suite),
+	}
+}
+
+// NewUnchainedScheme returns the unchained scheme of drand,
+// pedersen-bls-unchained, with public keys on G1 and signatures on G2.
+func NewUnchainedScheme(suite pairing.Suite) *Scheme {
+	return &Scheme{
+		name:     "pedersen-bls-unchained",
+		suite:    suite,
+		keyGroup: suite.G1(),
+		sigGroup: suite.G2(),
+		tbls:     tbls.NewThresholdSchemeOnG2(suite),
+	}
+}
+
+// NewUnchainedSchemeOnG1 returns the unchained scheme of drand with short
+// signatures, bls-unchained-g1-rfc9380, with public keys on G2 and signatures
+// on G1.
+func NewUnchainedSchemeOnG1(suite pairing.Suite) *Scheme {
+	return &Scheme{
+		name:     "bls-unchained-g1-rfc9380",
+		suite:    suite,
+		keyGroup: suite.G2(),
+		sigGroup: suite.G1(),
+		tbls:     tbls.NewThresholdSchemeOnG1(suite),
+	}
+}
+
+// Name returns the name of the scheme, as used by drand.
+func (s *Scheme) Name() string {
+	return s.name
+}
+
+// Chained reports whether the rounds of the scheme are linked by their
+// previous signature.
+func (s *Scheme) Chained() bool {
+	return s.chained
+}
+
+// Suite returns the pairing suite of the scheme.
+func (s *Scheme) Suite() pairing.Suite {
+	return s.suite
+}
+
+// KeyGroup returns the group of the public key and of the commitments of the
+// public polynomial.
+func (s *Scheme) KeyGroup() kyber.Group {
+	return s.keyGroup
+}
+
+// SigGroup returns the group of the signatures.
+func (s *Scheme) SigGroup() kyber.Group {
+	return s.sigGroup
+}
+
+// Digest returns the message signed at the given round: the SHA-256 hash of
+// the previous signature and of the round number in big-endian for a chained
+// scheme, and of the round number alone for an unchained one, which ignores
+// prevSig.
+func (s *Scheme) Digest(round uint64, prevSig []byte) []byte {
+	h := sha256.New()
+	if s.chained {
+		h.Write(prevSig)
+	}
+	var r [8]byte
+	binary.BigEndian.PutUint64(r[:], round)
+	h.Write(r[:])
+	return h.Sum(nil)
+}
+
+// Sign returns the partial signature of the round with the share of a node.
+func (s *Scheme) Sign(private *share.PriShare, round uint64, prevSig []byte) ([]byte, error) {
+	return s.tbls.Sign(private, s.Digest(round, prevSig))
+}
+
+// IndexOf returns the index of the node that produced a partial signature.
+func (s *Scheme) IndexOf(partial []byte) (int, error) {
+	return s.tbls.IndexOf(partial)
+}
+
+// VerifyPartial checks a partial signature of the round against the public
+// polynomial of the group.
+func (s *Scheme) VerifyPartial(public *share.PubPoly, round uint64, prevSig, partial []byte) error {
+	return s.tbls.VerifyPartial(public, s.Digest(round, prevSig), partial)
+}
+
+// Recover recovers the beacon of the round from the partial signatures of at
+// least t of the n nodes. The invalid partial signatures are skipped.
+func (s *Scheme) Recover(public *share.PubPoly, round uint64, prevSig []byte,
+	partials [][]byte, t, n int) (*Beacon, error) {
+	sig, err := s.tbls.Recover(public, s.Digest(round, prevSig), partials, t, n)
+	if err != nil {
+		return nil, err
+	}
+	b := &Beacon{Round: round, Signature: sig}
+	if s.chained {
+		b.PreviousSig = append([]byte{}, prevSig...)
+	}
+	return b, nil
+}
+
+// Verify checks the signature of a beacon against the public key of the
+// group, the constant coefficient of its public polynomial.
+func (s *Scheme) Verify(public kyber.Point, b *Beacon) error {
+	return s.tbls.VerifyRecovered(public, s.Digest(b.Round, b.PreviousSig), b.Signature)
+}
+
+// VerifyChain checks the signatures of consecutive beacons and, for a
+// chained scheme, that each of them links to the signature of the one before.
+func (s *Scheme) VerifyChain(public kyber.Point, beacons []*Beacon) error {
+	for i, b := range beacons {
+		if err := s.Verify(public, b); err != nil {
+			return fmt.Errorf("beacon: round %d: %w", b.Round, err)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := beacons[i-1]
+		if b.Round != prev.Round+1 {
+			return fmt.Errorf("beacon: round %d follows round %d", b.Round, prev.Round)
+		}
+		if s.chained && !bytes.Equal(b.PreviousSig, prev.Signature) {
+			return ErrBrokenChain
+		}
+	}
+	return nil
+}
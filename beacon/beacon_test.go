@@ -0,0 +1,79 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+func TestBeacon(t *testing.T) {
+	suite := kilic.NewBLS12381Suite()
+	n, th := 7, 4
+	for _, scheme := range []*Scheme{
+		NewChainedScheme(suite),
+		NewUnchainedScheme(suite),
+		NewUnchainedSchemeOnG1(suite),
+	} {
+		secret := scheme.KeyGroup().Scalar().Pick(random.New())
+		priPoly := share.NewPriPoly(scheme.KeyGroup(), th, secret, random.New())
+		pubPoly := priPoly.Commit(scheme.KeyGroup().Point().Base())
+		shares := priPoly.Shares(n)
+
+		prevSig := []byte("genesis seed")
+		var beacons []*Beacon
+		for round := uint64(1); round <= 3; round++ {
+			var partials [][]byte
+			for _, sh := range shares {
+				partial, err := scheme.Sign(sh, round, prevSig)
+				require.NoError(t, err)
+				require.NoError(t, scheme.VerifyPartial(pubPoly, round, prevSig, partial))
+				i, err := scheme.IndexOf(partial)
+				require.NoError(t, err)
+				require.Equal(t, sh.I, uint32(i))
+				require.Error(t, scheme.VerifyPartial(pubPoly, round+1, prevSig, partial))
+				partials = append(partials, partial)
+			}
+
+			// the first partial is invalid and skipped
+			partials[0] = append([]byte{}, partials[0]...)
+			partials[0][len(partials[0])-1] ^= 1
+			_, err := scheme.Recover(pubPoly, round, prevSig, partials[:th], th, n)
+			require.Error(t, err)
+			b, err := scheme.Recover(pubPoly, round, prevSig, partials, th, n)
+			require.NoError(t, err, scheme.Name())
+			require.NoError(t, scheme.Verify(pubPoly.Commit(), b))
+			require.Equal(t, RandomnessFromSignature(b.Signature), b.Randomness())
+
+			// any threshold of nodes recovers the same signature
+			other, err := scheme.Recover(pubPoly, round, prevSig, partials[n-th:], th, n)
+			require.NoError(t, err)
+			require.Equal(t, b, other)
+
+			beacons = append(beacons, b)
+			prevSig = b.Signature
+		}
+		require.NoError(t, scheme.VerifyChain(pubPoly.Commit(), beacons))
+
+		bad := *beacons[1]
+		bad.Round++
+		require.Error(t, scheme.Verify(pubPoly.Commit(), &bad))
+		require.Error(t, scheme.VerifyChain(pubPoly.Commit(), []*Beacon{beacons[0], beacons[2]}))
+
+		wrongKey := scheme.KeyGroup().Point().Pick(random.New())
+		require.Error(t, scheme.Verify(wrongKey, beacons[0]))
+
+		if scheme.Chained() {
+			require.Equal(t, []byte("genesis seed"), beacons[0].PreviousSig)
+			bad = *beacons[1]
+			bad.PreviousSig = beacons[1].Signature
+			require.Error(t, scheme.Verify(pubPoly.Commit(), &bad))
+		} else {
+			// the digest only depends on the round
+			require.Equal(t, scheme.Digest(2, nil), scheme.Digest(2, []byte("ignored")))
+			require.Nil(t, beacons[0].PreviousSig)
+		}
+	}
+}
@@ -75,11 +75,20 @@ func Encrypt(group kyber.Group, public kyber.Point, message []byte, hash func()
 // input parameter is nil then SHA256 is used as a default. Decrypt returns the
 // plaintext message or an error.
 func Decrypt(group kyber.Group, private kyber.Scalar, ctx []byte, hash func() hash.Hash) ([]byte, error) {
-	if hash == nil {
-		hash = sha256.New
+	// Reconstruct the ephemeral elliptic curve point
+	R, err := EphemeralPoint(group, ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Reconstruct the ephemeral elliptic curve point
+	// Compute shared DH key
+	dh := group.Point().Mul(private, R)
+	return DecryptWithSharedKey(group, dh, ctx, hash)
+}
+
+// EphemeralPoint returns the ephemeral elliptic curve point of the DH key
+// exchange, stored in the first part of ctx.
+func EphemeralPoint(group kyber.Group, ctx []byte) (kyber.Point, error) {
 	R := group.Point()
 	l := group.PointLen()
 	if len(ctx) < l {
@@ -88,9 +97,22 @@ func Decrypt(group kyber.Group, private kyber.Scalar, ctx []byte, hash func() ha
 	if err := R.UnmarshalBinary(ctx[:l]); err != nil {
 		return nil, err
 	}
+	return R, nil
+}
 
-	// Compute shared DH key and derive the symmetric key and nonce via HKDF
-	dh := group.Point().Mul(private, R)
+// DecryptWithSharedKey decrypts ctx like Decrypt, given the shared DH key, the
+// ephemeral point times the private key, instead of the private key itself.
+// This lets the holders of shares of the private key decrypt together.
+func DecryptWithSharedKey(group kyber.Group, dh kyber.Point, ctx []byte, hash func() hash.Hash) ([]byte, error) {
+	if hash == nil {
+		hash = sha256.New
+	}
+	l := group.PointLen()
+	if len(ctx) < l {
+		return nil, errors.New("invalid ecies cipher")
+	}
+
+	// Derive the symmetric key and nonce via HKDF
 	keyNonceLen := 32 + 12
 	buf, err := deriveKey(hash, dh, keyNonceLen)
 	if err != nil {
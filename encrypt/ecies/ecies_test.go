@@ -24,6 +24,22 @@ func TestECIES(t *testing.T) {
 	require.Equal(t, message, plaintext)
 }
 
+func TestECIESSharedKey(t *testing.T) {
+	message := []byte("Hello ECIES")
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+	ciphertext, err := Encrypt(suite, public, message, nil)
+	require.Nil(t, err)
+	R, err := EphemeralPoint(suite, ciphertext)
+	require.Nil(t, err)
+	plaintext, err := DecryptWithSharedKey(suite, suite.Point().Mul(private, R), ciphertext, nil)
+	require.Nil(t, err)
+	require.Equal(t, message, plaintext)
+	_, err = DecryptWithSharedKey(suite, R, ciphertext, nil)
+	require.NotNil(t, err)
+}
+
 func TestECIESFailPoint(t *testing.T) {
 	message := []byte("Hello ECIES")
 	suite := edwards25519.NewBlakeSHA256Ed25519()
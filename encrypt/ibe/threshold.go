@@ -0,0 +1,116 @@
+package ibe
+
+import (
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/share"
+)
+
+// In the distributed PKG mode, the master secret s is shared among n nodes,
+// for instance with share/dkg/pedersen, and the master public key is the
+// constant coefficient of the public polynomial of the sharing. Each node i
+// issues for an identity the partial private key s_i * H(ID), which can be
+// checked against its public share s_i * P with a pairing, and any t valid
+// partial keys recover the private key s * H(ID) of the identity by Lagrange
+// interpolation.
+
+// ExtractPartialOnG1 returns the partial private key of the identity ID
+// issued by the node holding the private share, for the master keys on G1 of
+// EncryptCCAonG1. The partial key is on G2.
+func ExtractPartialOnG1(s pairing.Suite, private *share.PriShare, ID []byte) (*share.PubShare, error) {
+	return extractPartial(s.G2(), private, ID)
+}
+
+// ExtractPartialOnG2 returns the partial private key of the identity ID
+// issued by the node holding the private share, for the master keys on G2 of
+// EncryptCCAonG2. The partial key is on G1.
+func ExtractPartialOnG2(s pairing.Suite, private *share.PriShare, ID []byte) (*share.PubShare, error) {
+	return extractPartial(s.G1(), private, ID)
+}
+
+// VerifyPartialOnG1 checks a partial private key of ExtractPartialOnG1
+// against the public polynomial of the master key: e(P, s_i * Q_id) must be
+// e(s_i * P, Q_id).
+func VerifyPartialOnG1(s pairing.Suite, public *share.PubPoly, ID []byte, partial *share.PubShare) error {
+	Qid, err := hashID(s.G2(), ID)
+	if err != nil {
+		return err
+	}
+	Si := public.Eval(partial.I).V
+	if !s.Pair(s.G1().Point().Base(), partial.V).Equal(s.Pair(Si, Qid)) {
+		return fmt.Errorf("invalid partial key %d", partial.I)
+	}
+	return nil
+}
+
+// VerifyPartialOnG2 checks a partial private key of ExtractPartialOnG2
+// against the public polynomial of the master key: e(s_i * Q_id, P) must be
+// e(Q_id, s_i * P).
+func VerifyPartialOnG2(s pairing.Suite, public *share.PubPoly, ID []byte, partial *share.PubShare) error {
+	Qid, err := hashID(s.G1(), ID)
+	if err != nil {
+		return err
+	}
+	Si := public.Eval(partial.I).V
+	if !s.Pair(partial.V, s.G2().Point().Base()).Equal(s.Pair(Qid, Si)) {
+		return fmt.Errorf("invalid partial key %d", partial.I)
+	}
+	return nil
+}
+
+// RecoverKeyOnG1 recovers the private key of the identity ID, usable with
+// DecryptCCAonG1, from t of the valid partial keys of ExtractPartialOnG1.
+// The invalid partial keys are skipped.
+func RecoverKeyOnG1(s pairing.Suite, public *share.PubPoly, ID []byte,
+	partials []*share.PubShare, t, n int) (kyber.Point, error) {
+	verify := func(p *share.PubShare) error { return VerifyPartialOnG1(s, public, ID, p) }
+	return recoverKey(s.G2(), verify, partials, t, n)
+}
+
+// RecoverKeyOnG2 recovers the private key of the identity ID, usable with
+// DecryptCCAonG2, from t of the valid partial keys of ExtractPartialOnG2.
+// The invalid partial keys are skipped.
+func RecoverKeyOnG2(s pairing.Suite, public *share.PubPoly, ID []byte,
+	partials []*share.PubShare, t, n int) (kyber.Point, error) {
+	verify := func(p *share.PubShare) error { return VerifyPartialOnG2(s, public, ID, p) }
+	return recoverKey(s.G1(), verify, partials, t, n)
+}
+
+func hashID(g kyber.Group, ID []byte) (kyber.Point, error) {
+	hashable, ok := g.Point().(kyber.HashablePoint)
+	if !ok {
+		return nil, errors.New("point needs to implement `kyber.HashablePoint`")
+	}
+	return hashable.Hash(ID), nil
+}
+
+func extractPartial(g kyber.Group, private *share.PriShare, ID []byte) (*share.PubShare, error) {
+	Qid, err := hashID(g, ID)
+	if err != nil {
+		return nil, err
+	}
+	return &share.PubShare{I: private.I, V: Qid.Mul(private.V, Qid)}, nil
+}
+
+func recoverKey(g kyber.Group, verify func(*share.PubShare) error,
+	partials []*share.PubShare, t, n int) (kyber.Point, error) {
+	seen := make(map[uint32]bool)
+	var valid []*share.PubShare
+	for _, p := range partials {
+		if p == nil || seen[p.I] || verify(p) != nil {
+			continue
+		}
+		seen[p.I] = true
+		valid = append(valid, p)
+		if len(valid) == t {
+			break
+		}
+	}
+	if len(valid) < t {
+		return nil, errors.New("not enough valid partial keys")
+	}
+	return share.RecoverCommit(g, valid, t, n)
+}
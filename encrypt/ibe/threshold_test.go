@@ -0,0 +1,62 @@
+package ibe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	circl "go.dedis.ch/kyber/v4/pairing/bls12381/circl"
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+func TestThresholdExtraction(t *testing.T) {
+	suite := circl.NewSuiteBLS12381()
+	n, th := 5, 3
+	ID := []byte("passtherand")
+	msg := []byte("Hello World\n")
+
+	settings := []struct {
+		name    string
+		master  kyber.Group
+		extract func(pairing.Suite, *share.PriShare, []byte) (*share.PubShare, error)
+		verify  func(pairing.Suite, *share.PubPoly, []byte, *share.PubShare) error
+		recover func(pairing.Suite, *share.PubPoly, []byte, []*share.PubShare, int, int) (kyber.Point, error)
+		encrypt func(pairing.Suite, kyber.Point, []byte, []byte) (*Ciphertext, error)
+		decrypt func(pairing.Suite, kyber.Point, *Ciphertext) ([]byte, error)
+	}{
+		{"OnG1", suite.G1(), ExtractPartialOnG1, VerifyPartialOnG1, RecoverKeyOnG1, EncryptCCAonG1, DecryptCCAonG1},
+		{"OnG2", suite.G2(), ExtractPartialOnG2, VerifyPartialOnG2, RecoverKeyOnG2, EncryptCCAonG2, DecryptCCAonG2},
+	}
+	for _, st := range settings {
+		t.Run(st.name, func(t *testing.T) {
+			secret := st.master.Scalar().Pick(random.New())
+			priPoly := share.NewPriPoly(st.master, th, secret, random.New())
+			pubPoly := priPoly.Commit(st.master.Point().Base())
+
+			var partials []*share.PubShare
+			for _, sh := range priPoly.Shares(n) {
+				p, err := st.extract(suite, sh, ID)
+				require.NoError(t, err)
+				require.NoError(t, st.verify(suite, pubPoly, ID, p))
+				require.Error(t, st.verify(suite, pubPoly, []byte("other"), p))
+				partials = append(partials, p)
+			}
+
+			// an invalid partial key and a duplicate are skipped
+			partials[0] = &share.PubShare{I: partials[0].I, V: partials[1].V}
+			partials[2] = partials[1]
+			_, err := st.recover(suite, pubPoly, ID, partials[:4], th, n)
+			require.Error(t, err)
+			private, err := st.recover(suite, pubPoly, ID, partials, th, n)
+			require.NoError(t, err)
+
+			c, err := st.encrypt(suite, pubPoly.Commit(), ID, msg)
+			require.NoError(t, err)
+			plain, err := st.decrypt(suite, private, c)
+			require.NoError(t, err)
+			require.Equal(t, msg, plain)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package threshold implements the threshold decryption of ElGamal and ECIES
+// ciphertexts, for a key shared among n nodes by a distributed key generation
+// such as share/dkg/pedersen.
+//
+// The ciphertexts are encrypted to the shared public key X = xG, the constant
+// coefficient of the public polynomial of the key, and all carry an
+// ephemeral point R = rG. Each node i holding the share xi of the secret
+// key computes the decryption share xiR, with a NIZK proof that
+// log_G(xiG) == log_R(xiR), so that the shares can be verified against the
+// public polynomial. Any t valid shares recover the shared key xR by
+// Lagrange interpolation, from which the plaintext is decrypted.
+package threshold
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/encrypt/ecies"
+	"go.dedis.ch/kyber/v4/proof/dleq"
+	"go.dedis.ch/kyber/v4/share"
+)
+
+// Suite describes the functionalities needed by this package.
+type Suite interface {
+	kyber.Group
+	kyber.HashFactory
+	kyber.XOFFactory
+	kyber.Random
+}
+
+// ErrTooFewShares is returned when less than t of the decryption shares are
+// valid.
+var ErrTooFewShares = errors.New("not enough valid decryption shares")
+
+// DecShare is the decryption share of a node with its correctness proof.
+type DecShare struct {
+	S share.PubShare // decryption share xiR of the node i
+	P dleq.Proof     // proof that log_G(xiG) == log_R(xiR)
+}
+
+// NewDecShare returns the decryption share of the node holding the private
+// share for the ciphertext with the ephemeral point R.
+func NewDecShare(suite Suite, private *share.PriShare, R kyber.Point) (*DecShare, error) {
+	P, _, xR, err := dleq.NewDLEQProof(suite, suite.Point().Base(), R, private.V)
+	if err != nil {
+		return nil, err
+	}
+	return &DecShare{S: share.PubShare{I: private.I, V: xR}, P: *P}, nil
+}
+
+// VerifyDecShare checks the decryption share for the ciphertext with the
+// ephemeral point R against the public polynomial of the shared key. A share
+// missing its point or any field of its proof is rejected.
+func VerifyDecShare(suite Suite, public *share.PubPoly, R kyber.Point, ds *DecShare) error {
+	if ds == nil {
+		return errors.New("missing decryption share")
+	}
+	if ds.S.V == nil || ds.P.C == nil || ds.P.R == nil || ds.P.VG == nil || ds.P.VH == nil {
+		return fmt.Errorf("share %d: incomplete decryption share", ds.S.I)
+	}
+	X := public.Eval(ds.S.I).V
+
+	// the challenge of the proof must be the hash of its statement and
+	// commitments, as computed by dleq.NewDLEQProof
+	h := suite.Hash()
+	for _, p := range []kyber.Point{X, ds.S.V, ds.P.VG, ds.P.VH} {
+		if _, err := p.MarshalTo(h); err != nil {
+			return err
+		}
+	}
+	c := suite.Scalar().Pick(suite.XOF(h.Sum(nil)))
+	if !ds.P.C.Equal(c) {
+		return fmt.Errorf("share %d: %w", ds.S.I, dleq.ErrInvalidProof)
+	}
+	if err := ds.P.Verify(suite, suite.Point().Base(), R, X, ds.S.V); err != nil {
+		return fmt.Errorf("share %d: %w", ds.S.I, err)
+	}
+	return nil
+}
+
+// Recover verifies the decryption shares for the ciphertext with the
+// ephemeral point R, and recovers the shared key xR from t of the valid
+// ones. The invalid shares are skipped.
+func Recover(suite Suite, public *share.PubPoly, R kyber.Point, shares []*DecShare, t, n int) (kyber.Point, error) {
+	seen := make(map[uint32]bool)
+	var valid []*share.PubShare
+	for _, ds := range shares {
+		if ds == nil || seen[ds.S.I] || VerifyDecShare(suite, public, R, ds) != nil {
+			continue
+		}
+		seen[ds.S.I] = true
+		valid = append(valid, &share.PubShare{I: ds.S.I, V: ds.S.V})
+		if len(valid) == t {
+			break
+		}
+	}
+	if len(valid) < t {
+		return nil, ErrTooFewShares
+	}
+	return share.RecoverCommit(suite, valid, t, n)
+}
+
+// EncryptElGamal encrypts the point M to the public key X. It returns the
+// ephemeral point K = kG and the ciphertext C = M + kX.
+func EncryptElGamal(suite Suite, public, M kyber.Point) (K, C kyber.Point) {
+	k := suite.Scalar().Pick(suite.RandomStream())
+	K = suite.Point().Mul(k, nil)
+	C = suite.Point().Mul(k, public)
+	C.Add(C, M)
+	return K, C
+}
+
+// DecryptElGamal recovers the point M = C - xK from the decryption shares of
+// the ElGamal ciphertext (K, C).
+func DecryptElGamal(suite Suite, public *share.PubPoly, K, C kyber.Point,
+	shares []*DecShare, t, n int) (kyber.Point, error) {
+	xK, err := Recover(suite, public, K, shares, t, n)
+	if err != nil {
+		return nil, err
+	}
+	return suite.Point().Sub(C, xK), nil
+}
+
+// NewECIESDecShare returns the decryption share of the node holding the
+// private share for a ciphertext of ecies.Encrypt.
+func NewECIESDecShare(suite Suite, private *share.PriShare, ctx []byte) (*DecShare, error) {
+	R, err := ecies.EphemeralPoint(suite, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewDecShare(suite, private, R)
+}
+
+// DecryptECIES decrypts a ciphertext of ecies.Encrypt, encrypted with the
+// given hash to the shared public key, from its decryption shares.
+func DecryptECIES(suite Suite, public *share.PubPoly, ctx []byte, shares []*DecShare,
+	t, n int, hash func() hash.Hash) ([]byte, error) {
+	R, err := ecies.EphemeralPoint(suite, ctx)
+	if err != nil {
+		return nil, err
+	}
+	dh, err := Recover(suite, public, R, shares, t, n)
+	if err != nil {
+		return nil, err
+	}
+	return ecies.DecryptWithSharedKey(suite, dh, ctx, hash)
+}
@@ -0,0 +1,108 @@
+package threshold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/encrypt/ecies"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/share"
+)
+
+const n, th = 7, 4
+
+func setup(suite Suite) (*share.PubPoly, []*share.PriShare) {
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	priPoly := share.NewPriPoly(suite, th, secret, suite.RandomStream())
+	return priPoly.Commit(nil), priPoly.Shares(n)
+}
+
+func TestElGamal(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	public, shares := setup(suite)
+	M := suite.Point().Embed([]byte("threshold elgamal"), suite.RandomStream())
+	K, C := EncryptElGamal(suite, public.Commit(), M)
+
+	var decShares []*DecShare
+	for _, sh := range shares {
+		ds, err := NewDecShare(suite, sh, K)
+		require.NoError(t, err)
+		require.NoError(t, VerifyDecShare(suite, public, K, ds))
+		decShares = append(decShares, ds)
+	}
+
+	// the first shares are invalid or duplicates
+	bad, err := NewDecShare(suite, shares[1], C)
+	require.NoError(t, err)
+	require.Error(t, VerifyDecShare(suite, public, K, bad))
+	decShares[0] = bad
+	decShares[2] = decShares[1]
+	_, err = DecryptElGamal(suite, public, K, C, decShares[:th+1], th, n)
+	require.ErrorIs(t, err, ErrTooFewShares)
+
+	m, err := DecryptElGamal(suite, public, K, C, decShares, th, n)
+	require.NoError(t, err)
+	require.True(t, M.Equal(m))
+	data, err := m.Data()
+	require.NoError(t, err)
+	require.Equal(t, "threshold elgamal", string(data))
+}
+
+func TestECIES(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	public, shares := setup(suite)
+	msg := []byte("threshold ecies")
+	ctx, err := ecies.Encrypt(suite, public.Commit(), msg, suite.Hash)
+	require.NoError(t, err)
+
+	var decShares []*DecShare
+	for _, sh := range shares[n-th:] {
+		ds, err := NewECIESDecShare(suite, sh, ctx)
+		require.NoError(t, err)
+		decShares = append(decShares, ds)
+	}
+	plain, err := DecryptECIES(suite, public, ctx, decShares, th, n, suite.Hash)
+	require.NoError(t, err)
+	require.Equal(t, msg, plain)
+
+	// a share forged for another ephemeral point is rejected
+	R, err := ecies.EphemeralPoint(suite, ctx)
+	require.NoError(t, err)
+	forged := *decShares[0]
+	forged.S.V = suite.Point().Add(forged.S.V, R)
+	require.Error(t, VerifyDecShare(suite, public, R, &forged))
+	decShares[0] = &forged
+	_, err = DecryptECIES(suite, public, ctx, decShares, th, n, suite.Hash)
+	require.ErrorIs(t, err, ErrTooFewShares)
+
+	_, err = DecryptECIES(suite, public, ctx[:4], decShares, th, n, suite.Hash)
+	require.Error(t, err)
+}
+
+func TestVerifyTruncatedDecShare(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	public, shares := setup(suite)
+	K, C := EncryptElGamal(suite, public.Commit(), suite.Point().Pick(suite.RandomStream()))
+	ds, err := NewDecShare(suite, shares[0], K)
+	require.NoError(t, err)
+
+	truncate := []func(*DecShare){
+		func(ds *DecShare) { ds.S.V = nil },
+		func(ds *DecShare) { ds.P.C = nil },
+		func(ds *DecShare) { ds.P.R = nil },
+		func(ds *DecShare) { ds.P.VG = nil },
+		func(ds *DecShare) { ds.P.VH = nil },
+	}
+	decShares := []*DecShare{nil}
+	for _, f := range truncate {
+		truncated := *ds
+		f(&truncated)
+		require.Error(t, VerifyDecShare(suite, public, K, &truncated))
+		decShares = append(decShares, &truncated)
+	}
+	require.Error(t, VerifyDecShare(suite, public, K, nil))
+
+	// the truncated shares are skipped instead of crashing the decryption
+	_, err = DecryptElGamal(suite, public, K, C, decShares, 1, n)
+	require.ErrorIs(t, err, ErrTooFewShares)
+}
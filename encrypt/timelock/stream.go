@@ -0,0 +1,175 @@
+package timelock
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// chunkSize is the size of the plaintext of the chunks of the payload, all
+// full but the last one.
+const chunkSize = 64 * 1024
+
+const encChunkSize = chunkSize + chacha20poly1305.Overhead
+
+// streamNonce is the nonce of a chunk: an 11-byte big-endian counter,
+// followed by a byte set to 1 for the last chunk.
+type streamNonce [chacha20poly1305.NonceSize]byte
+
+func (n *streamNonce) next() {
+	for i := len(n) - 2; i >= 0; i-- {
+		n[i]++
+		if n[i] != 0 {
+			return
+		}
+	}
+}
+
+func (n *streamNonce) isZero() bool {
+	for _, b := range n[:len(n)-1] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type streamWriter struct {
+	aead  cipher.AEAD
+	dst   io.Writer
+	buf   []byte
+	nonce streamNonce
+	err   error
+}
+
+func newStreamWriter(key []byte, dst io.Writer) (*streamWriter, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return &streamWriter{
+		aead: aead,
+		dst:  dst,
+		buf:  make([]byte, 0, encChunkSize),
+	}, nil
+}
+
+// Write encrypts the full chunks of p. A full chunk is only flushed once more
+// data follows it, as it might be the last one.
+func (w *streamWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	total := len(p)
+	for len(p) > 0 {
+		if len(w.buf) == chunkSize {
+			if err := w.flush(false); err != nil {
+				w.err = err
+				return total - len(p), err
+			}
+		}
+		n := copy(w.buf[len(w.buf):chunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+	}
+	return total, nil
+}
+
+// Close encrypts the last chunk. It does not close the destination.
+func (w *streamWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.flush(true)
+	if w.err != nil {
+		return w.err
+	}
+	w.err = errors.New("timelock: write on a closed writer")
+	return nil
+}
+
+func (w *streamWriter) flush(last bool) error {
+	if last {
+		w.nonce[len(w.nonce)-1] = 1
+	}
+	out := w.aead.Seal(w.buf[:0], w.nonce[:], w.buf, nil)
+	if _, err := w.dst.Write(out); err != nil {
+		return err
+	}
+	w.nonce.next()
+	w.buf = w.buf[:0]
+	return nil
+}
+
+type streamReader struct {
+	aead   cipher.AEAD
+	src    *bufio.Reader
+	enc    []byte
+	unread []byte
+	nonce  streamNonce
+	last   bool
+	err    error
+}
+
+func newStreamReader(key []byte, src *bufio.Reader) (*streamReader, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return &streamReader{
+		aead: aead,
+		src:  src,
+		enc:  make([]byte, encChunkSize),
+	}, nil
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	for len(r.unread) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.last {
+			return 0, io.EOF
+		}
+		r.unread, r.err = r.readChunk()
+	}
+	n := copy(p, r.unread)
+	r.unread = r.unread[n:]
+	return n, nil
+}
+
+// readChunk decrypts the next chunk, which is the last one if it is not full
+// or if nothing follows it.
+func (r *streamReader) readChunk() ([]byte, error) {
+	n, err := io.ReadFull(r.src, r.enc)
+	switch {
+	case err == io.ErrUnexpectedEOF:
+		r.last = true
+	case err == io.EOF:
+		return nil, errors.New("timelock: truncated payload")
+	case err != nil:
+		return nil, err
+	default:
+		if _, err := r.src.Peek(1); err == io.EOF {
+			r.last = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.last {
+		r.nonce[len(r.nonce)-1] = 1
+	}
+	out, err := r.aead.Open(r.enc[:0], r.nonce[:], r.enc[:n], nil)
+	if err != nil {
+		return nil, errors.New("timelock: failed to decrypt the payload")
+	}
+	if r.last && len(out) == 0 && !r.nonce.isZero() {
+		return nil, errors.New("timelock: empty last chunk")
+	}
+	r.nonce.next()
+	return out, nil
+}
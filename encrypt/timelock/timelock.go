@@ -0,0 +1,319 @@
+// Package timelock implements the timelock encryption of tlock, which
+// encrypts data to a future round of an unchained randomness beacon (see the
+// beacon package) so that it can only be decrypted once the signature of
+// that round is published.
+//
+// The data is encrypted in the format of age, version 1, which tlock uses: a
+// random file key is encrypted with the CCA identity-based encryption of
+// encrypt/ibe, the identity being the digest of the round, in a stanza of
+// type tlock. The file key authenticates the header and derives the key of
+// the STREAM encryption of the payload, with ChaCha20-Poly1305 in chunks of
+// 64 KiB.
+package timelock
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/beacon"
+	"go.dedis.ch/kyber/v4/encrypt/ibe"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	intro        = "age-encryption.org/v1\n"
+	stanzaPrefix = "-> "
+	footerPrefix = "---"
+	stanzaType   = "tlock"
+
+	fileKeySize = 16
+	nonceSize   = 16
+	// columnsPerLine is the number of base64 characters of a full line of
+	// a stanza body.
+	columnsPerLine = 64
+)
+
+var b64 = base64.RawStdEncoding.Strict()
+
+// Network is the beacon network whose rounds the data is locked to.
+type Network struct {
+	// Scheme is the scheme of the beacon, which must be unchained.
+	Scheme *beacon.Scheme
+	// Public is the public key of the group of the beacon.
+	Public kyber.Point
+	// ChainHash is the hash identifying the chain of the beacon.
+	ChainHash []byte
+}
+
+// Encrypt writes to dst the encryption of the data read from src until
+// io.EOF, which can be decrypted with the signature of the round.
+func (n *Network) Encrypt(dst io.Writer, src io.Reader, round uint64) error {
+	w, err := n.NewWriter(dst, round)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, src); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// Decrypt writes to dst the decryption of the data read from src. The
+// function signature is called with the round of the encryption and must
+// return the signature of that round, which is verified before its use.
+func (n *Network) Decrypt(dst io.Writer, src io.Reader, signature func(round uint64) ([]byte, error)) error {
+	r, err := n.NewReader(src, signature)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, r)
+	return err
+}
+
+// NewWriter writes the header of an encryption to the round to dst, and
+// returns a writer that encrypts the data written to it. The writer must be
+// closed to write the last chunk of the payload.
+func (n *Network) NewWriter(dst io.Writer, round uint64) (io.WriteCloser, error) {
+	if n.Scheme.Chained() {
+		return nil, errors.New("timelock: cannot encrypt to the rounds of a chained beacon")
+	}
+	fileKey := make([]byte, fileKeySize)
+	if _, err := rand.Read(fileKey); err != nil {
+		return nil, err
+	}
+	body, err := n.encryptKey(round, fileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	hdr := new(bytes.Buffer)
+	hdr.WriteString(intro)
+	fmt.Fprintf(hdr, "%s%s %d %s\n", stanzaPrefix, stanzaType, round, hex.EncodeToString(n.ChainHash))
+	writeBody(hdr, body)
+	hdr.WriteString(footerPrefix)
+	mac, err := headerMAC(fileKey, hdr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(hdr, " %s\n", b64.EncodeToString(mac))
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	hdr.Write(nonce)
+	if _, err := dst.Write(hdr.Bytes()); err != nil {
+		return nil, err
+	}
+	key, err := payloadKey(fileKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return newStreamWriter(key, dst)
+}
+
+// NewReader reads the header of an encryption from src, and returns a reader
+// of the decrypted data. The function signature is called with the round of
+// the encryption and must return the signature of that round, which is
+// verified before its use.
+func (n *Network) NewReader(src io.Reader, signature func(round uint64) ([]byte, error)) (io.Reader, error) {
+	br := bufio.NewReader(src)
+	round, body, hdr, mac, err := n.readHeader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := signature(round)
+	if err != nil {
+		return nil, err
+	}
+	b := &beacon.Beacon{Round: round, Signature: sig}
+	if err := n.Scheme.Verify(n.Public, b); err != nil {
+		return nil, fmt.Errorf("timelock: invalid signature of round %d: %w", round, err)
+	}
+	fileKey, err := n.decryptKey(body, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := headerMAC(fileKey, hdr)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(mac, expected) {
+		return nil, errors.New("timelock: invalid header mac")
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(br, nonce); err != nil {
+		return nil, fmt.Errorf("timelock: reading the payload nonce: %w", err)
+	}
+	key, err := payloadKey(fileKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return newStreamReader(key, br)
+}
+
+// encryptKey returns the body of the tlock stanza, the identity-based
+// encryption of the file key to the digest of the round, as U || V || W.
+func (n *Network) encryptKey(round uint64, fileKey []byte) ([]byte, error) {
+	suite := n.Scheme.Suite()
+	id := n.Scheme.Digest(round, nil)
+	var c *ibe.Ciphertext
+	var err error
+	if n.sigOnG2() {
+		c, err = ibe.EncryptCCAonG1(suite, n.Public, id, fileKey)
+	} else {
+		c, err = ibe.EncryptCCAonG2(suite, n.Public, id, fileKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+	buf, err := c.U.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	buf = append(buf, c.V...)
+	return append(buf, c.W...), nil
+}
+
+// decryptKey decrypts the file key from the body of the tlock stanza with
+// the signature of the round, the private key of its identity.
+func (n *Network) decryptKey(body, sig []byte) ([]byte, error) {
+	suite := n.Scheme.Suite()
+	c := &ibe.Ciphertext{U: n.Scheme.KeyGroup().Point()}
+	pointLen := c.U.MarshalSize()
+	if len(body) != pointLen+2*fileKeySize {
+		return nil, errors.New("timelock: invalid stanza body length")
+	}
+	if err := c.U.UnmarshalBinary(body[:pointLen]); err != nil {
+		return nil, err
+	}
+	c.V = body[pointLen : pointLen+fileKeySize]
+	c.W = body[pointLen+fileKeySize:]
+
+	private := n.Scheme.SigGroup().Point()
+	if err := private.UnmarshalBinary(sig); err != nil {
+		return nil, err
+	}
+	if n.sigOnG2() {
+		return ibe.DecryptCCAonG1(suite, private, c)
+	}
+	return ibe.DecryptCCAonG2(suite, private, c)
+}
+
+// sigOnG2 reports whether the signatures of the beacon are on G2, and so the
+// identities of the encryption.
+func (n *Network) sigOnG2() bool {
+	return n.Scheme.SigGroup().String() == n.Scheme.Suite().G2().String()
+}
+
+// readHeader parses the header of age with a single tlock stanza for the
+// chain of the network. It returns the round and the body of the stanza, the
+// bytes of the header covered by its mac, and the mac.
+func (n *Network) readHeader(br *bufio.Reader) (uint64, []byte, []byte, []byte, error) {
+	hdr := new(bytes.Buffer)
+	readLine := func() (string, error) {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("timelock: reading the header: %w", err)
+		}
+		hdr.WriteString(line)
+		return strings.TrimSuffix(line, "\n"), nil
+	}
+
+	line, err := readLine()
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	if line+"\n" != intro {
+		return 0, nil, nil, nil, errors.New("timelock: unknown format version")
+	}
+
+	line, err = readLine()
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	args := strings.Split(strings.TrimPrefix(line, stanzaPrefix), " ")
+	if !strings.HasPrefix(line, stanzaPrefix) || len(args) != 3 || args[0] != stanzaType {
+		return 0, nil, nil, nil, errors.New("timelock: expected a tlock stanza")
+	}
+	round, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil || strconv.FormatUint(round, 10) != args[1] {
+		return 0, nil, nil, nil, errors.New("timelock: invalid round")
+	}
+	if args[2] != hex.EncodeToString(n.ChainHash) {
+		return 0, nil, nil, nil, errors.New("timelock: encrypted for another chain")
+	}
+
+	var body []byte
+	for {
+		line, err = readLine()
+		if err != nil {
+			return 0, nil, nil, nil, err
+		}
+		b, err := b64.DecodeString(line)
+		if err != nil || len(line) > columnsPerLine {
+			return 0, nil, nil, nil, errors.New("timelock: invalid stanza body")
+		}
+		body = append(body, b...)
+		if len(line) < columnsPerLine {
+			break
+		}
+	}
+
+	line, err = readLine()
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	if !strings.HasPrefix(line, footerPrefix+" ") {
+		return 0, nil, nil, nil, errors.New("timelock: expected a single stanza")
+	}
+	mac, err := b64.DecodeString(strings.TrimPrefix(line, footerPrefix+" "))
+	if err != nil || len(mac) != sha256.Size {
+		return 0, nil, nil, nil, errors.New("timelock: invalid header mac")
+	}
+	// the mac covers the header up to the footer prefix
+	covered := hdr.Bytes()[:hdr.Len()-len(line)-1+len(footerPrefix)]
+	return round, body, covered, mac, nil
+}
+
+// writeBody writes the body of a stanza in base64, in lines of
+// columnsPerLine characters ended by a shorter, possibly empty, line.
+func writeBody(w *bytes.Buffer, body []byte) {
+	s := b64.EncodeToString(body)
+	for len(s) >= columnsPerLine {
+		w.WriteString(s[:columnsPerLine] + "\n")
+		s = s[columnsPerLine:]
+	}
+	w.WriteString(s + "\n")
+}
+
+func headerMAC(fileKey, hdr []byte) ([]byte, error) {
+	key := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, fileKey, nil, []byte("header")), key); err != nil {
+		return nil, err
+	}
+	h := hmac.New(sha256.New, key)
+	h.Write(hdr)
+	return h.Sum(nil), nil
+}
+
+func payloadKey(fileKey, nonce []byte) ([]byte, error) {
+	key := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, fileKey, nonce, []byte("payload")), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
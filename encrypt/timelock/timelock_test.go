@@ -0,0 +1,248 @@
+package timelock
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/beacon"
+	"go.dedis.ch/kyber/v4/encrypt/ibe"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/util/random"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+type testNetwork struct {
+	*Network
+	poly   *share.PubPoly
+	shares []*share.PriShare
+}
+
+func newTestNetwork(t *testing.T, scheme *beacon.Scheme) *testNetwork {
+	secret := scheme.KeyGroup().Scalar().Pick(random.New())
+	priPoly := share.NewPriPoly(scheme.KeyGroup(), 3, secret, random.New())
+	pubPoly := priPoly.Commit(scheme.KeyGroup().Point().Base())
+	return &testNetwork{
+		Network: &Network{
+			Scheme:    scheme,
+			Public:    pubPoly.Commit(),
+			ChainHash: []byte("chain hash of the test network"),
+		},
+		poly:   pubPoly,
+		shares: priPoly.Shares(5),
+	}
+}
+
+// signature returns the signature of a round, recovered from the partial
+// signatures of the nodes.
+func (n *testNetwork) signature(t *testing.T, round uint64) []byte {
+	var partials [][]byte
+	for _, sh := range n.shares {
+		partial, err := n.Scheme.Sign(sh, round, nil)
+		require.NoError(t, err)
+		partials = append(partials, partial)
+	}
+	b, err := n.Scheme.Recover(n.poly, round, nil, partials, 3, 5)
+	require.NoError(t, err)
+	return b.Signature
+}
+
+func TestTimelock(t *testing.T) {
+	suite := kilic.NewBLS12381Suite()
+	for _, scheme := range []*beacon.Scheme{
+		beacon.NewUnchainedScheme(suite),
+		beacon.NewUnchainedSchemeOnG1(suite),
+	} {
+		n := newTestNetwork(t, scheme)
+		sig := n.signature(t, 42)
+		for _, size := range []int{0, 1, 1000, chunkSize, chunkSize + 1, 2*chunkSize + 500} {
+			msg := make([]byte, size)
+			_, _ = rand.Read(msg)
+
+			enc := new(bytes.Buffer)
+			require.NoError(t, n.Encrypt(enc, bytes.NewReader(msg), 42))
+			require.True(t, strings.HasPrefix(enc.String(), intro+"-> tlock 42 "))
+
+			dec := new(bytes.Buffer)
+			err := n.Decrypt(dec, bytes.NewReader(enc.Bytes()), func(round uint64) ([]byte, error) {
+				require.Equal(t, uint64(42), round)
+				return sig, nil
+			})
+			require.NoError(t, err, "%s: %d bytes", scheme.Name(), size)
+			require.Equal(t, msg, dec.Bytes())
+		}
+	}
+}
+
+func TestTimelockInvalid(t *testing.T) {
+	suite := kilic.NewBLS12381Suite()
+	n := newTestNetwork(t, beacon.NewUnchainedSchemeOnG1(suite))
+	sig := n.signature(t, 7)
+	signature := func(uint64) ([]byte, error) { return sig, nil }
+
+	msg := make([]byte, chunkSize+100)
+	enc := new(bytes.Buffer)
+	require.NoError(t, n.Encrypt(enc, bytes.NewReader(msg), 7))
+	ct := enc.Bytes()
+	require.NoError(t, n.Decrypt(new(bytes.Buffer), bytes.NewReader(ct), signature))
+
+	// the signature of another round
+	other := n.signature(t, 8)
+	err := n.Decrypt(new(bytes.Buffer), bytes.NewReader(ct), func(uint64) ([]byte, error) { return other, nil })
+	require.Error(t, err)
+
+	// the round is not reached yet
+	tooEarly := errors.New("too early")
+	err = n.Decrypt(new(bytes.Buffer), bytes.NewReader(ct), func(uint64) ([]byte, error) { return nil, tooEarly })
+	require.ErrorIs(t, err, tooEarly)
+
+	// tampering anywhere
+	for _, i := range []int{len(intro) + 5, len(intro) + 20, len(ct) - chunkSize, len(ct) - 1} {
+		bad := append([]byte{}, ct...)
+		bad[i] ^= 1
+		require.Error(t, n.Decrypt(new(bytes.Buffer), bytes.NewReader(bad), signature), "byte %d", i)
+	}
+
+	// truncation at a chunk boundary
+	require.Error(t, n.Decrypt(new(bytes.Buffer), bytes.NewReader(ct[:len(ct)-116]), signature))
+	require.Error(t, n.Decrypt(new(bytes.Buffer), bytes.NewReader(ct[:len(ct)-10]), signature))
+
+	// another chain
+	n2 := *n.Network
+	n2.ChainHash = []byte("another chain")
+	require.Error(t, n2.Decrypt(new(bytes.Buffer), bytes.NewReader(ct), signature))
+
+	// the rounds of a chained beacon are not predictable
+	chained := &Network{Scheme: beacon.NewChainedScheme(suite), Public: n.Public}
+	_, err = chained.NewWriter(new(bytes.Buffer), 7)
+	require.Error(t, err)
+}
+
+// The fastnet network of drand, whose signatures are on G1 but hashed with
+// the domain separation tag of G2, and the signature of its round 1.
+const (
+	fastnetPublic = "a0b862a7527fee3a731bcb59280ab6abd62d5c0b6ea03dc4ddf6612fdfc9d01f" +
+		"01c31542541771903475eb1ec6615f8d0df0b8b6dce385811d6dcf8cbefb8759e5e616a3dfd0" +
+		"54c928940766d9a5b9db91e3b697e5d70a975181e007f87fca5e"
+	fastnetChainHash = "dbd506d6ef76e5f386f41c651dcb808c5bcbd75471cc4eafa3f4df7ad4e4c493"
+	fastnetRound1    = "9544ddce2fdbe8688d6f5b4f98eed5d63eee3902e7e162050ac0f45905a55657" +
+		"714880adabe3c3096b92767d886567d0"
+)
+
+func fastnet(t *testing.T) (*Network, []byte) {
+	suite := kilic.NewBLS12381SuiteWithDST(kilic.DefaultDomainG2(), nil)
+	scheme := beacon.NewUnchainedSchemeOnG1(suite)
+	public := scheme.KeyGroup().Point()
+	require.NoError(t, public.UnmarshalBinary(mustDecodeHex(t, fastnetPublic)))
+	n := &Network{Scheme: scheme, Public: public, ChainHash: mustDecodeHex(t, fastnetChainHash)}
+	sig := mustDecodeHex(t, fastnetRound1)
+	require.NoError(t, scheme.Verify(public, &beacon.Beacon{Round: 1, Signature: sig}))
+	return n, sig
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	buf, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return buf
+}
+
+func hkdfKey(t *testing.T, secret, salt []byte, info string) []byte {
+	key := make([]byte, 32)
+	_, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(info)), key)
+	require.NoError(t, err)
+	return key
+}
+
+// specEncrypt encrypts the plaintext to round 1 of fastnet as written out in
+// the specifications of age and tlock, independently of the Writer: a tlock
+// stanza whose body is U || V || W in base64 wrapped at 64 columns, the
+// header mac and the STREAM chunks of 64 KiB.
+func specEncrypt(t *testing.T, n *Network, fileKey, nonce, plaintext []byte) []byte {
+	c, err := ibe.EncryptCCAonG2(n.Scheme.Suite(), n.Public, n.Scheme.Digest(1, nil), fileKey)
+	require.NoError(t, err)
+	body, err := c.U.MarshalBinary()
+	require.NoError(t, err)
+	body = append(append(body, c.V...), c.W...)
+
+	hdr := "age-encryption.org/v1\n-> tlock 1 " + fastnetChainHash + "\n"
+	encoded := base64.RawStdEncoding.EncodeToString(body)
+	for ; len(encoded) >= 64; encoded = encoded[64:] {
+		hdr += encoded[:64] + "\n"
+	}
+	hdr += encoded + "\n---"
+	mac := hmac.New(sha256.New, hkdfKey(t, fileKey, nil, "header"))
+	mac.Write([]byte(hdr))
+	out := []byte(hdr + " " + base64.RawStdEncoding.EncodeToString(mac.Sum(nil)) + "\n")
+	out = append(out, nonce...)
+
+	aead, err := chacha20poly1305.New(hkdfKey(t, fileKey, nonce, "payload"))
+	require.NoError(t, err)
+	for counter := uint64(0); ; counter++ {
+		chunk := plaintext
+		if len(chunk) > chunkSize {
+			chunk = chunk[:chunkSize]
+		}
+		plaintext = plaintext[len(chunk):]
+		var chunkNonce [chacha20poly1305.NonceSize]byte
+		binary.BigEndian.PutUint64(chunkNonce[3:11], counter)
+		if len(plaintext) == 0 {
+			chunkNonce[11] = 1
+		}
+		out = aead.Seal(out, chunkNonce[:], chunk, nil)
+		if len(plaintext) == 0 {
+			return out
+		}
+	}
+}
+
+// TestTimelockFastnet decrypts ciphertexts laid out as in the specifications
+// with the actual signature of a round of drand.
+func TestTimelockFastnet(t *testing.T) {
+	n, sig := fastnet(t)
+	fileKey := mustDecodeHex(t, "deadbeefdeadbeefdeadbeefdeadbeef")
+	nonce := mustDecodeHex(t, "000102030405060708090a0b0c0d0e0f")
+	signature := func(round uint64) ([]byte, error) {
+		if round != 1 {
+			return nil, fmt.Errorf("no signature for round %d", round)
+		}
+		return sig, nil
+	}
+
+	// an empty payload is a single empty chunk, and a multiple of the chunk
+	// size ends with a full last chunk
+	for _, size := range []int{0, 5, chunkSize, 2*chunkSize + 1} {
+		msg := make([]byte, size)
+		_, _ = rand.Read(msg)
+		ct := specEncrypt(t, n, fileKey, nonce, msg)
+
+		dec := new(bytes.Buffer)
+		require.NoError(t, n.Decrypt(dec, bytes.NewReader(ct), signature), "%d bytes", size)
+		require.Equal(t, msg, dec.Bytes())
+	}
+
+	// the encryptions of the package have the same layout
+	msg := []byte("hello drand")
+	enc := new(bytes.Buffer)
+	require.NoError(t, n.Encrypt(enc, bytes.NewReader(msg), 1))
+	lines := strings.SplitN(enc.String(), "\n", 6)
+	require.Equal(t, "-> tlock 1 "+fastnetChainHash, lines[1])
+	// U on G2 followed by V and W, 128 bytes in base64 over three lines
+	require.Len(t, lines[2], 64)
+	require.Len(t, lines[3], 64)
+	require.Len(t, lines[4], 43)
+	// the mac, the nonce and a single chunk
+	hdrLen := strings.Index(enc.String(), "\n--- ") + len("\n--- ") + 43 + 1
+	require.Len(t, enc.Bytes()[hdrLen:], nonceSize+len(msg)+chacha20poly1305.Overhead)
+}
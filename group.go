@@ -141,6 +141,37 @@ type AllowsVarTime interface {
 	AllowVarTime(bool)
 }
 
+// MultiScalarMultiplier is an optional interface that can be implemented
+// by a Point to compute a linear combination of points faster than a
+// sequence of Mul and Add operations. Implementations typically use
+// Straus' or Pippenger's algorithm and run in variable time, so they must
+// only be used on public Scalars and Points, never on secret ones.
+type MultiScalarMultiplier interface {
+	// MultiScalarMul sets the receiver to the sum of scalars[i] * points[i]
+	// and returns it. It panics if the two slices have different lengths.
+	MultiScalarMul(scalars []Scalar, points []Point) Point
+}
+
+// PrecomputedPoint is a fixed Point along with a table of its multiples,
+// built once to speed up all the subsequent multiplications of that Point,
+// such as the base point or a long-lived public key. A PrecomputedPoint is
+// never modified by Mul, so it can be shared between goroutines.
+type PrecomputedPoint interface {
+	// Point returns a copy of the fixed Point.
+	Point() Point
+
+	// Mul returns a new Point set to s times the fixed Point.
+	// Unless variable time is explicitly allowed through AllowsVarTime,
+	// it is as safe to use on secret Scalars as the Mul of the Point.
+	Mul(s Scalar) Point
+}
+
+// Precomputable is an optional interface that can be implemented by a
+// Point to build its own PrecomputedPoint natively.
+type Precomputable interface {
+	Precompute() PrecomputedPoint
+}
+
 // Group interface represents a mathematical group
 // usable for Diffie-Hellman key exchange, ElGamal encryption,
 // and the related body of public-key cryptographic algorithms
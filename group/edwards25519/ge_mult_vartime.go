@@ -71,3 +71,131 @@ func geScalarMultVartime(h *extendedGroupElement, a *[32]byte,
 
 	t.ToExtended(h)
 }
+
+// geMultiScalarMultVartime computes h = a[0]*A[0] + ... + a[n-1]*A[n-1]
+// using Straus' interleaving of the sliding windows of each exponent, so
+// that the doublings are shared between all the terms.
+//
+// Preconditions:
+//
+//	a[i][31] <= 127
+func geMultiScalarMultVartime(h *extendedGroupElement, a []*[32]byte,
+	A []*extendedGroupElement) {
+
+	aSlide := make([][256]int8, len(a))
+	Ai := make([][8]cachedGroupElement, len(A)) // A,3A,5A,7A,9A,11A,13A,15A
+	var t completedGroupElement
+	var u, A2 extendedGroupElement
+	var r projectiveGroupElement
+
+	top := -1
+	for j := range a {
+		slide(&aSlide[j], a[j])
+		for i := 255; i > top; i-- {
+			if aSlide[j][i] != 0 {
+				top = i
+				break
+			}
+		}
+
+		A[j].ToCached(&Ai[j][0])
+		A[j].Double(&t)
+		t.ToExtended(&A2)
+		for i := 0; i < 7; i++ {
+			t.Add(&A2, &Ai[j][i])
+			t.ToExtended(&u)
+			u.ToCached(&Ai[j][i+1])
+		}
+	}
+
+	r.Zero()
+	for i := top; i >= 0; i-- {
+		r.Double(&t)
+
+		for j := range aSlide {
+			if aSlide[j][i] > 0 {
+				t.ToExtended(&u)
+				t.Add(&u, &Ai[j][aSlide[j][i]/2])
+			} else if aSlide[j][i] < 0 {
+				t.ToExtended(&u)
+				t.Sub(&u, &Ai[j][(-aSlide[j][i])/2])
+			}
+		}
+
+		t.ToProjective(&r)
+	}
+
+	if top < 0 {
+		h.Zero()
+		return
+	}
+	t.ToExtended(h)
+}
+
+// geMultiScalarMultPippenger computes h = sum_i A[i] * (sum_w d[i][w]*2^(c*w))
+// using Pippenger's bucket method, where d holds the base 2^c digits of the
+// exponents, least significant first.
+func geMultiScalarMultPippenger(h *extendedGroupElement, d [][]uint32, c int,
+	A []*extendedGroupElement) {
+
+	cached := make([]cachedGroupElement, len(A))
+	for i := range A {
+		A[i].ToCached(&cached[i])
+	}
+
+	buckets := make([]extendedGroupElement, 1<<c-1)
+	used := make([]bool, len(buckets))
+	var t completedGroupElement
+	var r projectiveGroupElement
+	var sum, window extendedGroupElement
+	var tmp cachedGroupElement
+
+	// accumulate in acc, as h may be one of the points
+	var acc extendedGroupElement
+	acc.Zero()
+	for w := len(d[0]) - 1; w >= 0; w-- {
+		// acc <<= c
+		acc.ToProjective(&r)
+		for i := 0; i < c; i++ {
+			r.Double(&t)
+			t.ToProjective(&r)
+		}
+		t.ToExtended(&acc)
+
+		for k := range used {
+			used[k] = false
+		}
+		for i := range d {
+			k := d[i][w]
+			if k == 0 {
+				continue
+			}
+			if !used[k-1] {
+				buckets[k-1] = *A[i]
+				used[k-1] = true
+				continue
+			}
+			t.Add(&buckets[k-1], &cached[i])
+			t.ToExtended(&buckets[k-1])
+		}
+
+		// window = sum_k k * buckets[k-1], computed with running sums
+		sum.Zero()
+		window.Zero()
+		for k := len(buckets) - 1; k >= 0; k-- {
+			if used[k] {
+				buckets[k].ToCached(&tmp)
+				t.Add(&sum, &tmp)
+				t.ToExtended(&sum)
+			}
+			sum.ToCached(&tmp)
+			t.Add(&window, &tmp)
+			t.ToExtended(&window)
+		}
+
+		window.ToCached(&tmp)
+		t.Add(&acc, &tmp)
+		t.ToExtended(&acc)
+	}
+	*h = acc
+}
@@ -0,0 +1,104 @@
+package edwards25519
+
+import "go.dedis.ch/kyber/v4"
+
+// precomputedPoint holds the multiples of a fixed point A needed by
+// geScalarMultPrecomputed: table[i][j] = (j+1) * 256^i * A.
+type precomputedPoint struct {
+	base  extendedGroupElement
+	table [32][8]cachedGroupElement
+}
+
+// Precompute returns a table of multiples of P that speeds up its
+// multiplication by any scalar. Multiplications using the table run in
+// constant time, like Mul.
+func (P *point) Precompute() kyber.PrecomputedPoint {
+	pp := &precomputedPoint{base: P.ge}
+
+	var t completedGroupElement
+	var u extendedGroupElement
+	var r projectiveGroupElement
+	B := P.ge
+	for i := range pp.table {
+		B.ToCached(&pp.table[i][0])
+		for j := 0; j < 7; j++ {
+			t.Add(&B, &pp.table[i][j])
+			t.ToExtended(&u)
+			u.ToCached(&pp.table[i][j+1])
+		}
+
+		// B = 256 * B
+		B.ToProjective(&r)
+		for j := 0; j < 8; j++ {
+			r.Double(&t)
+			t.ToProjective(&r)
+		}
+		t.ToExtended(&B)
+	}
+	return pp
+}
+
+// Point returns a copy of the precomputed point.
+func (pp *precomputedPoint) Point() kyber.Point {
+	return &point{ge: pp.base}
+}
+
+// Mul returns a new point set to s times the precomputed point.
+func (pp *precomputedPoint) Mul(s kyber.Scalar) kyber.Point {
+	P := new(point)
+	geScalarMultPrecomputed(&P.ge, &s.(*scalar).v, &pp.table) //nolint:errcheck // V4 may bring better error handling
+	return P
+}
+
+// geScalarMultPrecomputed computes h = a*A, where a = a[0]+256*a[1]+...+256^31 a[31]
+// and table holds the multiples of A computed by Precompute. It works like
+// geScalarMultBase, with the table of A instead of the one of the base point.
+//
+// Preconditions:
+//
+//	a[31] <= 127
+func geScalarMultPrecomputed(h *extendedGroupElement, a *[32]byte,
+	table *[32][8]cachedGroupElement) {
+
+	var e [64]int8
+	for i, v := range a {
+		e[2*i] = int8(v & 15)
+		e[2*i+1] = int8((v >> 4) & 15)
+	}
+	// each e[i] is between 0 and 15 and e[63] is between 0 and 7.
+
+	carry := int8(0)
+	for i := 0; i < 63; i++ {
+		e[i] += carry
+		carry = (e[i] + 8) >> 4
+		e[i] -= carry << 4
+	}
+	e[63] += carry
+	// each e[i] is between -8 and 8.
+
+	h.Zero()
+	var c cachedGroupElement
+	var r completedGroupElement
+	for i := 1; i < 64; i += 2 {
+		selectCached(&c, &table[i/2], int32(e[i]))
+		r.Add(h, &c)
+		r.ToExtended(h)
+	}
+
+	var s projectiveGroupElement
+
+	h.Double(&r)
+	r.ToProjective(&s)
+	s.Double(&r)
+	r.ToProjective(&s)
+	s.Double(&r)
+	r.ToProjective(&s)
+	s.Double(&r)
+	r.ToExtended(h)
+
+	for i := 0; i < 64; i += 2 {
+		selectCached(&c, &table[i/2], int32(e[i]))
+		r.Add(h, &c)
+		r.ToExtended(h)
+	}
+}
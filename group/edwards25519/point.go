@@ -314,6 +314,21 @@ func (P *point) Hash(m []byte, dst string) kyber.Point {
 	return P
 }
 
+// EncodeToCurve encodes the message to a point with encode_to_curve and the
+// suite edwards25519_XMD:SHA-512_ELL2_NU_ of RFC 9380, and the domain
+// separation tag dst. The encoding is cheaper than Hash, but its output is
+// not uniformly distributed: only use it when the protocol allows a
+// nonuniform encoding.
+func (P *point) EncodeToCurve(m, dst []byte) kyber.Point {
+	u := hashToField(m, string(dst), 1)
+	P.Set(mapToCurveElligator2Ed25519(u[0]))
+
+	// Clear cofactor
+	P.Mul(cofactorScalar, P)
+
+	return P
+}
+
 func hashToField(m []byte, dst string, count int) []fieldElement {
 	// L param in RFC9380 section 5
 	// https://datatracker.ietf.org/doc/html/rfc9380#name-hashing-to-a-finite-field
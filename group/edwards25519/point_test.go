@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -364,3 +365,53 @@ func TestHashToPoint(t *testing.T) {
 		j += 2
 	}
 }
+
+func TestEncodeToCurve(t *testing.T) {
+	p := new(point)
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_NU_")
+	expectedPoints := []string{
+		"1ff2b70ecf862799e11b7ae744e3489aa058ce805dd323a936375a84695e76da",
+		"222e314d04a4d5725e9f2aff9fb2a6b69ef375a1214eb19021ceab2d687f0f9b",
+
+		"5f13cc69c891d86927eb37bd4afc6672360007c63f68a33ab423a3aa040fd2a8",
+		"67732d50f9a26f73111dd1ed5dba225614e538599db58ba30aaea1f5c827fa42",
+
+		"1dd2fefce934ecfd7aae6ec998de088d7dd03316aa1847198aecf699ba6613f1",
+		"2f8a6c24dd1adde73909cada6a4a137577b0f179d336685c4a955a0a8e1a86fb",
+	}
+
+	var x, y, rec fieldElement
+	bX := big.NewInt(0)
+	bY := big.NewInt(0)
+
+	for i := 0; i < len(expectedPoints)/2; i++ {
+		p.EncodeToCurve([]byte(inputsTestVectRFC9380[i]), dst)
+
+		feInvert(&rec, &p.ge.Z)
+		feMul(&x, &p.ge.X, &rec)
+		feToBn(bX, &x)
+
+		feMul(&y, &p.ge.Y, &rec)
+		feToBn(bY, &y)
+
+		assert.Equal(t, expectedPoints[2*i], bX.Text(16))
+		assert.Equal(t, expectedPoints[2*i+1], bY.Text(16))
+	}
+}
+
+func TestPointMultiScalarMul(t *testing.T) {
+	// cover both Straus' algorithm and Pippenger's bucket method
+	for _, n := range []int{1, 3, pippengerThreshold - 1, pippengerThreshold + 50} {
+		scalars := make([]kyber.Scalar, n)
+		points := make([]kyber.Point, n)
+		expected := tSuite.Point().Null()
+		for i := range points {
+			scalars[i] = tSuite.Scalar().Pick(tSuite.RandomStream())
+			points[i] = tSuite.Point().Pick(tSuite.RandomStream())
+			expected.Add(expected, tSuite.Point().Mul(scalars[i], points[i]))
+		}
+
+		p := tSuite.Point().(kyber.MultiScalarMultiplier).MultiScalarMul(scalars, points)
+		require.True(t, expected.Equal(p), "mismatch for %d terms", n)
+	}
+}
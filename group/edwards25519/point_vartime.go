@@ -1,5 +1,14 @@
 package edwards25519
 
+import (
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/msm"
+)
+
+// pippengerThreshold is the number of terms from which MultiScalarMul
+// switches from Straus' algorithm to Pippenger's bucket method.
+const pippengerThreshold = 128
+
 // AllowVarTime sets a flag in this object which determines if a faster
 // but variable time implementation can be used. Set this only on Points
 // which represent public information. Using variable time algorithms to
@@ -7,3 +16,30 @@ package edwards25519
 func (P *point) AllowVarTime(varTime bool) {
 	P.varTime = varTime
 }
+
+// MultiScalarMul sets P to the sum of scalars[i] * points[i].
+// It always runs in variable time: only use it on public information.
+func (P *point) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	msm.CheckLengths(scalars, points)
+
+	A := make([]*extendedGroupElement, len(points))
+	for i := range points {
+		A[i] = &points[i].(*point).ge //nolint:errcheck // V4 may bring better error handling
+	}
+
+	if len(points) >= pippengerThreshold {
+		c := msm.WindowSize(len(points), 253)
+		geMultiScalarMultPippenger(&P.ge, msm.Digits(scalars, c), c, A)
+		return P
+	}
+
+	a := make([]*[32]byte, len(scalars))
+	for i := range scalars {
+		// reduce the scalar, as the precondition of slide requires
+		var v [32]byte
+		copy(v[:], scalars[i].(*scalar).toInt().LittleEndian(32, 32)) //nolint:errcheck // V4 may bring better error handling
+		a[i] = &v
+	}
+	geMultiScalarMultVartime(&P.ge, a, A)
+	return P
+}
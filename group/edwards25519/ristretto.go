@@ -0,0 +1,250 @@
+package edwards25519
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+)
+
+// This file implements the ristretto255 encoding of RFC 9496 on top of the
+// points of this package, which the package ristretto255 uses to build a
+// prime-order group: a ristretto255 element is the class of the points that
+// differ by a point of small order.
+
+var (
+	sqrtADMinusOne = feFromDecimal("25063068953384623474111414158702152701244531502492656460079210482610430750235")
+	invSqrtAMinusD = feFromDecimal("54469307008909316920995813868745141605393597292927456921205312896311721017578")
+	oneMinusDSq    = feFromDecimal("1159843021668779879193775521855586647937357759715417654439879720876111806838")
+	dMinusOneSq    = feFromDecimal("40440834346308536858101042469323190826248399146238708352240133220865137265952")
+)
+
+func feFromDecimal(s string) fieldElement {
+	var fe fieldElement
+	n, _ := new(big.Int).SetString(s, 10)
+	feFromBn(&fe, n)
+	return fe
+}
+
+// feEqual returns 1 if a == b and 0 otherwise, in constant time.
+func feEqual(a, b *fieldElement) int32 {
+	var sa, sb [32]byte
+	feToBytes(&sa, a)
+	feToBytes(&sb, b)
+	return int32(subtle.ConstantTimeCompare(sa[:], sb[:]))
+}
+
+// feAbs sets dst to the non-negative one of a and -a.
+func feAbs(dst, a *fieldElement) {
+	var neg fieldElement
+	feNeg(&neg, a)
+	feCopy(dst, a)
+	feCMove(dst, &neg, int32(feIsNegative(a)))
+}
+
+// feSqrtRatioM1 sets r to the non-negative square root of u/v if it exists
+// and returns 1, or sets r to the non-negative square root of sqrt(-1)*u/v and
+// returns 0.
+func feSqrtRatioM1(r, u, v *fieldElement) int32 {
+	var v3, v7, t, check, negU, negUI fieldElement
+	feSquare(&v3, v)
+	feMul(&v3, &v3, v)
+	feSquare(&v7, &v3)
+	feMul(&v7, &v7, v)
+
+	// r = (u * v3) * (u * v7)^((p-5)/8)
+	feMul(&t, u, &v7)
+	fePow22523(&t, &t)
+	feMul(r, u, &v3)
+	feMul(r, r, &t)
+
+	feSquare(&check, r)
+	feMul(&check, &check, v)
+	feNeg(&negU, u)
+	feMul(&negUI, &negU, &sqrtM1)
+	correctSign := feEqual(&check, u)
+	flippedSign := feEqual(&check, &negU)
+	flippedSignI := feEqual(&check, &negUI)
+
+	var rPrime fieldElement
+	feMul(&rPrime, r, &sqrtM1)
+	feCMove(r, &rPrime, flippedSign|flippedSignI)
+	feAbs(r, r)
+	return correctSign | flippedSign
+}
+
+// RistrettoEncode returns the ristretto255 encoding of the element of the
+// point p, which must be a point of this package.
+func RistrettoEncode(p kyber.Point) [32]byte {
+	P := p.(*point) //nolint:errcheck // V4 may bring better error handling
+	var u1, u2, t, invSqrt, den1, den2, zInv fieldElement
+	feAdd(&u1, &P.ge.Z, &P.ge.Y)
+	feSub(&t, &P.ge.Z, &P.ge.Y)
+	feMul(&u1, &u1, &t)
+	feMul(&u2, &P.ge.X, &P.ge.Y)
+
+	feSquare(&t, &u2)
+	feMul(&t, &t, &u1)
+	var one fieldElement
+	feOne(&one)
+	feSqrtRatioM1(&invSqrt, &one, &t)
+	feMul(&den1, &invSqrt, &u1)
+	feMul(&den2, &invSqrt, &u2)
+	feMul(&zInv, &den1, &den2)
+	feMul(&zInv, &zInv, &P.ge.T)
+
+	var x, y, ix, iy, enchanted fieldElement
+	feCopy(&x, &P.ge.X)
+	feCopy(&y, &P.ge.Y)
+	feMul(&ix, &P.ge.X, &sqrtM1)
+	feMul(&iy, &P.ge.Y, &sqrtM1)
+	feMul(&enchanted, &den1, &invSqrtAMinusD)
+	feMul(&t, &P.ge.T, &zInv)
+	rotate := int32(feIsNegative(&t))
+	feCMove(&x, &iy, rotate)
+	feCMove(&y, &ix, rotate)
+	feCMove(&den2, &enchanted, rotate)
+
+	var negY fieldElement
+	feNeg(&negY, &y)
+	feMul(&t, &x, &zInv)
+	feCMove(&y, &negY, int32(feIsNegative(&t)))
+
+	var s fieldElement
+	feSub(&t, &P.ge.Z, &y)
+	feMul(&s, &den2, &t)
+	feAbs(&s, &s)
+	var buf [32]byte
+	feToBytes(&buf, &s)
+	return buf
+}
+
+// RistrettoDecode sets the point p of this package to a representative of the
+// ristretto255 element encoded in buf. It returns an error if the encoding is
+// invalid or not canonical.
+func RistrettoDecode(p kyber.Point, buf []byte) error {
+	P := p.(*point) //nolint:errcheck // V4 may bring better error handling
+	if len(buf) != 32 {
+		return errors.New("invalid ristretto255 encoding length")
+	}
+	var s fieldElement
+	var canonical [32]byte
+	feFromBytes(&s, buf)
+	feToBytes(&canonical, &s)
+	if subtle.ConstantTimeCompare(canonical[:], buf) != 1 || feIsNegative(&s) == 1 {
+		return errors.New("non-canonical ristretto255 encoding")
+	}
+
+	var one, ss, u1, u2, u2Sq, v, t fieldElement
+	feOne(&one)
+	feSquare(&ss, &s)
+	feSub(&u1, &one, &ss)
+	feAdd(&u2, &one, &ss)
+	feSquare(&u2Sq, &u2)
+
+	// v = -(d * u1^2) - u2^2
+	feSquare(&v, &u1)
+	feMul(&v, &v, &d)
+	feNeg(&v, &v)
+	feSub(&v, &v, &u2Sq)
+
+	var invSqrt, denX, denY fieldElement
+	feMul(&t, &v, &u2Sq)
+	wasSquare := feSqrtRatioM1(&invSqrt, &one, &t)
+	feMul(&denX, &invSqrt, &u2)
+	feMul(&denY, &invSqrt, &denX)
+	feMul(&denY, &denY, &v)
+
+	var x, y fieldElement
+	feMul(&x, &s, &denX)
+	feAdd(&x, &x, &x)
+	feAbs(&x, &x)
+	feMul(&y, &u1, &denY)
+	feMul(&t, &x, &y)
+	if wasSquare == 0 || feIsNegative(&t) == 1 || feIsNonZero(&y) == 0 {
+		return errors.New("invalid ristretto255 encoding")
+	}
+	P.ge.X = x
+	P.ge.Y = y
+	feOne(&P.ge.Z)
+	P.ge.T = t
+	return nil
+}
+
+// RistrettoEqual returns true if the points p and q of this package represent
+// the same ristretto255 element.
+func RistrettoEqual(p, q kyber.Point) bool {
+	P := p.(*point) //nolint:errcheck // V4 may bring better error handling
+	Q := q.(*point) //nolint:errcheck // V4 may bring better error handling
+	var a, b fieldElement
+	feMul(&a, &P.ge.X, &Q.ge.Y)
+	feMul(&b, &P.ge.Y, &Q.ge.X)
+	eq := feEqual(&a, &b)
+	feMul(&a, &P.ge.Y, &Q.ge.Y)
+	feMul(&b, &P.ge.X, &Q.ge.X)
+	return eq|feEqual(&a, &b) == 1
+}
+
+// RistrettoFromUniformBytes sets the point p of this package to a
+// representative of the ristretto255 element derived from 64 uniformly random
+// bytes, using the one-way map of RFC 9496.
+func RistrettoFromUniformBytes(p kyber.Point, buf []byte) error {
+	P := p.(*point) //nolint:errcheck // V4 may bring better error handling
+	if len(buf) != 64 {
+		return errors.New("ristretto255 one-way map needs 64 bytes")
+	}
+	var r1, r2 point
+	ristrettoMap(&r1.ge, buf[:32])
+	ristrettoMap(&r2.ge, buf[32:])
+	P.Add(&r1, &r2)
+	return nil
+}
+
+// ristrettoMap is the MAP function of RFC 9496, section 4.3.4.
+func ristrettoMap(p *extendedGroupElement, buf []byte) {
+	var t, one, minusOne fieldElement
+	// the most significant bit is ignored
+	feFromBytes(&t, buf)
+	feOne(&one)
+	feNeg(&minusOne, &one)
+
+	var r, u, v, a, b fieldElement
+	feSquare(&r, &t)
+	feMul(&r, &r, &sqrtM1)
+	feAdd(&u, &r, &one)
+	feMul(&u, &u, &oneMinusDSq)
+	feMul(&a, &r, &d)
+	feSub(&a, &minusOne, &a)
+	feAdd(&b, &r, &d)
+	feMul(&v, &a, &b)
+
+	var s, sPrime, c fieldElement
+	wasSquare := feSqrtRatioM1(&s, &u, &v)
+	feMul(&sPrime, &s, &t)
+	feAbs(&sPrime, &sPrime)
+	feNeg(&sPrime, &sPrime)
+	feCMove(&s, &sPrime, 1-wasSquare)
+	feCopy(&c, &r)
+	feCMove(&c, &minusOne, wasSquare)
+
+	// N = c * (r - 1) * (d - 1)^2 - v
+	var n fieldElement
+	feSub(&a, &r, &one)
+	feMul(&n, &c, &a)
+	feMul(&n, &n, &dMinusOneSq)
+	feSub(&n, &n, &v)
+
+	var w0, w1, w2, w3, ss fieldElement
+	feMul(&w0, &s, &v)
+	feAdd(&w0, &w0, &w0)
+	feMul(&w1, &n, &sqrtADMinusOne)
+	feSquare(&ss, &s)
+	feSub(&w2, &one, &ss)
+	feAdd(&w3, &one, &ss)
+
+	feMul(&p.X, &w0, &w3)
+	feMul(&p.Y, &w2, &w1)
+	feMul(&p.Z, &w1, &w3)
+	feMul(&p.T, &w0, &w2)
+}
@@ -0,0 +1,334 @@
+// Package field implements constant time arithmetic in the prime fields of
+// at most 256 bits, used by the curves that need constant time
+// implementations of their base and scalar fields.
+//
+// The elements are stored in the Montgomery domain as four 64-bit words, and
+// the operations are written in the style of the code generated by
+// fiat-crypto: the words are combined with math/bits primitives and the
+// reductions are done with masks, without any branch or memory access that
+// depends on the values of the elements. The methods allow their arguments
+// to alias.
+package field
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// Size is the size in bytes of the encoding of the elements.
+const Size = 32
+
+// Element is an element of a Field, in the Montgomery domain. Its zero value
+// is the zero of any field.
+type Element [4]uint64
+
+// Field holds the constants of the arithmetic modulo an odd prime.
+type Field struct {
+	modulus *big.Int
+	p       Element
+	// -p^-1 mod 2^64
+	n0 uint64
+	// R, R^2 and R^3 modulo p, where R = 2^256
+	r1, r2, r3 Element
+	// exponents used for the inversions and the square roots
+	pMinus2, sqrtExp Element
+}
+
+// New returns the field of the integers modulo the odd prime p, which must be
+// smaller than 2^256.
+func New(p *big.Int) *Field {
+	if p.BitLen() > 256 || p.Bit(0) == 0 {
+		panic("field: invalid modulus")
+	}
+	f := &Field{modulus: new(big.Int).Set(p)}
+	f.p = fromBig(p)
+
+	// Newton iteration for the inverse of p modulo 2^64
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - f.p[0]*inv
+	}
+	f.n0 = -inv
+
+	R := new(big.Int).Lsh(big.NewInt(1), 256)
+	r := new(big.Int).Mod(R, p)
+	f.r1 = fromBig(r)
+	r.Mul(r, R).Mod(r, p)
+	f.r2 = fromBig(r)
+	r.Mul(r, R).Mod(r, p)
+	f.r3 = fromBig(r)
+
+	f.pMinus2 = fromBig(new(big.Int).Sub(p, big.NewInt(2)))
+	// (p+1)/4, the square root exponent when p = 3 mod 4
+	f.sqrtExp = fromBig(new(big.Int).Rsh(new(big.Int).Add(p, big.NewInt(1)), 2))
+	return f
+}
+
+func fromBig(n *big.Int) Element {
+	var b [Size]byte
+	n.FillBytes(b[:])
+	return fromBytes(&b)
+}
+
+func fromBytes(b *[Size]byte) Element {
+	return Element{
+		binary.BigEndian.Uint64(b[24:]),
+		binary.BigEndian.Uint64(b[16:]),
+		binary.BigEndian.Uint64(b[8:]),
+		binary.BigEndian.Uint64(b[:]),
+	}
+}
+
+// Modulus returns the modulus of the field.
+func (f *Field) Modulus() *big.Int {
+	return new(big.Int).Set(f.modulus)
+}
+
+// BitLen returns the bit length of the modulus.
+func (f *Field) BitLen() int {
+	return f.modulus.BitLen()
+}
+
+// selectWords sets z to x if cond is 1 and to y if cond is 0.
+func selectWords(z, x, y *Element, cond uint64) {
+	mask := -cond
+	for i := range z {
+		z[i] = (x[i] & mask) | (y[i] &^ mask)
+	}
+}
+
+// reduce sets z to (carry:x) mod p, for a value smaller than 2p.
+func (f *Field) reduce(z, x *Element, carry uint64) {
+	var r Element
+	var b uint64
+	r[0], b = bits.Sub64(x[0], f.p[0], 0)
+	r[1], b = bits.Sub64(x[1], f.p[1], b)
+	r[2], b = bits.Sub64(x[2], f.p[2], b)
+	r[3], b = bits.Sub64(x[3], f.p[3], b)
+	_, b = bits.Sub64(carry, 0, b)
+	// a borrow means that the value was already reduced
+	selectWords(z, x, &r, b)
+}
+
+// Zero sets z to 0.
+func (f *Field) Zero(z *Element) {
+	*z = Element{}
+}
+
+// One sets z to 1.
+func (f *Field) One(z *Element) {
+	*z = f.r1
+}
+
+// SetInt64 sets z to v modulo p.
+func (f *Field) SetInt64(z *Element, v int64) {
+	n := new(big.Int).Mod(big.NewInt(v), f.modulus)
+	f.SetBig(z, n)
+}
+
+// SetBig sets z to n modulo p.
+func (f *Field) SetBig(z *Element, n *big.Int) {
+	if n.Sign() < 0 || n.Cmp(f.modulus) >= 0 {
+		n = new(big.Int).Mod(n, f.modulus)
+	}
+	e := fromBig(n)
+	f.Mul(z, &e, &f.r2)
+}
+
+// Big returns the value of x as a big.Int.
+func (f *Field) Big(x *Element) *big.Int {
+	b := f.Bytes(x)
+	return new(big.Int).SetBytes(b[:])
+}
+
+// SetBytes sets z to the big-endian integer b, which must be smaller than p
+// and encoded on Size bytes. It returns 1 on success, and 0 otherwise, in
+// which case z is left unchanged.
+func (f *Field) SetBytes(z *Element, b []byte) int {
+	if len(b) != Size {
+		return 0
+	}
+	e := fromBytes((*[Size]byte)(b))
+	var b0 uint64
+	_, b0 = bits.Sub64(e[0], f.p[0], 0)
+	_, b0 = bits.Sub64(e[1], f.p[1], b0)
+	_, b0 = bits.Sub64(e[2], f.p[2], b0)
+	_, b0 = bits.Sub64(e[3], f.p[3], b0)
+	// the value is smaller than p if the subtraction borrows
+	var m Element
+	f.Mul(&m, &e, &f.r2)
+	selectWords(z, &m, z, b0)
+	return int(b0)
+}
+
+// SetWideBytes sets z to the big-endian integer b modulo p. The input can be
+// up to 2*Size bytes long, so that reducing uniformly random bytes gives a
+// negligible bias.
+func (f *Field) SetWideBytes(z *Element, b []byte) {
+	if len(b) > 2*Size {
+		f.SetBig(z, new(big.Int).SetBytes(b))
+		return
+	}
+	var buf [2 * Size]byte
+	copy(buf[2*Size-len(b):], b)
+	hi := fromBytes((*[Size]byte)(buf[:Size]))
+	lo := fromBytes((*[Size]byte)(buf[Size:]))
+	// The Montgomery multiplication of any 256-bit value by R^2 or R^3 is
+	// reduced, so hi*R^2 + lo*R is (hi*2^256 + lo) in the Montgomery domain.
+	f.Mul(&hi, &hi, &f.r3)
+	f.Mul(&lo, &lo, &f.r2)
+	f.Add(z, &hi, &lo)
+}
+
+// Bytes returns the big-endian encoding of x on Size bytes.
+func (f *Field) Bytes(x *Element) [Size]byte {
+	var e Element
+	f.Mul(&e, x, &Element{1})
+	var b [Size]byte
+	binary.BigEndian.PutUint64(b[:], e[3])
+	binary.BigEndian.PutUint64(b[8:], e[2])
+	binary.BigEndian.PutUint64(b[16:], e[1])
+	binary.BigEndian.PutUint64(b[24:], e[0])
+	return b
+}
+
+// Add sets z = x + y.
+func (f *Field) Add(z, x, y *Element) {
+	var s Element
+	var c uint64
+	s[0], c = bits.Add64(x[0], y[0], 0)
+	s[1], c = bits.Add64(x[1], y[1], c)
+	s[2], c = bits.Add64(x[2], y[2], c)
+	s[3], c = bits.Add64(x[3], y[3], c)
+	f.reduce(z, &s, c)
+}
+
+// Sub sets z = x - y.
+func (f *Field) Sub(z, x, y *Element) {
+	var d, e Element
+	var b, c uint64
+	d[0], b = bits.Sub64(x[0], y[0], 0)
+	d[1], b = bits.Sub64(x[1], y[1], b)
+	d[2], b = bits.Sub64(x[2], y[2], b)
+	d[3], b = bits.Sub64(x[3], y[3], b)
+	// add p back if the subtraction borrowed
+	mask := -b
+	e[0], c = bits.Add64(d[0], f.p[0]&mask, 0)
+	e[1], c = bits.Add64(d[1], f.p[1]&mask, c)
+	e[2], c = bits.Add64(d[2], f.p[2]&mask, c)
+	e[3], _ = bits.Add64(d[3], f.p[3]&mask, c)
+	*z = e
+}
+
+// Neg sets z = -x.
+func (f *Field) Neg(z, x *Element) {
+	f.Sub(z, &Element{}, x)
+}
+
+// Mul sets z = x * y, with the CIOS Montgomery multiplication.
+func (f *Field) Mul(z, x, y *Element) {
+	var t [6]uint64
+	for i := 0; i < 4; i++ {
+		// t += x * y[i]
+		var c uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(x[j], y[i])
+			var c1 uint64
+			lo, c1 = bits.Add64(lo, t[j], 0)
+			hi += c1
+			lo, c1 = bits.Add64(lo, c, 0)
+			hi += c1
+			t[j] = lo
+			c = hi
+		}
+		t[4], c = bits.Add64(t[4], c, 0)
+		t[5] = c
+
+		// t = (t + m*p) / 2^64
+		m := t[0] * f.n0
+		hi, lo := bits.Mul64(m, f.p[0])
+		_, c1 := bits.Add64(lo, t[0], 0)
+		c = hi + c1
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(m, f.p[j])
+			lo, c1 = bits.Add64(lo, t[j], 0)
+			hi += c1
+			lo, c1 = bits.Add64(lo, c, 0)
+			hi += c1
+			t[j-1] = lo
+			c = hi
+		}
+		t[3], c1 = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + c1
+	}
+	f.reduce(z, (*Element)(t[:4]), t[4])
+}
+
+// Square sets z = x * x.
+func (f *Field) Square(z, x *Element) {
+	f.Mul(z, x, x)
+}
+
+// exp sets z = x^e for a public exponent e.
+func (f *Field) exp(z, x, e *Element) {
+	var r Element
+	base := *x
+	f.One(&r)
+	for i := 255; i >= 0; i-- {
+		f.Square(&r, &r)
+		if (e[i/64]>>(i%64))&1 == 1 {
+			f.Mul(&r, &r, &base)
+		}
+	}
+	*z = r
+}
+
+// Inv sets z = 1/x, or 0 if x is 0.
+func (f *Field) Inv(z, x *Element) {
+	f.exp(z, x, &f.pMinus2)
+}
+
+// Sqrt sets z to a square root of x and returns 1 if x is a square. Otherwise,
+// it returns 0 and z is left unchanged. The modulus must be 3 mod 4.
+func (f *Field) Sqrt(z, x *Element) int {
+	if f.p[0]&3 != 3 {
+		panic("field: square root needs a modulus equal to 3 mod 4")
+	}
+	var r, check Element
+	f.exp(&r, x, &f.sqrtExp)
+	f.Square(&check, &r)
+	ok := f.Equal(&check, x)
+	selectWords(z, &r, z, uint64(ok))
+	return ok
+}
+
+// Select sets z to x if cond is 1 and to y if cond is 0.
+func (f *Field) Select(z, x, y *Element, cond int) {
+	selectWords(z, x, y, uint64(cond))
+}
+
+// Equal returns 1 if x == y and 0 otherwise.
+func (f *Field) Equal(x, y *Element) int {
+	var acc uint64
+	for i := range x {
+		acc |= x[i] ^ y[i]
+	}
+	return isZero(acc)
+}
+
+// IsZero returns 1 if x == 0 and 0 otherwise.
+func (f *Field) IsZero(x *Element) int {
+	return f.Equal(x, &Element{})
+}
+
+// IsOdd returns 1 if the integer representing x is odd and 0 otherwise.
+func (f *Field) IsOdd(x *Element) int {
+	b := f.Bytes(x)
+	return int(b[Size-1] & 1)
+}
+
+func isZero(x uint64) int {
+	// the top bit of x | -x is set if and only if x is not zero
+	return int(1 ^ ((x | -x) >> 63))
+}
@@ -0,0 +1,100 @@
+package field
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var moduli = []string{
+	// secp256k1 base field
+	"fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+	// P-256 base field and order
+	"ffffffff00000001000000000000000000000000ffffffffffffffffffffffff",
+	"ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551",
+	// 2^255 - 19, smaller than 2^255
+	"7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed",
+}
+
+func randomElement(t *testing.T, f *Field) (*big.Int, Element) {
+	n, err := rand.Int(rand.Reader, f.modulus)
+	require.NoError(t, err)
+	var e Element
+	f.SetBig(&e, n)
+	return n, e
+}
+
+func TestArithmetic(t *testing.T) {
+	for _, m := range moduli {
+		p, _ := new(big.Int).SetString(m, 16)
+		f := New(p)
+		for i := 0; i < 100; i++ {
+			a, x := randomElement(t, f)
+			b, y := randomElement(t, f)
+			var z Element
+			exp := new(big.Int)
+
+			f.Add(&z, &x, &y)
+			require.Equal(t, exp.Add(a, b).Mod(exp, p), f.Big(&z))
+			f.Sub(&z, &x, &y)
+			require.Equal(t, exp.Sub(a, b).Mod(exp, p), f.Big(&z))
+			f.Neg(&z, &x)
+			require.Equal(t, exp.Neg(a).Mod(exp, p), f.Big(&z))
+			f.Mul(&z, &x, &y)
+			require.Equal(t, exp.Mul(a, b).Mod(exp, p), f.Big(&z))
+			f.Inv(&z, &x)
+			require.Equal(t, exp.ModInverse(a, p), f.Big(&z))
+
+			// aliasing
+			z = x
+			f.Mul(&z, &z, &z)
+			require.Equal(t, exp.Mul(a, a).Mod(exp, p), f.Big(&z))
+
+			var wide [64]byte
+			_, err := rand.Read(wide[:])
+			require.NoError(t, err)
+			f.SetWideBytes(&z, wide[:])
+			require.Equal(t, exp.SetBytes(wide[:]).Mod(exp, p), f.Big(&z))
+		}
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	p, _ := new(big.Int).SetString(moduli[0], 16)
+	f := New(p)
+	for i := 0; i < 50; i++ {
+		_, x := randomElement(t, f)
+		var sq, r Element
+		f.Square(&sq, &x)
+		require.Equal(t, 1, f.Sqrt(&r, &sq))
+		f.Square(&r, &r)
+		require.Equal(t, 1, f.Equal(&r, &sq))
+	}
+	// -1 is not a square modulo a prime equal to 3 mod 4
+	var minusOne, r Element
+	f.One(&minusOne)
+	f.Neg(&minusOne, &minusOne)
+	require.Equal(t, 0, f.Sqrt(&r, &minusOne))
+	require.Equal(t, 1, f.IsZero(&r))
+}
+
+func TestBytes(t *testing.T) {
+	p, _ := new(big.Int).SetString(moduli[0], 16)
+	f := New(p)
+	a, x := randomElement(t, f)
+	b := f.Bytes(&x)
+	require.Equal(t, a, new(big.Int).SetBytes(b[:]))
+
+	var y Element
+	require.Equal(t, 1, f.SetBytes(&y, b[:]))
+	require.Equal(t, 1, f.Equal(&x, &y))
+
+	// non-canonical encodings are rejected
+	var pb [Size]byte
+	p.FillBytes(pb[:])
+	require.Equal(t, 0, f.SetBytes(&y, pb[:]))
+	require.Equal(t, 1, f.Equal(&x, &y))
+	require.Equal(t, 0, f.SetBytes(&y, b[1:]))
+}
@@ -10,6 +10,7 @@ import (
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/internal/marshalling"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/util/msm"
 	"go.dedis.ch/kyber/v4/util/random"
 )
 
@@ -59,13 +60,7 @@ func (P *curvePoint) Valid() bool {
 // with a random sign.
 func (P *curvePoint) genPoint(x *big.Int, rand cipher.Stream) bool {
 	// Compute the corresponding Y coordinate, if any
-	y2 := new(big.Int).Mul(x, x)
-	y2.Mul(y2, x)
-	threeX := new(big.Int).Lsh(x, 1)
-	threeX.Add(threeX, x)
-	y2.Sub(y2, threeX)
-	y2.Add(y2, P.c.p.B)
-	y2.Mod(y2, P.c.p.P)
+	y2 := P.c.rhs(x)
 	y := P.c.sqrt(y2)
 
 	// Pick a random sign for the y coordinate
@@ -166,6 +161,62 @@ func (P *curvePoint) Mul(s kyber.Scalar, B kyber.Point) kyber.Point {
 	return P
 }
 
+// MultiScalarMul sets P to the sum of scalars[i] * points[i], computed with
+// Pippenger's bucket method on Jacobian coordinates in the Montgomery domain,
+// so that a single field inversion is needed. It runs in variable time.
+func (P *curvePoint) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	msm.CheckLengths(scalars, points)
+	f := newMontField(P.c.p.P)
+	var acc jacobianPoint
+	if len(points) == 0 {
+		P.x, P.y = f.affine(&acc, P.c.p.P)
+		return P
+	}
+
+	// the affine points in the Montgomery domain, nil for the point at
+	// infinity
+	xs, ys := make([]*felem, len(points)), make([]*felem, len(points))
+	for i := range points {
+		cp := points[i].(*curvePoint) //nolint:errcheck // V4 may bring better error handling
+		if cp.x.Sign() != 0 || cp.y.Sign() != 0 {
+			x, y := f.toMont(cp.x), f.toMont(cp.y)
+			xs[i], ys[i] = &x, &y
+		}
+	}
+
+	c := msm.WindowSize(len(points), P.c.p.N.BitLen())
+	digits := msm.Digits(scalars, c)
+	buckets := make([]jacobianPoint, 1<<c-1)
+	var sum, window jacobianPoint
+
+	for w := len(digits[0]) - 1; w >= 0; w-- {
+		for i := 0; i < c; i++ {
+			f.pointDouble(&acc, &acc)
+		}
+
+		for k := range buckets {
+			buckets[k] = jacobianPoint{}
+		}
+		for i, d := range digits {
+			if k := d[w]; k != 0 && xs[i] != nil {
+				f.pointAddAffine(&buckets[k-1], &buckets[k-1], xs[i], ys[i])
+			}
+		}
+
+		// window = sum_k k * buckets[k-1], computed with running sums
+		sum, window = jacobianPoint{}, jacobianPoint{}
+		for k := len(buckets) - 1; k >= 0; k-- {
+			f.pointAdd(&sum, &sum, &buckets[k])
+			f.pointAdd(&window, &window, &sum)
+		}
+
+		f.pointAdd(&acc, &acc, &window)
+	}
+	// P is only written now, as it may be one of the points
+	P.x, P.y = f.affine(&acc, P.c.p.P)
+	return P
+}
+
 func (P *curvePoint) MarshalSize() int {
 	coordlen := (P.c.Params().BitSize + 7) >> 3
 	return 1 + 2*coordlen // uncompressed ANSI X9.62 representation
@@ -176,6 +227,9 @@ func (P *curvePoint) MarshalBinary() ([]byte, error) {
 }
 
 func (P *curvePoint) UnmarshalBinary(buf []byte) error {
+	if len(buf) != P.MarshalSize() {
+		return errors.New("invalid elliptic curve point length")
+	}
 	// Check whether all bytes after first one are 0, so we
 	// just return the initial point. Read everything to
 	// prevent timing-leakage.
@@ -215,6 +269,8 @@ type curve struct {
 	elliptic.Curve
 	curveOps
 	p *elliptic.CurveParams
+	// h2c is the hash_to_curve suite of the curve, which every curve sets
+	h2c *hashToCurve
 }
 
 // Return the number of bytes in the encoding of a Scalar for this curve.
@@ -1,3 +1,3 @@
-// Package p256 implements the P-256 elliptic curve
+// Package p256 implements the P-256, P-384 and P-521 elliptic curves
 // based on the NIST standard.
 package p256
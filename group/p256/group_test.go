@@ -1,8 +1,14 @@
 package p256
 
 import (
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/random"
 	"go.dedis.ch/kyber/v4/util/test"
 )
 
@@ -14,6 +20,230 @@ var testP256 = NewBlakeSHA256P256()
 
 func TestP256(t *testing.T) { test.SuiteTest(t, testP256) }
 
+var testP384 = NewBlakeSHA384P384()
+
+func TestP384(t *testing.T) { test.SuiteTest(t, testP384) }
+
+var testP521 = NewBlakeSHA512P521()
+
+func TestP521(t *testing.T) { test.SuiteTest(t, testP521) }
+
+func TestInvalidPoints(t *testing.T) {
+	for _, g := range []kyber.Group{testP256, testP384, testP521} {
+		buf, err := g.Point().Pick(random.New()).MarshalBinary()
+		require.NoError(t, err)
+
+		// off the curve
+		buf[len(buf)-1] ^= 1
+		require.Error(t, g.Point().UnmarshalBinary(buf), g.String())
+		// truncated
+		require.Error(t, g.Point().UnmarshalBinary(buf[:len(buf)-1]), g.String())
+		require.Error(t, g.Point().UnmarshalBinary(nil), g.String())
+	}
+}
+
+// RFC 9380, appendices J.3.1 and J.4.1.
+var hashVectors = []struct {
+	g         kyber.Group
+	dst       string
+	msg, x, y string
+}{
+	{
+		testP384, "QUUX-V01-CS02-with-P384_XMD:SHA-384_SSWU_RO_", "",
+		"eb9fe1b4f4e14e7140803c1d99d0a93cd823d2b024040f9c067a8eca1f5a2eeac9ad604973527a356f3fa3aeff0e4d83",
+		"0c21708cff382b7f4643c07b105c2eaec2cead93a917d825601e63c8f21f6abd9abc22c93c2bed6f235954b25048bb1a",
+	},
+	{
+		testP384, "QUUX-V01-CS02-with-P384_XMD:SHA-384_SSWU_RO_", "abc",
+		"e02fc1a5f44a7519419dd314e29863f30df55a514da2d655775a81d413003c4d4e7fd59af0826dfaad4200ac6f60abe1",
+		"01f638d04d98677d65bef99aef1a12a70a4cbb9270ec55248c04530d8bc1f8f90f8a6a859a7c1f1ddccedf8f96d675f6",
+	},
+	{
+		testP521, "QUUX-V01-CS02-with-P521_XMD:SHA-512_SSWU_RO_", "",
+		"00fd767cebb2452030358d0e9cf907f525f50920c8f607889a6a35680727f64f4d66b161fafeb2654bea0d35086bec0a10b3" +
+			"0b14adef3556ed9f7f1bc23cecc9c088",
+		"0169ba78d8d851e930680322596e39c78f4fe31b97e57629ef6460ddd68f8763fd7bd767a4e94a80d3d21a3c2ee98347e024" +
+			"fc73ee1c27166dc3fe5eeef782be411d",
+	},
+	{
+		testP521, "QUUX-V01-CS02-with-P521_XMD:SHA-512_SSWU_RO_", "abc",
+		"002f89a1677b28054b50d15e1f81ed6669b5a2158211118ebdef8a6efc77f8ccaa528f698214e4340155abc1fa08f8f613ef" +
+			"14a043717503d57e267d57155cf784a4",
+		"010e0be5dc8e753da8ce51091908b72396d3deed14ae166f66d8ebf0a4e7059ead169ea4bead0232e9b700dd380b316e9361" +
+			"cfdba55a08c73545563a80966ecbb86d",
+	},
+}
+
+func TestMultiScalarMul(t *testing.T) {
+	for _, g := range []kyber.Group{testP256, testP384, testP521} {
+		t.Run(g.String(), func(t *testing.T) {
+			_, ok := g.Point().(kyber.MultiScalarMultiplier)
+			require.True(t, ok)
+
+			scalars := make([]kyber.Scalar, 9)
+			points := make([]kyber.Point, 9)
+			expected := g.Point().Null()
+			for i := range points {
+				scalars[i] = g.Scalar().Pick(random.New())
+				points[i] = g.Point().Pick(random.New())
+			}
+			scalars[1].Zero()
+			scalars[2].One().Neg(scalars[2])
+			points[3].Null()
+			points[4].Set(points[0])
+			points[6].Neg(points[7])
+			scalars[6].Set(scalars[7])
+			for i := range points {
+				expected.Add(expected, g.Point().Mul(scalars[i], points[i]))
+			}
+
+			P := g.Point().(kyber.MultiScalarMultiplier).MultiScalarMul(scalars, points)
+			require.True(t, expected.Equal(P))
+			// the receiver may be one of the points
+			P = points[5].(kyber.MultiScalarMultiplier).MultiScalarMul(scalars, points)
+			require.True(t, expected.Equal(P))
+			require.True(t, g.Point().Null().Equal(
+				g.Point().(kyber.MultiScalarMultiplier).MultiScalarMul(nil, nil)))
+		})
+	}
+}
+
+func TestHashToCurve(t *testing.T) {
+	for _, v := range hashVectors {
+		P := v.g.Point().(kyber.HashablePointWithDST).Hash2([]byte(v.msg), []byte(v.dst)).(*curvePoint)
+		l := P.c.coordLen()
+		require.Equal(t, v.x, hex.EncodeToString(P.x.FillBytes(make([]byte, l))), v.dst)
+		require.Equal(t, v.y, hex.EncodeToString(P.y.FillBytes(make([]byte, l))), v.dst)
+		require.True(t, P.Valid())
+	}
+
+	for _, g := range []kyber.Group{testP384, testP521} {
+		msg := []byte("message")
+		p1 := g.Point().(kyber.HashablePoint).Hash(msg)
+		p2 := g.Point().(kyber.HashablePointWithDST).Hash2(msg, p1.(*curvePoint).c.h2c.domain)
+		require.True(t, p1.Equal(p2))
+		p3 := g.Point().(kyber.HashablePointWithDST).Hash2(msg, []byte("other DST"))
+		require.False(t, p1.Equal(p3))
+	}
+}
+
+// TestP256Elliptic compares the constant time arithmetic and the encodings of
+// P-256 with the ones of Go's elliptic package.
+func TestP256Elliptic(t *testing.T) {
+	c := elliptic.P256()
+	rand := random.New()
+	for i := 0; i < 20; i++ {
+		s := testP256.Scalar().Pick(rand)
+		k, err := s.MarshalBinary()
+		require.NoError(t, err)
+
+		P := testP256.Point().Mul(s, nil)
+		x, y := c.ScalarBaseMult(k)
+		buf, err := P.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, elliptic.Marshal(c, x, y), buf)
+
+		Q := testP256.Point().Pick(rand)
+		buf, err = Q.MarshalBinary()
+		require.NoError(t, err)
+		qx, qy := elliptic.Unmarshal(c, buf)
+		require.NotNil(t, qx)
+
+		x, y = c.ScalarMult(qx, qy, k)
+		buf, err = P.Mul(s, Q).MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, elliptic.Marshal(c, x, y), buf)
+
+		x, y = c.Add(x, y, qx, qy)
+		buf, err = P.Add(P, Q).MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, elliptic.Marshal(c, x, y), buf)
+	}
+
+	// the point at infinity is encoded with zero coordinates
+	buf, err := testP256.Point().Null().MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, elliptic.Marshal(c, new(big.Int), new(big.Int)), buf)
+	O := testP256.Point().Base()
+	require.NoError(t, O.UnmarshalBinary(buf))
+	require.True(t, O.Equal(testP256.Point().Null()))
+
+	// the order is not a valid scalar encoding
+	require.Error(t, testP256.Scalar().UnmarshalBinary(c.Params().N.Bytes()))
+}
+
+// RFC 9380, appendices J.1.1 and J.1.2.
+var p256HashVectors = []struct {
+	nu        bool
+	msg, x, y string
+}{
+	{
+		false, "",
+		"2c15230b26dbc6fc9a37051158c95b79656e17a1a920b11394ca91c44247d3e4",
+		"8a7a74985cc5c776cdfe4b1f19884970453912e9d31528c060be9ab5c43e8415",
+	},
+	{
+		false, "abc",
+		"0bb8b87485551aa43ed54f009230450b492fead5f1cc91658775dac4a3388a0f",
+		"5c41b3d0731a27a7b14bc0bf0ccded2d8751f83493404c84a88e71ffd424212e",
+	},
+	{
+		false, "abcdef0123456789",
+		"65038ac8f2b1def042a5df0b33b1f4eca6bff7cb0f9c6c1526811864e544ed80",
+		"cad44d40a656e7aff4002a8de287abc8ae0482b5ae825822bb870d6df9b56ca3",
+	},
+	{
+		true, "",
+		"f871caad25ea3b59c16cf87c1894902f7e7b2c822c3d3f73596c5ace8ddd14d1",
+		"87b9ae23335bee057b99bac1e68588b18b5691af476234b8971bc4f011ddc99b",
+	},
+	{
+		true, "abc",
+		"fc3f5d734e8dce41ddac49f47dd2b8a57257522a865c124ed02b92b5237befa4",
+		"fe4d197ecf5a62645b9690599e1d80e82c500b22ac705a0b421fac7b47157866",
+	},
+	{
+		true, "abcdef0123456789",
+		"f164c6674a02207e414c257ce759d35eddc7f55be6d7f415e2cc177e5d8faa84",
+		"3aa274881d30db70485368c0467e97da0e73c18c1d00f34775d012b6fcee7f97",
+	},
+}
+
+func TestP256HashToCurve(t *testing.T) {
+	for _, v := range p256HashVectors {
+		var P kyber.Point
+		if v.nu {
+			dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_NU_")
+			P = testP256.Point().(kyber.EncodablePoint).EncodeToCurve([]byte(v.msg), dst)
+		} else {
+			dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+			P = testP256.Point().(kyber.HashablePointWithDST).Hash2([]byte(v.msg), dst)
+		}
+		buf, err := P.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, "04"+v.x+v.y, hex.EncodeToString(buf), v.msg)
+	}
+
+	msg := []byte("message")
+	p1 := testP256.Point().(kyber.HashablePoint).Hash(msg)
+	p2 := testP256.Point().(kyber.HashablePointWithDST).Hash2(msg, p256DomainRO)
+	require.True(t, p1.Equal(p2))
+	p3 := testP256.Point().(kyber.EncodablePoint).EncodeToCurve(msg, nil)
+	require.False(t, p1.Equal(p3))
+}
+
+func TestResidueHash(t *testing.T) {
+	msg := []byte("message")
+	p1 := testQR512.Point().(kyber.HashablePoint).Hash(msg).(*residuePoint)
+	require.True(t, p1.Valid())
+	p2 := testQR512.Point().(kyber.HashablePoint).Hash(msg)
+	require.True(t, p1.Equal(p2))
+
+	p3 := testQR512.Point().(kyber.HashablePointWithDST).Hash2(msg, []byte("other DST"))
+	require.True(t, p3.(*residuePoint).Valid())
+	require.False(t, p1.Equal(p3))
+}
+
 func TestSetBytesBE(t *testing.T) {
 	s := testP256.Scalar()
 	s.SetBytes([]byte{0, 1, 2, 3})
@@ -44,3 +274,29 @@ func BenchmarkPointBaseMul(b *testing.B) { benchP256.PointBaseMul(b.N) }
 func BenchmarkPointPick(b *testing.B)    { benchP256.PointPick(b.N) }
 func BenchmarkPointEncode(b *testing.B)  { benchP256.PointEncode(b.N) }
 func BenchmarkPointDecode(b *testing.B)  { benchP256.PointDecode(b.N) }
+
+// BenchmarkMultiScalarMul compares the multi-scalar multiplication of 256
+// terms with the sum of as many scalar multiplications.
+func BenchmarkMultiScalarMul(b *testing.B) {
+	for _, g := range []kyber.Group{testP256, testP384, testP521} {
+		scalars := make([]kyber.Scalar, 256)
+		points := make([]kyber.Point, 256)
+		for i := range points {
+			scalars[i] = g.Scalar().Pick(random.New())
+			points[i] = g.Point().Pick(random.New())
+		}
+		b.Run(g.String()+"/msm", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.Point().(kyber.MultiScalarMultiplier).MultiScalarMul(scalars, points)
+			}
+		})
+		b.Run(g.String()+"/naive", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sum, term := g.Point().Null(), g.Point()
+				for j := range points {
+					sum.Add(sum, term.Mul(scalars[j], points[j]))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package p256
+
+import (
+	"crypto/sha256"
+	"hash"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/internal/field"
+	"go.dedis.ch/kyber/v4/internal/xmd"
+)
+
+// The suite IDs of RFC 9380 for P-256, used as the default domain separation
+// tags of the random oracle hash_to_curve and of the nonuniform
+// encode_to_curve.
+var (
+	p256DomainRO = []byte("P256_XMD:SHA-256_SSWU_RO_")
+	p256DomainNU = []byte("P256_XMD:SHA-256_SSWU_NU_")
+)
+
+// the constants of the simplified SWU map for P-256: Z, -B/A and B/(Z*A)
+var p256Z, p256SSWUC1, p256SSWUC2 field.Element
+
+func initP256Hash() {
+	var a field.Element
+	fp.SetInt64(&a, -3)
+	fp.SetInt64(&p256Z, -10)
+
+	fp.Inv(&p256SSWUC1, &a)
+	fp.Mul(&p256SSWUC1, &p256SSWUC1, &p256CurveB)
+	fp.Neg(&p256SSWUC1, &p256SSWUC1)
+	fp.Mul(&p256SSWUC2, &p256Z, &a)
+	fp.Inv(&p256SSWUC2, &p256SSWUC2)
+	fp.Mul(&p256SSWUC2, &p256SSWUC2, &p256CurveB)
+}
+
+// hashToCurve holds the parameters of the RFC 9380 suite used to hash to a
+// curve with the simplified SWU map.
+type hashToCurve struct {
+	// domain is the suite ID, used as the default domain separation tag
+	domain []byte
+	hash   func() hash.Hash
+	// k is the security level in bits
+	k int
+	// z is the non-square constant of the map
+	z *big.Int
+}
+
+// sqrt3Mod4 returns a square root of c modulo p = 3 mod 4, if there is one.
+func sqrt3Mod4(c, p *big.Int) *big.Int {
+	e := new(big.Int).Add(p, one)
+	e.Rsh(e, 2)
+	return new(big.Int).Exp(c, e, p)
+}
+
+// Hash hashes the message to a point with the default domain separation tag,
+// see Hash2.
+func (P *curvePoint) Hash(m []byte) kyber.Point {
+	return P.Hash2(m, nil)
+}
+
+// Hash2 hashes the message to a point with the hash_to_curve suite of the
+// curve in RFC 9380, like P384_XMD:SHA-384_SSWU_RO_, and the domain
+// separation tag dst. An empty dst is replaced by the suite ID, since tags
+// must not be empty.
+func (P *curvePoint) Hash2(m, dst []byte) kyber.Point {
+	h2c := P.c.h2c
+	if len(dst) == 0 {
+		dst = h2c.domain
+	}
+	// never fails with a non-empty dst and two elements
+	u, _ := xmd.HashToField(h2c.hash, m, dst, P.c.p.P, h2c.k, 2)
+	x0, y0 := P.c.mapToCurve(u[0])
+	x1, y1 := P.c.mapToCurve(u[1])
+	// the cofactor of the NIST curves is 1
+	P.x, P.y = P.c.Add(x0, y0, x1, y1)
+	return P
+}
+
+// mapToCurve returns the image of u by the simplified SWU map of RFC 9380,
+// section 6.6.2, for the curves y^2 = x^3 - 3x + b. It runs in variable time,
+// like the rest of the arithmetic of these curves.
+func (c *curve) mapToCurve(u *big.Int) (*big.Int, *big.Int) {
+	p := c.p.P
+	a := big.NewInt(-3)
+	z := c.h2c.z
+
+	// tv1 = inv0(Z^2*u^4 + Z*u^2)
+	zu2 := new(big.Int).Mul(u, u)
+	zu2.Mul(zu2, z).Mod(zu2, p)
+	tv1 := new(big.Int).Mul(zu2, zu2)
+	tv1.Add(tv1, zu2).Mod(tv1, p)
+	if tv1.Sign() != 0 {
+		tv1.ModInverse(tv1, p)
+	}
+
+	// x1 = -B/A * (1 + tv1), or B/(Z*A) if tv1 is 0
+	x1 := new(big.Int)
+	if tv1.Sign() == 0 {
+		x1.Mul(z, a)
+		x1.ModInverse(x1.Mod(x1, p), p)
+		x1.Mul(x1, c.p.B)
+	} else {
+		x1.ModInverse(new(big.Int).Mod(a, p), p)
+		x1.Mul(x1, c.p.B).Neg(x1)
+		x1.Mul(x1, tv1.Add(tv1, one))
+	}
+	x1.Mod(x1, p)
+
+	x, y := x1, c.rhs(x1)
+	if !c.isSquare(y) {
+		// x2 = Z*u^2*x1
+		x = new(big.Int).Mul(zu2, x1)
+		x.Mod(x, p)
+		y = c.rhs(x)
+	}
+	y = c.sqrt(y)
+	y.Mod(y, p)
+
+	// the sign of y must match the sign of u
+	if u.Bit(0) != y.Bit(0) && y.Sign() != 0 {
+		y.Sub(p, y)
+	}
+	return x, y
+}
+
+// rhs returns x^3 - 3x + b.
+func (c *curve) rhs(x *big.Int) *big.Int {
+	y2 := new(big.Int).Mul(x, x)
+	y2.Mul(y2, x)
+	threeX := new(big.Int).Lsh(x, 1)
+	threeX.Add(threeX, x)
+	y2.Sub(y2, threeX)
+	y2.Add(y2, c.p.B)
+	return y2.Mod(y2, c.p.P)
+}
+
+// isSquare returns true if x is a square modulo the prime of the curve.
+func (c *curve) isSquare(x *big.Int) bool {
+	return big.Jacobi(x, c.p.P) >= 0
+}
+
+// Hash hashes the message to a point with the default domain separation tag,
+// see Hash2.
+func (P *p256Point) Hash(m []byte) kyber.Point {
+	return P.Hash2(m, p256DomainRO)
+}
+
+// Hash2 hashes the message to a point with hash_to_curve and the suite
+// P256_XMD:SHA-256_SSWU_RO_ of RFC 9380, and the domain separation tag dst.
+// An empty dst is replaced by the suite ID, since tags must not be empty.
+func (P *p256Point) Hash2(m, dst []byte) kyber.Point {
+	if len(dst) == 0 {
+		dst = p256DomainRO
+	}
+	u := p256HashToField(m, dst, 2)
+	var q0, q1 p256Point
+	q0.mapToCurve(&u[0])
+	q1.mapToCurve(&u[1])
+	// the cofactor of P-256 is 1
+	P.add(&q0, &q1)
+	return P
+}
+
+// EncodeToCurve encodes the message to a point with encode_to_curve and the
+// suite P256_XMD:SHA-256_SSWU_NU_ of RFC 9380, and the domain separation tag
+// dst, or the suite ID if dst is empty. The encoding is cheaper than Hash2,
+// but its output is not uniformly distributed: only use it when the protocol
+// allows a nonuniform encoding.
+func (P *p256Point) EncodeToCurve(m, dst []byte) kyber.Point {
+	if len(dst) == 0 {
+		dst = p256DomainNU
+	}
+	u := p256HashToField(m, dst, 1)
+	P.mapToCurve(&u[0])
+	return P
+}
+
+func p256HashToField(m, dst []byte, count int) []field.Element {
+	// never fails with a non-empty dst and at most two elements
+	u, _ := xmd.HashToField(sha256.New, m, dst, p256Prime, 128, count)
+	e := make([]field.Element, count)
+	for i := range u {
+		fp.SetBig(&e[i], u[i])
+	}
+	return e
+}
+
+// mapToCurve sets P to the image of u by the simplified SWU map, in constant
+// time.
+func (P *p256Point) mapToCurve(u *field.Element) {
+	var tv1, tv2, x1, x2, gx1, gx2, y1, y2 field.Element
+
+	// tv1 = inv0(Z^2*u^4 + Z*u^2)
+	fp.Square(&tv1, u)
+	fp.Mul(&tv1, &p256Z, &tv1)
+	fp.Square(&tv2, &tv1)
+	fp.Add(&tv2, &tv2, &tv1)
+	fp.Inv(&tv2, &tv2)
+
+	// x1 = -B/A * (1 + tv1), or B/(Z*A) if tv1 is 0
+	var one field.Element
+	fp.One(&one)
+	fp.Add(&x1, &one, &tv2)
+	fp.Mul(&x1, &p256SSWUC1, &x1)
+	fp.Select(&x1, &p256SSWUC2, &x1, fp.IsZero(&tv2))
+	p256RHS(&gx1, &x1)
+
+	// x2 = Z*u^2*x1
+	fp.Mul(&x2, &tv1, &x1)
+	p256RHS(&gx2, &x2)
+
+	isSquare := fp.Sqrt(&y1, &gx1)
+	fp.Sqrt(&y2, &gx2)
+	fp.Select(&P.x, &x1, &x2, isSquare)
+	fp.Select(&P.y, &y1, &y2, isSquare)
+
+	// the sign of y must match the sign of u
+	var negY field.Element
+	fp.Neg(&negY, &P.y)
+	fp.Select(&P.y, &negY, &P.y, fp.IsOdd(u)^fp.IsOdd(&P.y))
+	fp.One(&P.z)
+}
+
+// Hash hashes the message to an element of the group with the default
+// domain separation tag, see Hash2.
+func (P *residuePoint) Hash(m []byte) kyber.Point {
+	return P.Hash2(m, nil)
+}
+
+// Hash2 hashes the message to an element of the group with the domain
+// separation tag dst: an integer modulo P is derived from the message with
+// expand_message_xmd and SHA-256 as in RFC 9380, and raised to the power R to
+// map it to the subgroup of order Q. An empty dst is replaced by the name of
+// the group followed by "_XMD:SHA-256_RO_".
+func (P *residuePoint) Hash2(m, dst []byte) kyber.Point {
+	if len(dst) == 0 {
+		dst = []byte(P.g.String() + "_XMD:SHA-256_RO_")
+	}
+	// never fails with a non-empty dst and one element
+	u, _ := xmd.HashToField(sha256.New, m, dst, P.g.P, 128, 1)
+	P.Int.Exp(u[0], P.g.R, P.g.P)
+	return P
+}
@@ -0,0 +1,309 @@
+package p256
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// maxLimbs is the number of 64-bit words of the largest field, the one of
+// P-521.
+const maxLimbs = 9
+
+// felem is an element of a montField, in the Montgomery domain, stored in
+// its first limbs words.
+type felem [maxLimbs]uint64
+
+// montField implements the variable time arithmetic modulo the prime of a
+// NIST curve, with elements in the Montgomery domain, which avoids the
+// divisions of the modular reductions of big.Int.
+type montField struct {
+	limbs int
+	p     felem
+	// -p^-1 mod 2^64
+	n0 uint64
+	// R and R^2 mod p, where R = 2^(64 limbs)
+	one, r2 felem
+}
+
+func newMontField(p *big.Int) *montField {
+	f := &montField{limbs: (p.BitLen() + 63) / 64}
+	f.p = f.fromBig(p)
+	// Newton iteration for the inverse of p modulo 2^64
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - f.p[0]*inv
+	}
+	f.n0 = -inv
+	r2 := new(big.Int).Lsh(big.NewInt(1), uint(128*f.limbs))
+	f.r2 = f.fromBig(r2.Mod(r2, p))
+	f.one = f.toMont(big.NewInt(1))
+	return f
+}
+
+// fromBig returns the words of x, which must be reduced.
+func (f *montField) fromBig(x *big.Int) felem {
+	var z felem
+	buf := x.FillBytes(make([]byte, 8*f.limbs))
+	for i := 0; i < f.limbs; i++ {
+		z[i] = binary.BigEndian.Uint64(buf[len(buf)-8*(i+1):])
+	}
+	return z
+}
+
+// toMont returns x in the Montgomery domain.
+func (f *montField) toMont(x *big.Int) felem {
+	z := f.fromBig(x)
+	f.mul(&z, &z, &f.r2)
+	return z
+}
+
+// toBig returns x out of the Montgomery domain.
+func (f *montField) toBig(x *felem) *big.Int {
+	one := felem{1}
+	var z felem
+	f.mul(&z, x, &one)
+	buf := make([]byte, 8*f.limbs)
+	for i := 0; i < f.limbs; i++ {
+		binary.BigEndian.PutUint64(buf[len(buf)-8*(i+1):], z[i])
+	}
+	return new(big.Int).SetBytes(buf)
+}
+
+func (f *montField) isZero(x *felem) bool {
+	var acc uint64
+	for i := 0; i < f.limbs; i++ {
+		acc |= x[i]
+	}
+	return acc == 0
+}
+
+// reduce subtracts p from the limbs of x and the carry hi if they are not
+// smaller than p.
+func (f *montField) reduce(z *felem, x *felem, hi uint64) {
+	var d felem
+	var borrow uint64
+	for i := 0; i < f.limbs; i++ {
+		d[i], borrow = bits.Sub64(x[i], f.p[i], borrow)
+	}
+	if hi != 0 || borrow == 0 {
+		*z = d
+	} else {
+		*z = *x
+	}
+}
+
+// mul sets z = x * y / R with the CIOS method. The arguments may alias.
+func (f *montField) mul(z, x, y *felem) {
+	n := f.limbs
+	var t [maxLimbs + 2]uint64
+	for i := 0; i < n; i++ {
+		var c uint64
+		for j := 0; j < n; j++ {
+			hi, lo := bits.Mul64(x[j], y[i])
+			var carry uint64
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			t[j], carry = bits.Add64(lo, c, 0)
+			c = hi + carry
+		}
+		var carry uint64
+		t[n], carry = bits.Add64(t[n], c, 0)
+		t[n+1] = carry
+
+		m := t[0] * f.n0
+		hi, lo := bits.Mul64(m, f.p[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+		for j := 1; j < n; j++ {
+			hi, lo = bits.Mul64(m, f.p[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			t[j-1], carry = bits.Add64(lo, c, 0)
+			c = hi + carry
+		}
+		t[n-1], carry = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + carry
+	}
+	var r felem
+	copy(r[:n], t[:n])
+	f.reduce(z, &r, t[n])
+}
+
+func (f *montField) add(z, x, y *felem) {
+	var r felem
+	var carry uint64
+	for i := 0; i < f.limbs; i++ {
+		r[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+	f.reduce(z, &r, carry)
+}
+
+func (f *montField) sub(z, x, y *felem) {
+	var r felem
+	var borrow uint64
+	for i := 0; i < f.limbs; i++ {
+		r[i], borrow = bits.Sub64(x[i], y[i], borrow)
+	}
+	if borrow != 0 {
+		var carry uint64
+		for i := 0; i < f.limbs; i++ {
+			r[i], carry = bits.Add64(r[i], f.p[i], carry)
+		}
+	}
+	*z = r
+}
+
+// jacobianPoint is a point (x/z^2, y/z^3) of a NIST curve in Jacobian
+// coordinates, which add without the field inversion of the affine
+// coordinates of curvePoint. The point at infinity has z = 0.
+type jacobianPoint struct {
+	x, y, z felem
+}
+
+// pointDouble sets r = 2 * p with the dbl-2001-b formulas for a = -3.
+func (f *montField) pointDouble(r, p *jacobianPoint) {
+	if f.isZero(&p.z) {
+		*r = *p
+		return
+	}
+	var delta, gamma, beta, alpha, t, x, y, z felem
+	f.mul(&delta, &p.z, &p.z)
+	f.mul(&gamma, &p.y, &p.y)
+	f.mul(&beta, &p.x, &gamma)
+	f.sub(&t, &p.x, &delta)
+	f.add(&alpha, &p.x, &delta)
+	f.mul(&alpha, &alpha, &t)
+	f.add(&t, &alpha, &alpha)
+	f.add(&alpha, &alpha, &t)
+	// beta = 4 * beta
+	f.add(&beta, &beta, &beta)
+	f.add(&beta, &beta, &beta)
+	f.mul(&x, &alpha, &alpha)
+	f.sub(&x, &x, &beta)
+	f.sub(&x, &x, &beta)
+	f.add(&z, &p.y, &p.z)
+	f.mul(&z, &z, &z)
+	f.sub(&z, &z, &gamma)
+	f.sub(&z, &z, &delta)
+	// gamma = 8 * gamma^2
+	f.mul(&gamma, &gamma, &gamma)
+	f.add(&gamma, &gamma, &gamma)
+	f.add(&gamma, &gamma, &gamma)
+	f.add(&gamma, &gamma, &gamma)
+	f.sub(&y, &beta, &x)
+	f.mul(&y, &y, &alpha)
+	f.sub(&y, &y, &gamma)
+	r.x, r.y, r.z = x, y, z
+}
+
+// pointAdd sets r = p + q with the add-2007-bl formulas.
+func (f *montField) pointAdd(r, p, q *jacobianPoint) {
+	if f.isZero(&p.z) {
+		*r = *q
+		return
+	}
+	if f.isZero(&q.z) {
+		*r = *p
+		return
+	}
+	var z1z1, z2z2, u1, u2, s1, s2, h, rr, i, j, v, x, y, z felem
+	f.mul(&z1z1, &p.z, &p.z)
+	f.mul(&z2z2, &q.z, &q.z)
+	f.mul(&u1, &p.x, &z2z2)
+	f.mul(&u2, &q.x, &z1z1)
+	f.mul(&s1, &p.y, &q.z)
+	f.mul(&s1, &s1, &z2z2)
+	f.mul(&s2, &q.y, &p.z)
+	f.mul(&s2, &s2, &z1z1)
+	f.sub(&h, &u2, &u1)
+	f.sub(&rr, &s2, &s1)
+	if f.isZero(&h) {
+		if f.isZero(&rr) {
+			f.pointDouble(r, p)
+		} else {
+			*r = jacobianPoint{}
+		}
+		return
+	}
+	f.add(&rr, &rr, &rr)
+	f.add(&i, &h, &h)
+	f.mul(&i, &i, &i)
+	f.mul(&j, &h, &i)
+	f.mul(&v, &u1, &i)
+	f.mul(&x, &rr, &rr)
+	f.sub(&x, &x, &j)
+	f.sub(&x, &x, &v)
+	f.sub(&x, &x, &v)
+	f.mul(&s1, &s1, &j)
+	f.sub(&y, &v, &x)
+	f.mul(&y, &y, &rr)
+	f.sub(&y, &y, &s1)
+	f.sub(&y, &y, &s1)
+	f.add(&z, &p.z, &q.z)
+	f.mul(&z, &z, &z)
+	f.sub(&z, &z, &z1z1)
+	f.sub(&z, &z, &z2z2)
+	f.mul(&z, &z, &h)
+	r.x, r.y, r.z = x, y, z
+}
+
+// pointAddAffine sets r = p + (x2, y2) with the madd-2007-bl formulas, for a
+// point (x2, y2) other than the point at infinity.
+func (f *montField) pointAddAffine(r, p *jacobianPoint, x2, y2 *felem) {
+	if f.isZero(&p.z) {
+		r.x, r.y, r.z = *x2, *y2, f.one
+		return
+	}
+	var z1z1, u2, s2, h, rr, hh, i, j, v, x, y, z felem
+	f.mul(&z1z1, &p.z, &p.z)
+	f.mul(&u2, x2, &z1z1)
+	f.mul(&s2, y2, &p.z)
+	f.mul(&s2, &s2, &z1z1)
+	f.sub(&h, &u2, &p.x)
+	f.sub(&rr, &s2, &p.y)
+	if f.isZero(&h) {
+		if f.isZero(&rr) {
+			f.pointDouble(r, p)
+		} else {
+			*r = jacobianPoint{}
+		}
+		return
+	}
+	f.add(&rr, &rr, &rr)
+	f.mul(&hh, &h, &h)
+	f.add(&i, &hh, &hh)
+	f.add(&i, &i, &i)
+	f.mul(&j, &h, &i)
+	f.mul(&v, &p.x, &i)
+	f.mul(&x, &rr, &rr)
+	f.sub(&x, &x, &j)
+	f.sub(&x, &x, &v)
+	f.sub(&x, &x, &v)
+	f.mul(&y, &p.y, &j)
+	f.add(&y, &y, &y)
+	f.sub(&v, &v, &x)
+	f.mul(&v, &v, &rr)
+	f.sub(&y, &v, &y)
+	f.add(&z, &p.z, &h)
+	f.mul(&z, &z, &z)
+	f.sub(&z, &z, &z1z1)
+	f.sub(&z, &z, &hh)
+	r.x, r.y, r.z = x, y, z
+}
+
+// affine returns the affine coordinates of p, (0, 0) for the point at
+// infinity.
+func (f *montField) affine(p *jacobianPoint, modulus *big.Int) (x, y *big.Int) {
+	if f.isZero(&p.z) {
+		return new(big.Int), new(big.Int)
+	}
+	zInv := new(big.Int).ModInverse(f.toBig(&p.z), modulus)
+	zInv2 := new(big.Int).Mul(zInv, zInv)
+	zInv2.Mod(zInv2, modulus)
+	zInv3 := new(big.Int).Mul(zInv2, zInv)
+	zInv3.Mod(zInv3, modulus)
+	x = zInv2.Mul(zInv2, f.toBig(&p.x))
+	y = zInv3.Mul(zInv3, f.toBig(&p.y))
+	return x.Mod(x, modulus), y.Mod(y, modulus)
+}
@@ -1,76 +1,466 @@
 package p256
 
 import (
-	"crypto/elliptic"
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+	"io"
 	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/internal/field"
+	"go.dedis.ch/kyber/v4/group/internal/marshalling"
+	"go.dedis.ch/kyber/v4/util/msm"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var (
+	p256Prime, _ = new(big.Int).SetString("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", 16)
+	p256Order, _ = new(big.Int).SetString("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551", 16)
+	p256B, _     = new(big.Int).SetString("5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b", 16)
+	p256Gx, _    = new(big.Int).SetString("6b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296", 16)
+	p256Gy, _    = new(big.Int).SetString("4fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5", 16)
+
+	// fp is the base field and fn the scalar field of P-256
+	fp = field.New(p256Prime)
+	fn = field.New(p256Order)
+
+	// the curve is y^2 = x^3 - 3x + b
+	p256CurveB field.Element
+	p256Base   p256Point
 )
 
-// P256 implements the kyber.Group interface
-// for the NIST P-256 elliptic curve,
-// based on Go's native elliptic curve library.
-type p256 struct {
-	curve
+func init() {
+	fp.SetBig(&p256CurveB, p256B)
+	fp.SetBig(&p256Base.x, p256Gx)
+	fp.SetBig(&p256Base.y, p256Gy)
+	fp.One(&p256Base.z)
+	initP256Hash()
 }
 
+// p256 implements the kyber.Group interface for the NIST P-256 elliptic
+// curve with constant time arithmetic, in the style of the nistec package of
+// the Go standard library: the field operations run in constant time and the
+// points use complete addition formulas.
+//
+// The encodings are the ones of Go's elliptic package: the points use the
+// uncompressed ANSI X9.62 form and the scalars are big-endian integers.
+type p256 struct{}
+
 func (curve *p256) String() string {
 	return "P256"
 }
 
-// Optimized modular square root for P-256 curve, from
-// "Mathematical routines for the NIST prime elliptic curves" (April 2010)
-func (curve *p256) sqrt(c *big.Int) *big.Int {
-	m := curve.p.P
-
-	t1 := new(big.Int)
-	t1.Mul(c, c)
-	t1.Mul(t1, c) // t1 = c^(2^2-1)
-
-	p2 := new(big.Int)
-	p2.SetBit(p2, 2, 1)
-	t2 := new(big.Int)
-	t2.Exp(t1, p2, m)
-	t2.Mul(t2, t1) // t2 = c^(2^4-1)
-
-	p3 := new(big.Int)
-	p3.SetBit(p3, 4, 1)
-	t3 := new(big.Int)
-	t3.Exp(t2, p3, m)
-	t3.Mul(t3, t2) // t3 = c^(2^8-1)
-
-	p4 := new(big.Int)
-	p4.SetBit(p4, 8, 1)
-	t4 := new(big.Int)
-	t4.Exp(t3, p4, m)
-	t4.Mul(t4, t3) // t4 = c^(2^16-1)
-
-	p5 := new(big.Int)
-	p5.SetBit(p5, 16, 1)
-	r := new(big.Int)
-	r.Exp(t4, p5, m)
-	r.Mul(r, t4) // r = c^(2^32-1)
-
-	p6 := new(big.Int)
-	p6.SetBit(p6, 32, 1)
-	r.Exp(r, p6, m)
-	r.Mul(r, c) // r = c^(2^64-2^32+1)
-
-	p7 := new(big.Int)
-	p7.SetBit(p7, 96, 1)
-	r.Exp(r, p7, m)
-	r.Mul(r, c) // r = c^(2^160-2^128+2^96+1)
-
-	p8 := new(big.Int)
-	p8.SetBit(p8, 94, 1)
-	r.Exp(r, p8, m)
-
-	// r = c^(2^254-2^222+2^190+2^94) = sqrt(c) mod p256
-	return r
-}
-
-// Init initializes standard Curve instances
-func (curve *p256) Init() curve {
-	curve.curve.Curve = elliptic.P256()
-	curve.p = curve.Params()
-	curve.curveOps = curve
-	return curve.curve
+// ScalarLen returns 32, the number of bytes in the encoding of a Scalar.
+func (curve *p256) ScalarLen() int {
+	return field.Size
+}
+
+// Scalar creates a Scalar modulo the order of the curve. The scalars
+// implement kyber.Scalar's SetBytes method, interpreting the bytes as a
+// big-endian integer, so as to be compatible with the Go standard library's
+// big.Int type.
+func (curve *p256) Scalar() kyber.Scalar {
+	return new(p256Scalar)
+}
+
+// PointLen returns 65, the number of bytes in the uncompressed ANSI X9.62
+// encoding of a Point.
+func (curve *p256) PointLen() int {
+	return 1 + 2*field.Size
+}
+
+// Point creates a Point, set to the point at infinity.
+func (curve *p256) Point() kyber.Point {
+	return new(p256Point).Null()
+}
+
+// p256Point is a point of P-256 in projective coordinates (X:Y:Z), which
+// represent the affine point (X/Z, Y/Z). The point at infinity is (0:1:0).
+type p256Point struct {
+	x, y, z field.Element
+}
+
+// affine returns the affine coordinates of the point, which are (0, 0) for
+// the point at infinity.
+func (P *p256Point) affine() (x, y field.Element) {
+	var zInv field.Element
+	fp.Inv(&zInv, &P.z)
+	fp.Mul(&x, &P.x, &zInv)
+	fp.Mul(&y, &P.y, &zInv)
+	return x, y
+}
+
+func (P *p256Point) String() string {
+	x, y := P.affine()
+	return "(" + fp.Big(&x).String() + "," + fp.Big(&y).String() + ")"
+}
+
+func (P *p256Point) Equal(P2 kyber.Point) bool {
+	Q := P2.(*p256Point) //nolint:errcheck // V4 may bring better error handling
+	var a, b field.Element
+	fp.Mul(&a, &P.x, &Q.z)
+	fp.Mul(&b, &Q.x, &P.z)
+	eq := fp.Equal(&a, &b)
+	fp.Mul(&a, &P.y, &Q.z)
+	fp.Mul(&b, &Q.y, &P.z)
+	return eq&fp.Equal(&a, &b) == 1
+}
+
+func (P *p256Point) Null() kyber.Point {
+	fp.Zero(&P.x)
+	fp.One(&P.y)
+	fp.Zero(&P.z)
+	return P
+}
+
+func (P *p256Point) Base() kyber.Point {
+	*P = p256Base
+	return P
+}
+
+func (P *p256Point) Set(A kyber.Point) kyber.Point {
+	*P = *A.(*p256Point)
+	return P
+}
+
+func (P *p256Point) Clone() kyber.Point {
+	Q := *P
+	return &Q
+}
+
+func (P *p256Point) EmbedLen() int {
+	// Reserve at least 8 most-significant bits for randomness,
+	// and the least-significant 8 bits for embedded data length.
+	return (256 - 8 - 8) / 8
+}
+
+func (P *p256Point) Pick(rand cipher.Stream) kyber.Point {
+	return P.Embed(nil, rand)
+}
+
+// Embed picks a curve point containing a variable amount of embedded data.
+// Remaining bits comprising the point are chosen randomly.
+func (P *p256Point) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	l := field.Size
+	dl := P.EmbedLen()
+	if dl > len(data) {
+		dl = len(data)
+	}
+
+	for {
+		b := random.Bits(256, false, rand)
+		if data != nil {
+			b[l-1] = byte(dl)         // Encode length in low 8 bits
+			copy(b[l-dl-1:l-1], data) // Copy in data to embed
+		}
+		if P.genPoint(b, rand) {
+			return P
+		}
+	}
+}
+
+// genPoint tries to set P to a point with the x-coordinate b and a random
+// sign.
+func (P *p256Point) genPoint(b []byte, rand cipher.Stream) bool {
+	var x, y, y2 field.Element
+	if fp.SetBytes(&x, b) != 1 {
+		return false
+	}
+	p256RHS(&y2, &x)
+	if fp.Sqrt(&y, &y2) != 1 {
+		return false
+	}
+
+	// Pick a random sign for the y coordinate
+	s := make([]byte, 1)
+	rand.XORKeyStream(s, s)
+	var negY field.Element
+	fp.Neg(&negY, &y)
+	fp.Select(&y, &negY, &y, int(s[0]>>7))
+
+	P.x, P.y = x, y
+	fp.One(&P.z)
+	return true
+}
+
+// Data extracts embedded data from a curve point
+func (P *p256Point) Data() ([]byte, error) {
+	x, _ := P.affine()
+	b := fp.Bytes(&x)
+	l := field.Size
+	dl := int(b[l-1])
+	if dl > P.EmbedLen() {
+		return nil, errors.New("invalid embedded data length")
+	}
+	return b[l-dl-1 : l-1], nil
+}
+
+// add sets P = A + B, with the algorithm 4 of Renes, Costello and Batina,
+// "Complete addition formulas for prime order elliptic curves", for the
+// curves with a = -3.
+func (P *p256Point) add(A, B *p256Point) {
+	var t0, t1, t2, t3, t4, x3, y3, z3 field.Element
+	fp.Mul(&t0, &A.x, &B.x)
+	fp.Mul(&t1, &A.y, &B.y)
+	fp.Mul(&t2, &A.z, &B.z)
+	fp.Add(&t3, &A.x, &A.y)
+	fp.Add(&t4, &B.x, &B.y)
+	fp.Mul(&t3, &t3, &t4)
+	fp.Add(&t4, &t0, &t1)
+	fp.Sub(&t3, &t3, &t4)
+	fp.Add(&t4, &A.y, &A.z)
+	fp.Add(&x3, &B.y, &B.z)
+	fp.Mul(&t4, &t4, &x3)
+	fp.Add(&x3, &t1, &t2)
+	fp.Sub(&t4, &t4, &x3)
+	fp.Add(&x3, &A.x, &A.z)
+	fp.Add(&y3, &B.x, &B.z)
+	fp.Mul(&x3, &x3, &y3)
+	fp.Add(&y3, &t0, &t2)
+	fp.Sub(&y3, &x3, &y3)
+	fp.Mul(&z3, &p256CurveB, &t2)
+	fp.Sub(&x3, &y3, &z3)
+	fp.Add(&z3, &x3, &x3)
+	fp.Add(&x3, &x3, &z3)
+	fp.Sub(&z3, &t1, &x3)
+	fp.Add(&x3, &t1, &x3)
+	fp.Mul(&y3, &p256CurveB, &y3)
+	fp.Add(&t1, &t2, &t2)
+	fp.Add(&t2, &t1, &t2)
+	fp.Sub(&y3, &y3, &t2)
+	fp.Sub(&y3, &y3, &t0)
+	fp.Add(&t1, &y3, &y3)
+	fp.Add(&y3, &t1, &y3)
+	fp.Add(&t1, &t0, &t0)
+	fp.Add(&t0, &t1, &t0)
+	fp.Sub(&t0, &t0, &t2)
+	fp.Mul(&t1, &t4, &y3)
+	fp.Mul(&t2, &t0, &y3)
+	fp.Mul(&y3, &x3, &z3)
+	fp.Add(&y3, &y3, &t2)
+	fp.Mul(&x3, &x3, &t3)
+	fp.Sub(&x3, &x3, &t1)
+	fp.Mul(&z3, &z3, &t4)
+	fp.Mul(&t1, &t3, &t0)
+	fp.Add(&z3, &z3, &t1)
+	P.x, P.y, P.z = x3, y3, z3
+}
+
+// double sets P = 2A, with the algorithm 6 of Renes, Costello and Batina for
+// the curves with a = -3.
+func (P *p256Point) double(A *p256Point) {
+	var t0, t1, t2, t3, x3, y3, z3 field.Element
+	fp.Square(&t0, &A.x)
+	fp.Square(&t1, &A.y)
+	fp.Square(&t2, &A.z)
+	fp.Mul(&t3, &A.x, &A.y)
+	fp.Add(&t3, &t3, &t3)
+	fp.Mul(&z3, &A.x, &A.z)
+	fp.Add(&z3, &z3, &z3)
+	fp.Mul(&y3, &p256CurveB, &t2)
+	fp.Sub(&y3, &y3, &z3)
+	fp.Add(&x3, &y3, &y3)
+	fp.Add(&y3, &x3, &y3)
+	fp.Sub(&x3, &t1, &y3)
+	fp.Add(&y3, &t1, &y3)
+	fp.Mul(&y3, &x3, &y3)
+	fp.Mul(&x3, &x3, &t3)
+	fp.Add(&t3, &t2, &t2)
+	fp.Add(&t2, &t2, &t3)
+	fp.Mul(&z3, &p256CurveB, &z3)
+	fp.Sub(&z3, &z3, &t2)
+	fp.Sub(&z3, &z3, &t0)
+	fp.Add(&t3, &z3, &z3)
+	fp.Add(&z3, &z3, &t3)
+	fp.Add(&t3, &t0, &t0)
+	fp.Add(&t0, &t3, &t0)
+	fp.Sub(&t0, &t0, &t2)
+	fp.Mul(&t0, &t0, &z3)
+	fp.Add(&y3, &y3, &t0)
+	fp.Mul(&t0, &A.y, &A.z)
+	fp.Add(&t0, &t0, &t0)
+	fp.Mul(&z3, &t0, &z3)
+	fp.Sub(&x3, &x3, &z3)
+	fp.Mul(&z3, &t0, &t1)
+	fp.Add(&z3, &z3, &z3)
+	fp.Add(&z3, &z3, &z3)
+	P.x, P.y, P.z = x3, y3, z3
+}
+
+// selectPoint sets P to A if cond is 1 and to B if cond is 0.
+func (P *p256Point) selectPoint(A, B *p256Point, cond int) {
+	fp.Select(&P.x, &A.x, &B.x, cond)
+	fp.Select(&P.y, &A.y, &B.y, cond)
+	fp.Select(&P.z, &A.z, &B.z, cond)
+}
+
+func (P *p256Point) Add(A, B kyber.Point) kyber.Point {
+	P.add(A.(*p256Point), B.(*p256Point))
+	return P
+}
+
+func (P *p256Point) Sub(A, B kyber.Point) kyber.Point {
+	var neg p256Point
+	neg.Neg(B)
+	P.add(A.(*p256Point), &neg)
+	return P
+}
+
+func (P *p256Point) Neg(A kyber.Point) kyber.Point {
+	Q := A.(*p256Point) //nolint:errcheck // V4 may bring better error handling
+	P.x = Q.x
+	fp.Neg(&P.y, &Q.y)
+	P.z = Q.z
+	return P
+}
+
+// Mul sets P to s*B, or s times the base point if B is nil, with a fixed
+// window of 4 bits and constant time lookups in the table of the multiples.
+func (P *p256Point) Mul(s kyber.Scalar, B kyber.Point) kyber.Point {
+	var table [16]p256Point
+	table[0].Null()
+	if B == nil {
+		table[1] = p256Base
+	} else {
+		table[1] = *B.(*p256Point)
+	}
+	for i := 2; i < len(table); i++ {
+		table[i].add(&table[i-1], &table[1])
+	}
+
+	k := fn.Bytes(&s.(*p256Scalar).v)
+	var acc, entry p256Point
+	acc.Null()
+	for i := 0; i < 2*len(k); i++ {
+		for j := 0; j < 4; j++ {
+			acc.double(&acc)
+		}
+		nibble := k[i/2] >> 4
+		if i%2 == 1 {
+			nibble = k[i/2] & 0xf
+		}
+		entry.Null()
+		for j := range table {
+			entry.selectPoint(&table[j], &entry, subtle.ConstantTimeByteEq(nibble, byte(j)))
+		}
+		acc.add(&acc, &entry)
+	}
+	*P = acc
+	return P
+}
+
+// MultiScalarMul sets P to the sum of scalars[i] * points[i], computed with
+// Pippenger's bucket method on the projective points. It runs in variable
+// time.
+func (P *p256Point) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	msm.CheckLengths(scalars, points)
+	var acc p256Point
+	acc.Null()
+	if len(points) == 0 {
+		*P = acc
+		return P
+	}
+
+	c := msm.WindowSize(len(points), p256Order.BitLen())
+	digits := msm.Digits(scalars, c)
+	buckets := make([]p256Point, 1<<c-1)
+	var sum, window p256Point
+
+	for w := len(digits[0]) - 1; w >= 0; w-- {
+		for i := 0; i < c; i++ {
+			acc.double(&acc)
+		}
+
+		for k := range buckets {
+			buckets[k].Null()
+		}
+		for i, d := range digits {
+			if k := d[w]; k != 0 {
+				buckets[k-1].add(&buckets[k-1], points[i].(*p256Point))
+			}
+		}
+
+		// window = sum_k k * buckets[k-1], computed with running sums
+		sum.Null()
+		window.Null()
+		for k := len(buckets) - 1; k >= 0; k-- {
+			sum.add(&sum, &buckets[k])
+			window.add(&window, &sum)
+		}
+
+		acc.add(&acc, &window)
+	}
+	*P = acc
+	return P
+}
+
+func (P *p256Point) MarshalSize() int {
+	return 1 + 2*field.Size // uncompressed ANSI X9.62 representation
+}
+
+// MarshalBinary returns the uncompressed ANSI X9.62 encoding of the point,
+// where the point at infinity has zero coordinates.
+func (P *p256Point) MarshalBinary() ([]byte, error) {
+	x, y := P.affine()
+	xb, yb := fp.Bytes(&x), fp.Bytes(&y)
+	buf := make([]byte, 1+2*field.Size)
+	buf[0] = 4
+	copy(buf[1:], xb[:])
+	copy(buf[1+field.Size:], yb[:])
+	return buf, nil
+}
+
+// UnmarshalBinary decodes an uncompressed point, checking that it is on the
+// curve. The encodings whose coordinates are all zero decode to the point at
+// infinity.
+func (P *p256Point) UnmarshalBinary(buf []byte) error {
+	if len(buf) != P.MarshalSize() {
+		return errors.New("invalid elliptic curve point length")
+	}
+	// Check whether all bytes after first one are 0, so we
+	// just return the initial point. Read everything to
+	// prevent timing-leakage.
+	var c byte
+	for _, b := range buf[1:] {
+		c |= b
+	}
+	if c == 0 {
+		P.Null()
+		return nil
+	}
+
+	var x, y, y2, yy field.Element
+	if buf[0] != 4 ||
+		fp.SetBytes(&x, buf[1:1+field.Size]) != 1 ||
+		fp.SetBytes(&y, buf[1+field.Size:]) != 1 {
+		return errors.New("invalid elliptic curve point")
+	}
+	p256RHS(&y2, &x)
+	fp.Square(&yy, &y)
+	if fp.Equal(&yy, &y2) != 1 {
+		return errors.New("invalid elliptic curve point")
+	}
+	P.x, P.y = x, y
+	fp.One(&P.z)
+	return nil
+}
+
+// p256RHS sets y2 = x^3 - 3x + b.
+func p256RHS(y2, x *field.Element) {
+	var threeX field.Element
+	fp.Add(&threeX, x, x)
+	fp.Add(&threeX, &threeX, x)
+	fp.Square(y2, x)
+	fp.Mul(y2, y2, x)
+	fp.Sub(y2, y2, &threeX)
+	fp.Add(y2, y2, &p256CurveB)
+}
+
+func (P *p256Point) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.PointMarshalTo(P, w)
+}
+
+func (P *p256Point) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.PointUnmarshalFrom(P, r)
 }
@@ -0,0 +1,38 @@
+package p256
+
+import (
+	"crypto/elliptic"
+	"crypto/sha512"
+	"math/big"
+)
+
+// p384 implements the kyber.Group interface
+// for the NIST P-384 elliptic curve,
+// based on Go's native elliptic curve library.
+type p384 struct {
+	curve
+}
+
+func (curve *p384) String() string {
+	return "P384"
+}
+
+// sqrt returns a square root of c modulo the prime of P-384, which is 3 mod
+// 4, as c^((p+1)/4).
+func (curve *p384) sqrt(c *big.Int) *big.Int {
+	return sqrt3Mod4(c, curve.p.P)
+}
+
+// Init initializes standard Curve instances
+func (curve *p384) Init() curve {
+	curve.curve.Curve = elliptic.P384()
+	curve.p = curve.Params()
+	curve.curveOps = curve
+	curve.h2c = &hashToCurve{
+		domain: []byte("P384_XMD:SHA-384_SSWU_RO_"),
+		hash:   sha512.New384,
+		k:      192,
+		z:      big.NewInt(-12),
+	}
+	return curve.curve
+}
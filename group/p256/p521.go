@@ -0,0 +1,38 @@
+package p256
+
+import (
+	"crypto/elliptic"
+	"crypto/sha512"
+	"math/big"
+)
+
+// p521 implements the kyber.Group interface
+// for the NIST P-521 elliptic curve,
+// based on Go's native elliptic curve library.
+type p521 struct {
+	curve
+}
+
+func (curve *p521) String() string {
+	return "P521"
+}
+
+// sqrt returns a square root of c modulo the prime of P-521, which is 3 mod
+// 4, as c^((p+1)/4).
+func (curve *p521) sqrt(c *big.Int) *big.Int {
+	return sqrt3Mod4(c, curve.p.P)
+}
+
+// Init initializes standard Curve instances
+func (curve *p521) Init() curve {
+	curve.curve.Curve = elliptic.P521()
+	curve.p = curve.Params()
+	curve.curveOps = curve
+	curve.h2c = &hashToCurve{
+		domain: []byte("P521_XMD:SHA-512_SSWU_RO_"),
+		hash:   sha512.New,
+		k:      256,
+		z:      big.NewInt(-4),
+	}
+	return curve.curve
+}
@@ -48,12 +48,14 @@ func (P *residuePoint) Base() kyber.Point {
 
 func (P *residuePoint) Set(P2 kyber.Point) kyber.Point {
 	P.g = P2.(*residuePoint).g
-	P.Int = P2.(*residuePoint).Int
+	P.Int.Set(&P2.(*residuePoint).Int)
 	return P
 }
 
 func (P *residuePoint) Clone() kyber.Point {
-	return &residuePoint{g: P.g, Int: P.Int}
+	P2 := &residuePoint{g: P.g}
+	P2.Int.Set(&P.Int)
+	return P2
 }
 
 func (P *residuePoint) Valid() bool {
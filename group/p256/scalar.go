@@ -0,0 +1,139 @@
+package p256
+
+import (
+	"crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/internal/field"
+	"go.dedis.ch/kyber/v4/group/internal/marshalling"
+)
+
+// p256Scalar is an integer modulo the order of P-256, with the encodings of
+// mod.Int.
+type p256Scalar struct {
+	v field.Element
+}
+
+func (s *p256Scalar) Equal(s2 kyber.Scalar) bool {
+	return fn.Equal(&s.v, &s2.(*p256Scalar).v) == 1
+}
+
+func (s *p256Scalar) Set(a kyber.Scalar) kyber.Scalar {
+	s.v = a.(*p256Scalar).v
+	return s
+}
+
+func (s *p256Scalar) Clone() kyber.Scalar {
+	return &p256Scalar{v: s.v}
+}
+
+func (s *p256Scalar) SetInt64(v int64) kyber.Scalar {
+	fn.SetInt64(&s.v, v)
+	return s
+}
+
+func (s *p256Scalar) Zero() kyber.Scalar {
+	fn.Zero(&s.v)
+	return s
+}
+
+func (s *p256Scalar) One() kyber.Scalar {
+	fn.One(&s.v)
+	return s
+}
+
+func (s *p256Scalar) Add(a, b kyber.Scalar) kyber.Scalar {
+	fn.Add(&s.v, &a.(*p256Scalar).v, &b.(*p256Scalar).v)
+	return s
+}
+
+func (s *p256Scalar) Sub(a, b kyber.Scalar) kyber.Scalar {
+	fn.Sub(&s.v, &a.(*p256Scalar).v, &b.(*p256Scalar).v)
+	return s
+}
+
+func (s *p256Scalar) Neg(a kyber.Scalar) kyber.Scalar {
+	fn.Neg(&s.v, &a.(*p256Scalar).v)
+	return s
+}
+
+func (s *p256Scalar) Mul(a, b kyber.Scalar) kyber.Scalar {
+	fn.Mul(&s.v, &a.(*p256Scalar).v, &b.(*p256Scalar).v)
+	return s
+}
+
+// Div sets s to a / b, or 0 if b is 0.
+func (s *p256Scalar) Div(a, b kyber.Scalar) kyber.Scalar {
+	var inv field.Element
+	fn.Inv(&inv, &b.(*p256Scalar).v)
+	fn.Mul(&s.v, &a.(*p256Scalar).v, &inv)
+	return s
+}
+
+// Inv sets s to the inverse of a, or 0 if a is 0.
+func (s *p256Scalar) Inv(a kyber.Scalar) kyber.Scalar {
+	fn.Inv(&s.v, &a.(*p256Scalar).v)
+	return s
+}
+
+// Pick sets s to a uniformly random scalar, reducing 64 random bytes so that
+// the bias is negligible.
+func (s *p256Scalar) Pick(rand cipher.Stream) kyber.Scalar {
+	var b [2 * field.Size]byte
+	rand.XORKeyStream(b[:], b[:])
+	fn.SetWideBytes(&s.v, b[:])
+	return s
+}
+
+// SetBytes sets s to the big-endian integer buf, reduced modulo the order.
+func (s *p256Scalar) SetBytes(buf []byte) kyber.Scalar {
+	fn.SetWideBytes(&s.v, buf)
+	return s
+}
+
+func (s *p256Scalar) ByteOrder() kyber.ByteOrder {
+	return kyber.BigEndian
+}
+
+func (s *p256Scalar) GroupOrder() *big.Int {
+	return fn.Modulus()
+}
+
+// String returns the hexadecimal value of the scalar without its leading
+// zeros, like mod.Int.
+func (s *p256Scalar) String() string {
+	return hex.EncodeToString(fn.Big(&s.v).Bytes())
+}
+
+func (s *p256Scalar) MarshalSize() int {
+	return field.Size
+}
+
+func (s *p256Scalar) MarshalBinary() ([]byte, error) {
+	b := fn.Bytes(&s.v)
+	return b[:], nil
+}
+
+// UnmarshalBinary decodes a big-endian scalar, rejecting the values that are
+// not reduced modulo the order.
+func (s *p256Scalar) UnmarshalBinary(buf []byte) error {
+	if len(buf) != field.Size {
+		return errors.New("UnmarshalBinary: wrong size buffer")
+	}
+	if fn.SetBytes(&s.v, buf) != 1 {
+		return errors.New("UnmarshalBinary: value out of range")
+	}
+	return nil
+}
+
+func (s *p256Scalar) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.ScalarMarshalTo(s, w)
+}
+
+func (s *p256Scalar) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.ScalarUnmarshalFrom(s, r)
+}
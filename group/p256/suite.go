@@ -3,6 +3,7 @@ package p256
 import (
 	"crypto/cipher"
 	"crypto/sha256"
+	"crypto/sha512"
 	"hash"
 	"io"
 	"reflect"
@@ -52,11 +53,104 @@ func (s *Suite128) New(t reflect.Type) interface{} {
 // go.dedis.ch/kyber/v4/xof/blake2xb, SHA-256, and the NIST P-256
 // elliptic curve. It returns random streams from Go's crypto/rand.
 //
+// The arithmetic of the curve runs in constant time. The scalars created by
+// this group implement kyber.Scalar's SetBytes method, interpreting the bytes
+// as a big-endian integer, so as to be compatible with the Go standard
+// library's big.Int type.
+func NewBlakeSHA256P256() *Suite128 {
+	return new(Suite128)
+}
+
+// Suite192 is the suite for P384 curve
+type Suite192 struct {
+	p384
+}
+
+// Hash returns the instance associated with the suite
+func (s *Suite192) Hash() hash.Hash {
+	return sha512.New384()
+}
+
+// XOF creates the XOF associated with the suite
+func (s *Suite192) XOF(key []byte) kyber.XOF {
+	return blake2xb.New(key)
+}
+
+// RandomStream returns a cipher.Stream that returns a key stream
+// from crypto/rand.
+func (s *Suite192) RandomStream() cipher.Stream {
+	return random.New()
+}
+
+func (s *Suite192) Read(r io.Reader, objs ...interface{}) error {
+	return fixbuf.Read(r, s, objs...)
+}
+
+func (s *Suite192) Write(w io.Writer, objs ...interface{}) error {
+	return fixbuf.Write(w, objs...)
+}
+
+// New implements the kyber.encoding interface
+func (s *Suite192) New(t reflect.Type) interface{} {
+	return marshalling.GroupNew(s, t)
+}
+
+// NewBlakeSHA384P384 returns a cipher suite based on package
+// go.dedis.ch/kyber/v4/xof/blake2xb, SHA-384, and the NIST P-384
+// elliptic curve. It returns random streams from Go's crypto/rand.
+//
 // The scalars created by this group implement kyber.Scalar's SetBytes
 // method, interpreting the bytes as a big-endian integer, so as to be
 // compatible with the Go standard library's big.Int type.
-func NewBlakeSHA256P256() *Suite128 {
-	suite := new(Suite128)
-	suite.p256.Init()
+func NewBlakeSHA384P384() *Suite192 {
+	suite := new(Suite192)
+	suite.p384.Init()
+	return suite
+}
+
+// Suite256 is the suite for P521 curve
+type Suite256 struct {
+	p521
+}
+
+// Hash returns the instance associated with the suite
+func (s *Suite256) Hash() hash.Hash {
+	return sha512.New()
+}
+
+// XOF creates the XOF associated with the suite
+func (s *Suite256) XOF(key []byte) kyber.XOF {
+	return blake2xb.New(key)
+}
+
+// RandomStream returns a cipher.Stream that returns a key stream
+// from crypto/rand.
+func (s *Suite256) RandomStream() cipher.Stream {
+	return random.New()
+}
+
+func (s *Suite256) Read(r io.Reader, objs ...interface{}) error {
+	return fixbuf.Read(r, s, objs...)
+}
+
+func (s *Suite256) Write(w io.Writer, objs ...interface{}) error {
+	return fixbuf.Write(w, objs...)
+}
+
+// New implements the kyber.encoding interface
+func (s *Suite256) New(t reflect.Type) interface{} {
+	return marshalling.GroupNew(s, t)
+}
+
+// NewBlakeSHA512P521 returns a cipher suite based on package
+// go.dedis.ch/kyber/v4/xof/blake2xb, SHA-512, and the NIST P-521
+// elliptic curve. It returns random streams from Go's crypto/rand.
+//
+// The scalars created by this group implement kyber.Scalar's SetBytes
+// method, interpreting the bytes as a big-endian integer, so as to be
+// compatible with the Go standard library's big.Int type.
+func NewBlakeSHA512P521() *Suite256 {
+	suite := new(Suite256)
+	suite.p521.Init()
 	return suite
 }
@@ -0,0 +1,61 @@
+// Package ristretto255 implements the ristretto255 prime-order group of
+// RFC 9496 on top of the edwards25519 package. Each element of the group is
+// represented by an Ed25519 point and the points that differ by a point of
+// small order are the same element, so that the group has the prime order
+// l = 2^252 + 27742317777372353535851937790883648493 without cofactor: the
+// encoding of the elements is canonical and there is no small subgroup to
+// check for.
+//
+// The scalars are the ones of the edwards25519 package, and all the operations
+// are constant time.
+package ristretto255
+
+import (
+	"crypto/cipher"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var curve = new(edwards25519.Curve)
+
+// Group represents the ristretto255 group. There are no parameters and no
+// initialization is required.
+type Group struct {
+}
+
+// String returns the name of the group, "Ristretto255".
+func (g *Group) String() string {
+	return "Ristretto255"
+}
+
+// ScalarLen returns 32, the size in bytes of an encoded Scalar.
+func (g *Group) ScalarLen() int {
+	return 32
+}
+
+// Scalar creates a new Scalar modulo the order of the group.
+func (g *Group) Scalar() kyber.Scalar {
+	return curve.Scalar()
+}
+
+// PointLen returns 32, the size in bytes of an encoded Point.
+func (g *Group) PointLen() int {
+	return 32
+}
+
+// Point creates a new Point, set to the identity element.
+func (g *Group) Point() kyber.Point {
+	return &point{p: curve.Point().Null()}
+}
+
+// NewKey returns a uniformly random secret key. Unlike the ones of the
+// edwards25519 package, the keys don't need to be clamped since the group has
+// no cofactor.
+func (g *Group) NewKey(stream cipher.Stream) kyber.Scalar {
+	if stream == nil {
+		stream = random.New()
+	}
+	return g.Scalar().Pick(stream)
+}
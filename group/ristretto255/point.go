@@ -0,0 +1,187 @@
+package ristretto255
+
+import (
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/internal/marshalling"
+	"go.dedis.ch/kyber/v4/internal/xmd"
+)
+
+var marshalPointID = [8]byte{'r', '2', '5', '5', '.', 'p', 'n', 't'}
+
+// domain is the default domain separation tag of Hash, the suite ID of
+// hash_to_ristretto255 in RFC 9380.
+var domain = []byte("ristretto255_XMD:SHA-512_R255MAP_RO_")
+
+// point is an element of the group, represented by one of the Ed25519 points
+// of its class.
+type point struct {
+	p kyber.Point
+}
+
+func (P *point) encode() [32]byte {
+	return edwards25519.RistrettoEncode(P.p)
+}
+
+func (P *point) String() string {
+	b := P.encode()
+	return hex.EncodeToString(b[:])
+}
+
+func (P *point) MarshalSize() int {
+	return 32
+}
+
+func (P *point) MarshalBinary() ([]byte, error) {
+	b := P.encode()
+	return b[:], nil
+}
+
+// MarshalID returns the type tag used in encoding/decoding
+func (P *point) MarshalID() [8]byte {
+	return marshalPointID
+}
+
+// UnmarshalBinary decodes a point, rejecting the non-canonical encodings.
+func (P *point) UnmarshalBinary(b []byte) error {
+	return edwards25519.RistrettoDecode(P.p, b)
+}
+
+func (P *point) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.PointMarshalTo(P, w)
+}
+
+func (P *point) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.PointUnmarshalFrom(P, r)
+}
+
+// Equal returns true if both points are the same element of the group, in
+// constant time.
+func (P *point) Equal(P2 kyber.Point) bool {
+	return edwards25519.RistrettoEqual(P.p, P2.(*point).p)
+}
+
+func (P *point) Null() kyber.Point {
+	P.p.Null()
+	return P
+}
+
+// Base sets the point to the generator of the group, which is represented by
+// the Ed25519 base point.
+func (P *point) Base() kyber.Point {
+	P.p.Base()
+	return P
+}
+
+func (P *point) Pick(rand cipher.Stream) kyber.Point {
+	return P.Embed(nil, rand)
+}
+
+func (P *point) Set(P2 kyber.Point) kyber.Point {
+	P.p.Set(P2.(*point).p)
+	return P
+}
+
+func (P *point) Clone() kyber.Point {
+	return &point{p: P.p.Clone()}
+}
+
+func (P *point) EmbedLen() int {
+	// Reserve the least-significant 8 bits for the embedded data length and
+	// the most-significant 8 bits for pseudo-randomness.
+	return (255 - 8 - 8) / 8
+}
+
+// Embed sets the point to an element whose encoding holds the data: the first
+// byte holds its length, shifted left since a valid encoding is even, followed
+// by the data and random bytes.
+func (P *point) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	if data == nil {
+		var b [64]byte
+		rand.XORKeyStream(b[:], b[:])
+		// never fails with 64 bytes
+		_ = edwards25519.RistrettoFromUniformBytes(P.p, b[:])
+		return P
+	}
+
+	dl := P.EmbedLen()
+	if dl > len(data) {
+		dl = len(data)
+	}
+	for {
+		var b [32]byte
+		rand.XORKeyStream(b[:], b[:])
+		b[0] = byte(dl) << 1
+		copy(b[1:1+dl], data)
+		b[31] &= 0x7f
+		if P.UnmarshalBinary(b[:]) == nil {
+			return P
+		}
+	}
+}
+
+// Data extracts the data embedded in the point.
+func (P *point) Data() ([]byte, error) {
+	b := P.encode()
+	dl := int(b[0] >> 1)
+	if dl > P.EmbedLen() {
+		return nil, errors.New("invalid embedded data length")
+	}
+	return b[1 : 1+dl], nil
+}
+
+func (P *point) Add(P1, P2 kyber.Point) kyber.Point {
+	P.p.Add(P1.(*point).p, P2.(*point).p)
+	return P
+}
+
+func (P *point) Sub(P1, P2 kyber.Point) kyber.Point {
+	P.p.Sub(P1.(*point).p, P2.(*point).p)
+	return P
+}
+
+func (P *point) Neg(A kyber.Point) kyber.Point {
+	P.p.Neg(A.(*point).p)
+	return P
+}
+
+// Mul multiplies the point A by the scalar s, or the generator if A is nil.
+func (P *point) Mul(s kyber.Scalar, A kyber.Point) kyber.Point {
+	if A == nil {
+		P.p.Mul(s, nil)
+	} else {
+		P.p.Mul(s, A.(*point).p)
+	}
+	return P
+}
+
+// AllowVarTime allows the use of variable time algorithms for the scalar
+// multiplications by this point, as the Ed25519 points do.
+func (P *point) AllowVarTime(varTime bool) {
+	P.p.(kyber.AllowsVarTime).AllowVarTime(varTime)
+}
+
+// Hash hashes the message to a point with the default domain separation tag,
+// see Hash2.
+func (P *point) Hash(m []byte) kyber.Point {
+	return P.Hash2(m, domain)
+}
+
+// Hash2 hashes the message to a point using hash_to_ristretto255 from
+// RFC 9380, appendix B, with the domain separation tag dst. An empty dst is
+// replaced by the default one, since tags must not be empty.
+func (P *point) Hash2(m, dst []byte) kyber.Point {
+	if len(dst) == 0 {
+		dst = domain
+	}
+	// never fails with a non-empty dst and 64 bytes
+	uniform, _ := xmd.Expand(sha512.New, m, dst, 64)
+	_ = edwards25519.RistrettoFromUniformBytes(P.p, uniform)
+	return P
+}
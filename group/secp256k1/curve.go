@@ -0,0 +1,81 @@
+// Package secp256k1 implements the secp256k1 elliptic curve of SEC 2, used by
+// Bitcoin and Ethereum, with constant time arithmetic.
+//
+// The points are encoded in the SEC1 compressed form, the only form accepted
+// by UnmarshalBinary; the uncompressed form is produced by MarshalUncompressed
+// and decoded by UnmarshalUncompressed. The scalars are encoded as big-endian
+// integers, like the private keys of the curve.
+package secp256k1
+
+import (
+	"crypto/cipher"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/internal/field"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var (
+	prime, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	order, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	gx, _    = new(big.Int).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+	gy, _    = new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+
+	// fp is the base field and fn the scalar field
+	fp = field.New(prime)
+	fn = field.New(order)
+
+	// the curve is y^2 = x^3 + b
+	curveB, curveB3 field.Element
+	base            point
+)
+
+func init() {
+	fp.SetInt64(&curveB, 7)
+	fp.SetInt64(&curveB3, 21)
+	fp.SetBig(&base.x, gx)
+	fp.SetBig(&base.y, gy)
+	fp.One(&base.z)
+	initHash()
+}
+
+// Curve represents the secp256k1 group. There are no parameters and no
+// initialization is required.
+type Curve struct {
+}
+
+// String returns the name of the curve, "secp256k1".
+func (c *Curve) String() string {
+	return "secp256k1"
+}
+
+// ScalarLen returns 32, the size in bytes of an encoded Scalar.
+func (c *Curve) ScalarLen() int {
+	return field.Size
+}
+
+// Scalar creates a new Scalar modulo the order of the curve. The scalars
+// implement kyber.Scalar's SetBytes method by interpreting the bytes as a
+// big-endian integer.
+func (c *Curve) Scalar() kyber.Scalar {
+	return new(scalar)
+}
+
+// PointLen returns 33, the size in bytes of a compressed Point.
+func (c *Curve) PointLen() int {
+	return 1 + field.Size
+}
+
+// Point creates a new Point, set to the point at infinity.
+func (c *Curve) Point() kyber.Point {
+	return new(point).Null()
+}
+
+// NewKey returns a uniformly random secret key.
+func (c *Curve) NewKey(stream cipher.Stream) kyber.Scalar {
+	if stream == nil {
+		stream = random.New()
+	}
+	return c.Scalar().Pick(stream)
+}
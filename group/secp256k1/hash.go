@@ -0,0 +1,177 @@
+package secp256k1
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/internal/field"
+	"go.dedis.ch/kyber/v4/internal/xmd"
+)
+
+// domain is the default domain separation tag of Hash, the suite ID of
+// secp256k1 in RFC 9380.
+var domain = []byte("secp256k1_XMD:SHA-256_SSWU_RO_")
+
+// The simplified SWU map of RFC 9380 needs a curve with A != 0, so it maps to
+// the curve E': y^2 = x^3 + A'x + B', which is 3-isogenous to secp256k1.
+var (
+	isoA, isoB, sswuZ field.Element
+	// -B'/A' and B'/(Z*A')
+	sswuC1, sswuC2 field.Element
+	// the coefficients of the rational maps of the isogeny, RFC 9380,
+	// appendix E.1
+	isoXNum, isoXDen, isoYNum, isoYDen []field.Element
+)
+
+func setHex(s string) field.Element {
+	n, _ := new(big.Int).SetString(s, 16)
+	var e field.Element
+	fp.SetBig(&e, n)
+	return e
+}
+
+func initHash() {
+	isoA = setHex("3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533")
+	fp.SetInt64(&isoB, 1771)
+	fp.SetInt64(&sswuZ, -11)
+
+	fp.Inv(&sswuC1, &isoA)
+	fp.Mul(&sswuC1, &sswuC1, &isoB)
+	fp.Neg(&sswuC1, &sswuC1)
+	fp.Mul(&sswuC2, &sswuZ, &isoA)
+	fp.Inv(&sswuC2, &sswuC2)
+	fp.Mul(&sswuC2, &sswuC2, &isoB)
+
+	var one field.Element
+	fp.One(&one)
+	isoXNum = []field.Element{
+		setHex("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa8c7"),
+		setHex("07d3d4c80bc321d5b9f315cea7fd44c5d595d2fc0bf63b92dfff1044f17c6581"),
+		setHex("534c328d23f234e6e2a413deca25caece4506144037c40314ecbd0b53d9dd262"),
+		setHex("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa88c"),
+	}
+	isoXDen = []field.Element{
+		setHex("d35771193d94918a9ca34ccbb7b640dd86cd409542f8487d9fe6b745781eb49b"),
+		setHex("edadc6f64383dc1df7c4b2d51b54225406d36b641f5e41bbc52a56612a8c6d14"),
+		one,
+	}
+	isoYNum = []field.Element{
+		setHex("4bda12f684bda12f684bda12f684bda12f684bda12f684bda12f684b8e38e23c"),
+		setHex("c75e0c32d5cb7c0fa9d0a54b12a0a6d5647ab046d686da6fdffc90fc201d71a3"),
+		setHex("29a6194691f91a73715209ef6512e576722830a201be2018a765e85a9ecee931"),
+		setHex("2f684bda12f684bda12f684bda12f684bda12f684bda12f684bda12f38e38d84"),
+	}
+	isoYDen = []field.Element{
+		setHex("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffff93b"),
+		setHex("7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573"),
+		setHex("6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f"),
+		one,
+	}
+}
+
+// Hash hashes the message to a point with the default domain separation tag,
+// see Hash2.
+func (P *point) Hash(m []byte) kyber.Point {
+	return P.Hash2(m, domain)
+}
+
+// Hash2 hashes the message to a point with the suite
+// secp256k1_XMD:SHA-256_SSWU_RO_ of RFC 9380 and the domain separation tag
+// dst. An empty dst is replaced by the default one, since tags must not be
+// empty.
+func (P *point) Hash2(m, dst []byte) kyber.Point {
+	if len(dst) == 0 {
+		dst = domain
+	}
+	// never fails with a non-empty dst and two elements
+	u, _ := xmd.HashToField(sha256.New, m, dst, prime, 128, 2)
+	var u0, u1 field.Element
+	fp.SetBig(&u0, u[0])
+	fp.SetBig(&u1, u[1])
+	var q0, q1 point
+	q0.mapToCurve(&u0)
+	q1.mapToCurve(&u1)
+	// the cofactor of secp256k1 is 1
+	P.add(&q0, &q1)
+	return P
+}
+
+// mapToCurve sets P to the image of u by the simplified SWU map to E' followed
+// by the isogeny to secp256k1, in constant time.
+func (P *point) mapToCurve(u *field.Element) {
+	var tv1, tv2, x1, x2, gx1, gx2, y1, y2 field.Element
+
+	// tv1 = inv0(Z^2*u^4 + Z*u^2)
+	fp.Square(&tv1, u)
+	fp.Mul(&tv1, &sswuZ, &tv1)
+	fp.Square(&tv2, &tv1)
+	fp.Add(&tv2, &tv2, &tv1)
+	fp.Inv(&tv2, &tv2)
+
+	// x1 = -B/A * (1 + tv1), or B/(Z*A) if tv1 is 0
+	var one field.Element
+	fp.One(&one)
+	fp.Add(&x1, &one, &tv2)
+	fp.Mul(&x1, &sswuC1, &x1)
+	fp.Select(&x1, &sswuC2, &x1, fp.IsZero(&tv2))
+	isoRHS(&gx1, &x1)
+
+	// x2 = Z*u^2*x1
+	fp.Mul(&x2, &tv1, &x1)
+	isoRHS(&gx2, &x2)
+
+	isSquare := fp.Sqrt(&y1, &gx1)
+	fp.Sqrt(&y2, &gx2)
+	var x, y field.Element
+	fp.Select(&x, &x1, &x2, isSquare)
+	fp.Select(&y, &y1, &y2, isSquare)
+
+	// the sign of y must match the sign of u
+	var negY field.Element
+	fp.Neg(&negY, &y)
+	fp.Select(&y, &negY, &y, fp.IsOdd(u)^fp.IsOdd(&y))
+
+	P.isogeny(&x, &y)
+}
+
+// isoRHS sets y2 = x^3 + A'x + B'.
+func isoRHS(y2, x *field.Element) {
+	var ax field.Element
+	fp.Mul(&ax, &isoA, x)
+	fp.Square(y2, x)
+	fp.Mul(y2, y2, x)
+	fp.Add(y2, y2, &ax)
+	fp.Add(y2, y2, &isoB)
+}
+
+// polynomial returns the value at x of the polynomial of the coefficients k,
+// with Horner's method.
+func polynomial(k []field.Element, x *field.Element) field.Element {
+	r := k[len(k)-1]
+	for i := len(k) - 2; i >= 0; i-- {
+		fp.Mul(&r, &r, x)
+		fp.Add(&r, &r, &k[i])
+	}
+	return r
+}
+
+// isogeny sets P to the image of the point (x, y) of E' by the 3-isogeny to
+// secp256k1, in projective coordinates to avoid the inversions. The points
+// that cancel the denominators are mapped to the point at infinity.
+func (P *point) isogeny(x, y *field.Element) {
+	xNum := polynomial(isoXNum, x)
+	xDen := polynomial(isoXDen, x)
+	yNum := polynomial(isoYNum, x)
+	yDen := polynomial(isoYDen, x)
+
+	var Q point
+	fp.Mul(&Q.x, &xNum, &yDen)
+	fp.Mul(&Q.y, y, &yNum)
+	fp.Mul(&Q.y, &Q.y, &xDen)
+	fp.Mul(&Q.z, &xDen, &yDen)
+
+	var inf point
+	inf.Null()
+	P.selectPoint(&inf, &Q, fp.IsZero(&Q.z))
+}
@@ -0,0 +1,361 @@
+package secp256k1
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/internal/field"
+	"go.dedis.ch/kyber/v4/group/internal/marshalling"
+)
+
+const (
+	compressedEven = 2
+	compressedOdd  = 3
+	uncompressed   = 4
+)
+
+// point is a point of the curve in projective coordinates (X:Y:Z), which
+// represent the affine point (X/Z, Y/Z). The point at infinity is (0:1:0).
+// The arithmetic uses the complete formulas of Renes, Costello and Batina,
+// "Complete addition formulas for prime order elliptic curves", so it has no
+// exceptional case to handle in variable time.
+type point struct {
+	x, y, z field.Element
+}
+
+// affine returns the affine coordinates of the point, and 1 if it is the
+// point at infinity.
+func (P *point) affine() (x, y field.Element, inf int) {
+	var zInv field.Element
+	fp.Inv(&zInv, &P.z)
+	fp.Mul(&x, &P.x, &zInv)
+	fp.Mul(&y, &P.y, &zInv)
+	return x, y, fp.IsZero(&P.z)
+}
+
+func (P *point) String() string {
+	b, _ := P.MarshalBinary()
+	return hex.EncodeToString(b)
+}
+
+func (P *point) Equal(P2 kyber.Point) bool {
+	Q := P2.(*point) //nolint:errcheck // V4 may bring better error handling
+	var a, b field.Element
+	fp.Mul(&a, &P.x, &Q.z)
+	fp.Mul(&b, &Q.x, &P.z)
+	eq := fp.Equal(&a, &b)
+	fp.Mul(&a, &P.y, &Q.z)
+	fp.Mul(&b, &Q.y, &P.z)
+	return eq&fp.Equal(&a, &b) == 1
+}
+
+func (P *point) Null() kyber.Point {
+	fp.Zero(&P.x)
+	fp.One(&P.y)
+	fp.Zero(&P.z)
+	return P
+}
+
+func (P *point) Base() kyber.Point {
+	*P = base
+	return P
+}
+
+func (P *point) Set(P2 kyber.Point) kyber.Point {
+	*P = *P2.(*point)
+	return P
+}
+
+func (P *point) Clone() kyber.Point {
+	Q := *P
+	return &Q
+}
+
+func (P *point) Pick(rand cipher.Stream) kyber.Point {
+	return P.Embed(nil, rand)
+}
+
+func (P *point) EmbedLen() int {
+	// Reserve the most-significant 8 bits for pseudo-randomness.
+	// Reserve the least-significant 8 bits for embedded data length.
+	return (256 - 8 - 8) / 8
+}
+
+// Embed sets the point to one whose x-coordinate holds the data: its last
+// byte holds the length of the data, which precedes it, and the other bytes
+// are random.
+func (P *point) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	dl := P.EmbedLen()
+	if dl > len(data) {
+		dl = len(data)
+	}
+	for {
+		var b [field.Size + 1]byte
+		rand.XORKeyStream(b[:], b[:])
+		if data != nil {
+			b[field.Size-1] = byte(dl)
+			copy(b[field.Size-1-dl:field.Size-1], data)
+		}
+		b[field.Size] = compressedEven | (b[field.Size] & 1)
+		// move the prefix with the random sign first
+		buf := append([]byte{b[field.Size]}, b[:field.Size]...)
+		if P.UnmarshalBinary(buf) == nil {
+			return P
+		}
+	}
+}
+
+// Data extracts the data embedded in the point.
+func (P *point) Data() ([]byte, error) {
+	x, _, _ := P.affine()
+	b := fp.Bytes(&x)
+	dl := int(b[field.Size-1])
+	if dl > P.EmbedLen() {
+		return nil, errors.New("invalid embedded data length")
+	}
+	return b[field.Size-1-dl : field.Size-1], nil
+}
+
+// add sets P = A + B, with the algorithm 7 of Renes, Costello and Batina for
+// the curves with a = 0.
+func (P *point) add(A, B *point) {
+	var t0, t1, t2, t3, t4, x3, y3, z3 field.Element
+	fp.Mul(&t0, &A.x, &B.x)
+	fp.Mul(&t1, &A.y, &B.y)
+	fp.Mul(&t2, &A.z, &B.z)
+	fp.Add(&t3, &A.x, &A.y)
+	fp.Add(&t4, &B.x, &B.y)
+	fp.Mul(&t3, &t3, &t4)
+	fp.Add(&t4, &t0, &t1)
+	fp.Sub(&t3, &t3, &t4)
+	fp.Add(&t4, &A.y, &A.z)
+	fp.Add(&x3, &B.y, &B.z)
+	fp.Mul(&t4, &t4, &x3)
+	fp.Add(&x3, &t1, &t2)
+	fp.Sub(&t4, &t4, &x3)
+	fp.Add(&x3, &A.x, &A.z)
+	fp.Add(&y3, &B.x, &B.z)
+	fp.Mul(&x3, &x3, &y3)
+	fp.Add(&y3, &t0, &t2)
+	fp.Sub(&y3, &x3, &y3)
+	fp.Add(&x3, &t0, &t0)
+	fp.Add(&t0, &x3, &t0)
+	fp.Mul(&t2, &curveB3, &t2)
+	fp.Add(&z3, &t1, &t2)
+	fp.Sub(&t1, &t1, &t2)
+	fp.Mul(&y3, &curveB3, &y3)
+	fp.Mul(&x3, &t4, &y3)
+	fp.Mul(&t2, &t3, &t1)
+	fp.Sub(&x3, &t2, &x3)
+	fp.Mul(&y3, &y3, &t0)
+	fp.Mul(&t1, &t1, &z3)
+	fp.Add(&y3, &t1, &y3)
+	fp.Mul(&t0, &t0, &t3)
+	fp.Mul(&z3, &z3, &t4)
+	fp.Add(&z3, &z3, &t0)
+	P.x, P.y, P.z = x3, y3, z3
+}
+
+// double sets P = 2A, with the algorithm 9 of Renes, Costello and Batina for
+// the curves with a = 0.
+func (P *point) double(A *point) {
+	var t0, t1, t2, x3, y3, z3 field.Element
+	fp.Square(&t0, &A.y)
+	fp.Add(&z3, &t0, &t0)
+	fp.Add(&z3, &z3, &z3)
+	fp.Add(&z3, &z3, &z3)
+	fp.Mul(&t1, &A.y, &A.z)
+	fp.Square(&t2, &A.z)
+	fp.Mul(&t2, &curveB3, &t2)
+	fp.Mul(&x3, &t2, &z3)
+	fp.Add(&y3, &t0, &t2)
+	fp.Mul(&z3, &t1, &z3)
+	fp.Add(&t1, &t2, &t2)
+	fp.Add(&t2, &t1, &t2)
+	fp.Sub(&t0, &t0, &t2)
+	fp.Mul(&y3, &t0, &y3)
+	fp.Add(&y3, &x3, &y3)
+	fp.Mul(&t1, &A.x, &A.y)
+	fp.Mul(&x3, &t0, &t1)
+	fp.Add(&x3, &x3, &x3)
+	P.x, P.y, P.z = x3, y3, z3
+}
+
+// selectPoint sets P to A if cond is 1 and to B if cond is 0.
+func (P *point) selectPoint(A, B *point, cond int) {
+	fp.Select(&P.x, &A.x, &B.x, cond)
+	fp.Select(&P.y, &A.y, &B.y, cond)
+	fp.Select(&P.z, &A.z, &B.z, cond)
+}
+
+func (P *point) Add(A, B kyber.Point) kyber.Point {
+	P.add(A.(*point), B.(*point))
+	return P
+}
+
+func (P *point) Sub(A, B kyber.Point) kyber.Point {
+	var neg point
+	neg.Neg(B)
+	P.add(A.(*point), &neg)
+	return P
+}
+
+func (P *point) Neg(A kyber.Point) kyber.Point {
+	Q := A.(*point) //nolint:errcheck // V4 may bring better error handling
+	P.x = Q.x
+	fp.Neg(&P.y, &Q.y)
+	P.z = Q.z
+	return P
+}
+
+// Mul sets P to s*A, or s times the base point if A is nil, with a fixed
+// window of 4 bits and constant time lookups in the table of the multiples.
+func (P *point) Mul(s kyber.Scalar, A kyber.Point) kyber.Point {
+	var table [16]point
+	table[0].Null()
+	if A == nil {
+		table[1] = base
+	} else {
+		table[1] = *A.(*point)
+	}
+	for i := 2; i < len(table); i++ {
+		table[i].add(&table[i-1], &table[1])
+	}
+
+	k := fn.Bytes(&s.(*scalar).v)
+	var acc, entry point
+	acc.Null()
+	for i := 0; i < 2*len(k); i++ {
+		for j := 0; j < 4; j++ {
+			acc.double(&acc)
+		}
+		nibble := k[i/2] >> 4
+		if i%2 == 1 {
+			nibble = k[i/2] & 0xf
+		}
+		entry.Null()
+		for j := range table {
+			entry.selectPoint(&table[j], &entry, subtle.ConstantTimeByteEq(nibble, byte(j)))
+		}
+		acc.add(&acc, &entry)
+	}
+	*P = acc
+	return P
+}
+
+func (P *point) MarshalSize() int {
+	return 1 + field.Size
+}
+
+// MarshalBinary returns the SEC1 compressed encoding of the point. The point
+// at infinity is encoded as zeros.
+func (P *point) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 1+field.Size)
+	x, y, inf := P.affine()
+	if inf == 1 {
+		return buf, nil
+	}
+	buf[0] = compressedEven | byte(fp.IsOdd(&y))
+	xb := fp.Bytes(&x)
+	copy(buf[1:], xb[:])
+	return buf, nil
+}
+
+// MarshalUncompressed returns the SEC1 uncompressed encoding of the point,
+// which also holds the y-coordinate. The point at infinity is encoded as
+// zeros.
+func (P *point) MarshalUncompressed() []byte {
+	buf := make([]byte, 1+2*field.Size)
+	x, y, inf := P.affine()
+	if inf == 1 {
+		return buf
+	}
+	buf[0] = uncompressed
+	xb := fp.Bytes(&x)
+	yb := fp.Bytes(&y)
+	copy(buf[1:], xb[:])
+	copy(buf[1+field.Size:], yb[:])
+	return buf
+}
+
+// UnmarshalBinary decodes a point in the SEC1 compressed form produced by
+// MarshalBinary, checking that it is on the curve. The point at infinity is
+// only accepted as zeros; the other encodings are rejected, so that every
+// point has a single encoding.
+func (P *point) UnmarshalBinary(buf []byte) error {
+	var x, y, y2 field.Element
+	switch {
+	case len(buf) == 1+field.Size && allZeros(buf):
+		P.Null()
+		return nil
+	case len(buf) == 1+field.Size && (buf[0] == compressedEven || buf[0] == compressedOdd):
+		if fp.SetBytes(&x, buf[1:]) != 1 {
+			return errors.New("invalid secp256k1 point coordinate")
+		}
+		rhs(&y2, &x)
+		if fp.Sqrt(&y, &y2) != 1 {
+			return errors.New("invalid secp256k1 point")
+		}
+		var negY field.Element
+		fp.Neg(&negY, &y)
+		fp.Select(&y, &negY, &y, fp.IsOdd(&y)^int(buf[0]&1))
+	default:
+		return errors.New("invalid secp256k1 point encoding")
+	}
+	P.x, P.y = x, y
+	fp.One(&P.z)
+	return nil
+}
+
+// UnmarshalUncompressed decodes a point in the SEC1 uncompressed form
+// produced by MarshalUncompressed, checking that it is on the curve.
+func (P *point) UnmarshalUncompressed(buf []byte) error {
+	var x, y, y2, yy field.Element
+	switch {
+	case len(buf) == 1+2*field.Size && allZeros(buf):
+		P.Null()
+		return nil
+	case len(buf) == 1+2*field.Size && buf[0] == uncompressed:
+		if fp.SetBytes(&x, buf[1:1+field.Size]) != 1 ||
+			fp.SetBytes(&y, buf[1+field.Size:]) != 1 {
+			return errors.New("invalid secp256k1 point coordinate")
+		}
+		rhs(&y2, &x)
+		fp.Square(&yy, &y)
+		if fp.Equal(&yy, &y2) != 1 {
+			return errors.New("invalid secp256k1 point")
+		}
+	default:
+		return errors.New("invalid secp256k1 point encoding")
+	}
+	P.x, P.y = x, y
+	fp.One(&P.z)
+	return nil
+}
+
+// rhs sets y2 = x^3 + b.
+func rhs(y2, x *field.Element) {
+	fp.Square(y2, x)
+	fp.Mul(y2, y2, x)
+	fp.Add(y2, y2, &curveB)
+}
+
+func allZeros(buf []byte) bool {
+	var acc byte
+	for _, b := range buf {
+		acc |= b
+	}
+	return acc == 0
+}
+
+func (P *point) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.PointMarshalTo(P, w)
+}
+
+func (P *point) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.PointUnmarshalFrom(P, r)
+}
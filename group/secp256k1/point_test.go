@@ -0,0 +1,252 @@
+package secp256k1
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/random"
+	"go.dedis.ch/kyber/v4/util/test"
+)
+
+var tSuite = NewBlakeSHA256Secp256k1()
+
+func TestSuite(t *testing.T) { test.SuiteTest(t, tSuite) }
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// affineHex returns the hexadecimal affine coordinates of P.
+func affineHex(P kyber.Point) (string, string) {
+	x, y, _ := P.(*point).affine()
+	xb, yb := fp.Bytes(&x), fp.Bytes(&y)
+	return hex.EncodeToString(xb[:]), hex.EncodeToString(yb[:])
+}
+
+func TestGeneratorEncoding(t *testing.T) {
+	G := tSuite.Point().Base()
+	buf, err := G.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		hex.EncodeToString(buf))
+	require.Equal(t, "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"+
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+		hex.EncodeToString(G.(*point).MarshalUncompressed()))
+
+	P := tSuite.Point()
+	require.NoError(t, P.UnmarshalBinary(buf))
+	require.True(t, P.Equal(G))
+	P = tSuite.Point()
+	require.NoError(t, P.(*point).UnmarshalUncompressed(G.(*point).MarshalUncompressed()))
+	require.True(t, P.Equal(G))
+}
+
+var multiples = []struct{ x, y string }{
+	{
+		"c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5",
+		"1ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a",
+	},
+	{
+		"f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9",
+		"388f7b0f632de8140fe337e62a37f3566500a99934c2231b6cb9fd7584b8e672",
+	},
+}
+
+func TestMultiples(t *testing.T) {
+	G := tSuite.Point().Base()
+	P := tSuite.Point().Add(G, G)
+	for i, exp := range multiples {
+		x, y := affineHex(P)
+		require.Equal(t, exp.x, x, "multiple %d", i+2)
+		require.Equal(t, exp.y, y, "multiple %d", i+2)
+
+		Q := tSuite.Point().Mul(tSuite.Scalar().SetInt64(int64(i+2)), nil)
+		require.True(t, Q.Equal(P))
+		P.Add(P, G)
+	}
+
+	// doubling and adding the point at infinity
+	O := tSuite.Point().Null()
+	require.True(t, O.Add(O, O).Equal(tSuite.Point().Null()))
+	require.True(t, O.Add(O, G).Equal(G))
+	require.True(t, O.Sub(G, G).Equal(tSuite.Point().Null()))
+}
+
+// mulReference returns k*(x, y) with the affine formulas over big.Int.
+func mulReference(k, x, y *big.Int) (*big.Int, *big.Int) {
+	var rx, ry *big.Int
+	add := func(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+		if x1 == nil {
+			return x2, y2
+		}
+		var l *big.Int
+		if x1.Cmp(x2) == 0 {
+			if y1.Cmp(y2) != 0 {
+				return nil, nil
+			}
+			l = new(big.Int).Mul(x1, x1)
+			l.Mul(l, big.NewInt(3))
+			d := new(big.Int).Lsh(y1, 1)
+			l.Mul(l, d.ModInverse(d, prime))
+		} else {
+			l = new(big.Int).Sub(y2, y1)
+			d := new(big.Int).Sub(x2, x1)
+			d.Mod(d, prime)
+			l.Mul(l, d.ModInverse(d, prime))
+		}
+		l.Mod(l, prime)
+		x3 := new(big.Int).Mul(l, l)
+		x3.Sub(x3, x1).Sub(x3, x2).Mod(x3, prime)
+		y3 := new(big.Int).Sub(x1, x3)
+		y3.Mul(y3, l).Sub(y3, y1).Mod(y3, prime)
+		return x3, y3
+	}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		if rx != nil {
+			rx, ry = add(rx, ry, rx, ry)
+		}
+		if k.Bit(i) == 1 {
+			rx, ry = add(rx, ry, x, y)
+		}
+	}
+	return rx, ry
+}
+
+func TestMul(t *testing.T) {
+	rand := random.New()
+	for i := 0; i < 10; i++ {
+		P := tSuite.Point().Pick(rand)
+		s := tSuite.Scalar().Pick(rand)
+		Q := tSuite.Point().Mul(s, P)
+
+		x, y, _ := P.(*point).affine()
+		ex, ey := mulReference(fn.Big(&s.(*scalar).v), fp.Big(&x), fp.Big(&y))
+		qx, qy := affineHex(Q)
+		require.Equal(t, hex.EncodeToString(ex.FillBytes(make([]byte, 32))), qx)
+		require.Equal(t, hex.EncodeToString(ey.FillBytes(make([]byte, 32))), qy)
+	}
+
+	// multiplications by the order and by zero give the point at infinity
+	G := tSuite.Point().Base()
+	require.True(t, tSuite.Point().Mul(tSuite.Scalar().Zero(), G).Equal(tSuite.Point().Null()))
+	minusOne := tSuite.Scalar().SetInt64(-1)
+	P := tSuite.Point().Mul(minusOne, G)
+	require.True(t, P.Add(P, G).Equal(tSuite.Point().Null()))
+}
+
+func TestInvalidEncodings(t *testing.T) {
+	invalid := []string{
+		// x is not on the curve: x^3 + 7 is not a square for x = 5
+		"020000000000000000000000000000000000000000000000000000000000000005",
+		// x >= p
+		"02fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+		// invalid prefixes
+		"0579be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		"0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		// the uncompressed generator, which is not the encoding of MarshalBinary
+		"0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+			"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+		// invalid lengths
+		"0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f817",
+		"",
+		// other encodings of the point at infinity
+		"00",
+		"00000000000000000000000000000000000000000000000000000000000000000000000000" +
+			"000000000000000000000000000000000000000000000000000000000000",
+	}
+	for _, enc := range invalid {
+		require.Error(t, tSuite.Point().UnmarshalBinary(decodeHex(t, enc)), enc)
+	}
+
+	// the y-coordinate of the generator is off by one
+	offCurve := "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b9"
+	require.Error(t, tSuite.Point().(*point).UnmarshalUncompressed(decodeHex(t, offCurve)))
+	compressed, err := tSuite.Point().Base().MarshalBinary()
+	require.NoError(t, err)
+	require.Error(t, tSuite.Point().(*point).UnmarshalUncompressed(compressed))
+
+	// the point at infinity
+	P := tSuite.Point().Base()
+	require.NoError(t, P.UnmarshalBinary(make([]byte, 33)))
+	require.True(t, P.Equal(tSuite.Point().Null()))
+	P = tSuite.Point().Base()
+	require.NoError(t, P.(*point).UnmarshalUncompressed(make([]byte, 65)))
+	require.True(t, P.Equal(tSuite.Point().Null()))
+}
+
+// RFC 9380, appendix J.8.1: secp256k1_XMD:SHA-256_SSWU_RO_.
+var hashVectors = []struct {
+	msg, x, y string
+}{
+	{
+		"",
+		"c1cae290e291aee617ebaef1be6d73861479c48b841eaba9b7b5852ddfeb1346",
+		"64fa678e07ae116126f08b022a94af6de15985c996c3a91b64c406a960e51067",
+	},
+	{
+		"abc",
+		"3377e01eab42db296b512293120c6cee72b6ecf9f9205760bd9ff11fb3cb2c4b",
+		"7f95890f33efebd1044d382a01b1bee0900fb6116f94688d487c6c7b9c8371f6",
+	},
+	{
+		"abcdef0123456789",
+		"bac54083f293f1fe08e4a70137260aa90783a5cb84d3f35848b324d0674b0e3a",
+		"4436476085d4c3c4508b60fcf4389c40176adce756b398bdee27bca19758d828",
+	},
+}
+
+func TestHashToCurve(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+	for _, v := range hashVectors {
+		P := tSuite.Point().(kyber.HashablePointWithDST).Hash2([]byte(v.msg), dst)
+		x, y := affineHex(P)
+		require.Equal(t, v.x, x, v.msg)
+		require.Equal(t, v.y, y, v.msg)
+	}
+}
+
+func TestHash(t *testing.T) {
+	msg := []byte("message")
+	p1 := tSuite.Point().(kyber.HashablePoint).Hash(msg)
+	p2 := tSuite.Point().(kyber.HashablePointWithDST).Hash2(msg, domain)
+	require.True(t, p1.Equal(p2))
+
+	p3 := tSuite.Point().(kyber.HashablePointWithDST).Hash2(msg, []byte("other DST"))
+	require.False(t, p1.Equal(p3))
+}
+
+func TestEmbed(t *testing.T) {
+	data := []byte("secp256k1 embedding")
+	p := tSuite.Point().Embed(data, random.New())
+	got, err := p.Data()
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+}
+
+func TestScalarEncoding(t *testing.T) {
+	s := tSuite.Scalar().SetInt64(0x010203)
+	buf, err := s.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, "0000000000000000000000000000000000000000000000000000000000010203",
+		hex.EncodeToString(buf))
+
+	// the order is not a canonical encoding
+	require.Error(t, tSuite.Scalar().UnmarshalBinary(order.Bytes()))
+}
+
+var bench = test.NewGroupBench(tSuite)
+
+func BenchmarkScalarMul(b *testing.B)    { bench.ScalarMul(b.N) }
+func BenchmarkScalarInv(b *testing.B)    { bench.ScalarInv(b.N) }
+func BenchmarkPointAdd(b *testing.B)     { bench.PointAdd(b.N) }
+func BenchmarkPointMul(b *testing.B)     { bench.PointMul(b.N) }
+func BenchmarkPointBaseMul(b *testing.B) { bench.PointBaseMul(b.N) }
+func BenchmarkPointPick(b *testing.B)    { bench.PointPick(b.N) }
+func BenchmarkPointEncode(b *testing.B)  { bench.PointEncode(b.N) }
+func BenchmarkPointDecode(b *testing.B)  { bench.PointDecode(b.N) }
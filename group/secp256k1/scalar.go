@@ -0,0 +1,134 @@
+package secp256k1
+
+import (
+	"crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/internal/field"
+	"go.dedis.ch/kyber/v4/group/internal/marshalling"
+)
+
+// scalar is an integer modulo the order of the curve.
+type scalar struct {
+	v field.Element
+}
+
+func (s *scalar) Equal(s2 kyber.Scalar) bool {
+	return fn.Equal(&s.v, &s2.(*scalar).v) == 1
+}
+
+func (s *scalar) Set(a kyber.Scalar) kyber.Scalar {
+	s.v = a.(*scalar).v
+	return s
+}
+
+func (s *scalar) Clone() kyber.Scalar {
+	return &scalar{v: s.v}
+}
+
+func (s *scalar) SetInt64(v int64) kyber.Scalar {
+	fn.SetInt64(&s.v, v)
+	return s
+}
+
+func (s *scalar) Zero() kyber.Scalar {
+	fn.Zero(&s.v)
+	return s
+}
+
+func (s *scalar) One() kyber.Scalar {
+	fn.One(&s.v)
+	return s
+}
+
+func (s *scalar) Add(a, b kyber.Scalar) kyber.Scalar {
+	fn.Add(&s.v, &a.(*scalar).v, &b.(*scalar).v)
+	return s
+}
+
+func (s *scalar) Sub(a, b kyber.Scalar) kyber.Scalar {
+	fn.Sub(&s.v, &a.(*scalar).v, &b.(*scalar).v)
+	return s
+}
+
+func (s *scalar) Neg(a kyber.Scalar) kyber.Scalar {
+	fn.Neg(&s.v, &a.(*scalar).v)
+	return s
+}
+
+func (s *scalar) Mul(a, b kyber.Scalar) kyber.Scalar {
+	fn.Mul(&s.v, &a.(*scalar).v, &b.(*scalar).v)
+	return s
+}
+
+// Div sets s to a / b, or 0 if b is 0.
+func (s *scalar) Div(a, b kyber.Scalar) kyber.Scalar {
+	var inv field.Element
+	fn.Inv(&inv, &b.(*scalar).v)
+	fn.Mul(&s.v, &a.(*scalar).v, &inv)
+	return s
+}
+
+// Inv sets s to the inverse of a, or 0 if a is 0.
+func (s *scalar) Inv(a kyber.Scalar) kyber.Scalar {
+	fn.Inv(&s.v, &a.(*scalar).v)
+	return s
+}
+
+// Pick sets s to a uniformly random scalar, reducing 64 random bytes so that
+// the bias is negligible.
+func (s *scalar) Pick(rand cipher.Stream) kyber.Scalar {
+	var b [2 * field.Size]byte
+	rand.XORKeyStream(b[:], b[:])
+	fn.SetWideBytes(&s.v, b[:])
+	return s
+}
+
+// SetBytes sets s to the big-endian integer buf, reduced modulo the order.
+func (s *scalar) SetBytes(buf []byte) kyber.Scalar {
+	fn.SetWideBytes(&s.v, buf)
+	return s
+}
+
+func (s *scalar) ByteOrder() kyber.ByteOrder {
+	return kyber.BigEndian
+}
+
+func (s *scalar) GroupOrder() *big.Int {
+	return fn.Modulus()
+}
+
+func (s *scalar) String() string {
+	b := fn.Bytes(&s.v)
+	return hex.EncodeToString(b[:])
+}
+
+func (s *scalar) MarshalSize() int {
+	return field.Size
+}
+
+func (s *scalar) MarshalBinary() ([]byte, error) {
+	b := fn.Bytes(&s.v)
+	return b[:], nil
+}
+
+// UnmarshalBinary decodes a big-endian scalar, rejecting the values that are
+// not reduced modulo the order.
+func (s *scalar) UnmarshalBinary(buf []byte) error {
+	if fn.SetBytes(&s.v, buf) != 1 {
+		return errors.New("invalid secp256k1 scalar")
+	}
+	return nil
+}
+
+func (s *scalar) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.ScalarMarshalTo(s, w)
+}
+
+func (s *scalar) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.ScalarUnmarshalFrom(s, r)
+}
@@ -13,3 +13,17 @@ type HashFactory interface {
 type HashablePoint interface {
 	Hash([]byte) Point
 }
+
+// HashablePointWithDST is an interface implemented by the curves that can
+// hash to a point using an explicit domain separation tag, as defined in
+// RFC 9380, instead of the one they were configured with.
+type HashablePointWithDST interface {
+	Hash2(msg, dst []byte) Point
+}
+
+// EncodablePoint is an interface implemented by the curves that can encode a
+// message to a point with the nonuniform encode_to_curve of RFC 9380, which
+// is cheaper than hashing but whose output is not uniformly distributed.
+type EncodablePoint interface {
+	EncodeToCurve(msg, dst []byte) Point
+}
@@ -0,0 +1,74 @@
+// Package xmd implements expand_message_xmd and hash_to_field from RFC 9380,
+// section 5, shared by the hash-to-curve and hash-to-scalar functions of the
+// prime order groups.
+package xmd
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// longDSTPrefix is prepended to the domain separation tags longer than 255
+// bytes before hashing them, see RFC 9380, section 5.3.3.
+const longDSTPrefix = "H2C-OVERSIZE-DST-"
+
+// Expand returns length uniformly random bytes derived from msg and the domain
+// separation tag dst with the hash function h, as specified by
+// expand_message_xmd.
+func Expand(h func() hash.Hash, msg, dst []byte, length int) ([]byte, error) {
+	H := h()
+	ell := (length + H.Size() - 1) / H.Size()
+	if ell > 255 || length > 65535 || len(dst) == 0 {
+		return nil, errors.New("xmd: invalid parameters")
+	}
+
+	if len(dst) > 255 {
+		H.Write([]byte(longDSTPrefix))
+		H.Write(dst)
+		dst = H.Sum(nil)
+		H.Reset()
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
+	H.Write(make([]byte, H.BlockSize()))
+	H.Write(msg)
+	H.Write([]byte{byte(length >> 8), byte(length), 0})
+	H.Write(dstPrime)
+	b0 := H.Sum(nil)
+
+	// b_i = H(strxor(b_0, b_(i-1)) || I2OSP(i, 1) || DST_prime)
+	out := make([]byte, 0, ell*H.Size())
+	bi := make([]byte, len(b0))
+	for i := 1; i <= ell; i++ {
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		H.Reset()
+		H.Write(bi)
+		H.Write([]byte{byte(i)})
+		H.Write(dstPrime)
+		bi = H.Sum(nil)
+		out = append(out, bi...)
+	}
+	return out[:length], nil
+}
+
+// HashToField returns count elements of the prime field of order p derived
+// from msg and dst with expand_message_xmd, each one reduced from L bytes,
+// where L is large enough to make the bias negligible for a security level of
+// k bits.
+func HashToField(h func() hash.Hash, msg, dst []byte, p *big.Int, k, count int) ([]*big.Int, error) {
+	L := (p.BitLen() + k + 7) / 8
+	buf, err := Expand(h, msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+	u := make([]*big.Int, count)
+	for i := range u {
+		u[i] = new(big.Int).SetBytes(buf[i*L : (i+1)*L])
+		u[i].Mod(u[i], p)
+	}
+	return u, nil
+}
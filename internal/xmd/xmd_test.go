@@ -0,0 +1,63 @@
+package xmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors of RFC 9380, appendix K.1.
+func TestExpandSHA256(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	vectors := []struct {
+		msg      string
+		length   int
+		expected string
+	}{
+		{"", 0x20, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{"abc", 0x20, "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+		{"abcdef0123456789", 0x20, "eff31487c770a893cfb36f912fbfcbff40d5661771ca4b2cb4eafe524333f5c1"},
+		{"", 0x80, "af84c27ccfd45d41914fdff5df25293e221afc53d8ad2ac06d5e3e29485dadbee0d121587713a3e0dd4d5e69e93eb7cd4f5df4cd103e188cf60cb02edc3edf18eda8576c412b18ffb658e3dd6ec849469b979d444cf7b26911a08e63cf31f9dcc541708d3491184472c2c29bb749d4286b004ceb5ee6b9a7fa5b646c993f0ced"},
+	}
+	for _, v := range vectors {
+		out, err := Expand(sha256.New, []byte(v.msg), dst, v.length)
+		require.NoError(t, err)
+		require.Equal(t, v.expected, hex.EncodeToString(out))
+	}
+}
+
+func TestExpandLongDST(t *testing.T) {
+	long := []byte(strings.Repeat("a", 256))
+	h := sha256.New()
+	h.Write([]byte(longDSTPrefix))
+	h.Write(long)
+
+	out1, err := Expand(sha256.New, []byte("msg"), long, 48)
+	require.NoError(t, err)
+	out2, err := Expand(sha256.New, []byte("msg"), h.Sum(nil), 48)
+	require.NoError(t, err)
+	require.Equal(t, out1, out2)
+
+	_, err = Expand(sha256.New, nil, nil, 32)
+	require.Error(t, err)
+	_, err = Expand(sha256.New, nil, []byte("dst"), 256*32)
+	require.Error(t, err)
+}
+
+func TestHashToField(t *testing.T) {
+	p := big.NewInt(65521)
+	u, err := HashToField(sha256.New, []byte("msg"), []byte("dst"), p, 128, 3)
+	require.NoError(t, err)
+	require.Len(t, u, 3)
+
+	buf, err := Expand(sha256.New, []byte("msg"), []byte("dst"), 3*18)
+	require.NoError(t, err)
+	for i := range u {
+		expected := new(big.Int).SetBytes(buf[i*18 : (i+1)*18])
+		require.Equal(t, 0, expected.Mod(expected, p).Cmp(u[i]))
+	}
+}
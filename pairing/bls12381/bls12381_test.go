@@ -22,6 +22,7 @@ import (
 	"go.dedis.ch/kyber/v4/sign/bls"
 	"go.dedis.ch/kyber/v4/sign/tbls"
 	"go.dedis.ch/kyber/v4/util/random"
+	utiltest "go.dedis.ch/kyber/v4/util/test"
 	"go.dedis.ch/kyber/v4/xof/blake2xb"
 	"gopkg.in/yaml.v3"
 )
@@ -418,6 +419,30 @@ func TestKyberG2(t *testing.T) {
 	}
 }
 
+func TestKyberMultiScalarMul(t *testing.T) {
+	suites := []pairing.Suite{
+		kilic.NewBLS12381Suite(),
+		circl.NewSuiteBLS12381(),
+	}
+
+	for _, suite := range suites {
+		utiltest.MultiScalarMulTest(t, suite.G1(), random.New())
+		utiltest.MultiScalarMulTest(t, suite.G2(), random.New())
+	}
+}
+
+func TestKyberPrecomputedPoint(t *testing.T) {
+	suites := []pairing.Suite{
+		kilic.NewBLS12381Suite(),
+		circl.NewSuiteBLS12381(),
+	}
+
+	for _, suite := range suites {
+		utiltest.PrecomputedPointTest(t, suite.G1(), random.New())
+		utiltest.PrecomputedPointTest(t, suite.G2(), random.New())
+	}
+}
+
 func TestKyberPairingG2(t *testing.T) {
 	suites := []pairing.Suite{
 		kilic.NewBLS12381Suite(),
@@ -596,6 +621,38 @@ func TestBasicPairing(t *testing.T) {
 	}
 }
 
+func TestMultiPair(t *testing.T) {
+	suites := []pairing.Suite{
+		kilic.NewBLS12381Suite(),
+		circl.NewSuiteBLS12381(),
+	}
+
+	for _, suite := range suites {
+		var g1s, g2s []kyber.Point
+		expected := suite.GT().Point().Null()
+		for i := 0; i < 4; i++ {
+			p1 := suite.G1().Point().Pick(random.New())
+			p2 := suite.G2().Point().Pick(random.New())
+			g1s = append(g1s, p1)
+			g2s = append(g2s, p2)
+			expected.Add(expected, pair(suite, p1, p2))
+		}
+		g1s = append(g1s, suite.G1().Point().Null(), newG1(suite))
+		g2s = append(g2s, newG2(suite), suite.G2().Point().Null())
+		require.True(t, expected.Equal(suite.MultiPair(g1s, g2s)), "%T", suite)
+
+		// e(aG1, bG2) * e(-abG1, G2) = 1
+		a := newElement(suite).Pick(random.New())
+		b := newElement(suite).Pick(random.New())
+		aG := newG1(suite).Mul(a, nil)
+		bG := newG2(suite).Mul(b, nil)
+		abG := newG1(suite).Mul(newElement(suite).Mul(a, b), nil)
+		require.True(t, suite.PairingCheck([]kyber.Point{aG, abG.Clone().Neg(abG)}, []kyber.Point{bG, newG2(suite)}))
+		require.False(t, suite.PairingCheck([]kyber.Point{aG, abG}, []kyber.Point{bG, newG2(suite)}))
+		require.Panics(t, func() { suite.MultiPair(g1s, g2s[1:]) })
+	}
+}
+
 // Benchmarking
 func BenchmarkPairingSeparate(bb *testing.B) {
 	var suites = []pairing.Suite{
@@ -0,0 +1,96 @@
+//nolint:dupl // unavoidable duplication between g1 and g2
+package circl
+
+import (
+	bls12381 "github.com/cloudflare/circl/ecc/bls12381"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/msm"
+)
+
+// MultiScalarMul sets p to the sum of scalars[i] * points[i], computed with
+// Pippenger's bucket method. It runs in variable time.
+func (p *G1Elt) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	msm.CheckLengths(scalars, points)
+	if len(points) == 0 {
+		p.inner.SetIdentity()
+		return p
+	}
+
+	c := msm.WindowSize(len(points), 8*bls12381.ScalarSize)
+	digits := msm.Digits(scalars, c)
+	buckets := make([]bls12381.G1, 1<<c-1)
+	// accumulate in acc, as p may be one of the points
+	var acc, sum, window bls12381.G1
+	acc.SetIdentity()
+
+	for w := len(digits[0]) - 1; w >= 0; w-- {
+		for i := 0; i < c; i++ {
+			acc.Double()
+		}
+
+		for k := range buckets {
+			buckets[k].SetIdentity()
+		}
+		for i, d := range digits {
+			if k := d[w]; k != 0 {
+				buckets[k-1].Add(&buckets[k-1], &points[i].(*G1Elt).inner)
+			}
+		}
+
+		// window = sum_k k * buckets[k-1], computed with running sums
+		sum.SetIdentity()
+		window.SetIdentity()
+		for k := len(buckets) - 1; k >= 0; k-- {
+			sum.Add(&sum, &buckets[k])
+			window.Add(&window, &sum)
+		}
+
+		acc.Add(&acc, &window)
+	}
+	p.inner = acc
+	return p
+}
+
+// MultiScalarMul sets p to the sum of scalars[i] * points[i], computed with
+// Pippenger's bucket method. It runs in variable time.
+func (p *G2Elt) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	msm.CheckLengths(scalars, points)
+	if len(points) == 0 {
+		p.inner.SetIdentity()
+		return p
+	}
+
+	c := msm.WindowSize(len(points), 8*bls12381.ScalarSize)
+	digits := msm.Digits(scalars, c)
+	buckets := make([]bls12381.G2, 1<<c-1)
+	// accumulate in acc, as p may be one of the points
+	var acc, sum, window bls12381.G2
+	acc.SetIdentity()
+
+	for w := len(digits[0]) - 1; w >= 0; w-- {
+		for i := 0; i < c; i++ {
+			acc.Double()
+		}
+
+		for k := range buckets {
+			buckets[k].SetIdentity()
+		}
+		for i, d := range digits {
+			if k := d[w]; k != 0 {
+				buckets[k-1].Add(&buckets[k-1], &points[i].(*G2Elt).inner)
+			}
+		}
+
+		// window = sum_k k * buckets[k-1], computed with running sums
+		sum.SetIdentity()
+		window.SetIdentity()
+		for k := len(buckets) - 1; k >= 0; k-- {
+			sum.Add(&sum, &buckets[k])
+			window.Add(&window, &sum)
+		}
+
+		acc.Add(&acc, &window)
+	}
+	p.inner = acc
+	return p
+}
@@ -0,0 +1,143 @@
+//nolint:dupl // unavoidable duplication between g1 and g2
+package circl
+
+import (
+	bls12381 "github.com/cloudflare/circl/ecc/bls12381"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/msm"
+	"go.dedis.ch/kyber/v4/util/precomp"
+)
+
+// tableWindows is the number of digits of a scalar in base 2^precomp.Window.
+const tableWindows = (8*bls12381.ScalarSize + precomp.Window - 1) / precomp.Window
+
+// tableRow is the number of non-zero digits in base 2^precomp.Window.
+const tableRow = 1<<precomp.Window - 1
+
+// g1Table is a kyber.PrecomputedPoint for G1, holding the points
+// d * 2^(precomp.Window*i) * base at index i*tableRow + d-1. The lookups
+// depend on the scalar, so the table is only used once variable time has
+// been allowed; until then Mul falls back to the constant time ScalarMult.
+type g1Table struct {
+	base  G1Elt
+	table []bls12381.G1
+}
+
+// Precompute returns a table of multiples of p that speeds up its
+// multiplication by public scalars.
+func (p *G1Elt) Precompute() kyber.PrecomputedPoint {
+	return &g1Table{base: *p}
+}
+
+// AllowVarTime builds the table when varTime is true, and drops it
+// otherwise. It must be called before the table is shared between
+// goroutines.
+func (t *g1Table) AllowVarTime(varTime bool) {
+	if !varTime {
+		t.table = nil
+		return
+	}
+	if t.table != nil {
+		return
+	}
+
+	t.table = make([]bls12381.G1, tableWindows*tableRow)
+	b := t.base.inner
+	for i := 0; i < tableWindows; i++ {
+		row := t.table[i*tableRow : (i+1)*tableRow]
+		row[0] = b
+		for d := 1; d < tableRow; d++ {
+			row[d].Add(&row[d-1], &b)
+		}
+		b.Add(&row[tableRow-1], &b)
+	}
+}
+
+// Point returns a copy of the precomputed point.
+func (t *g1Table) Point() kyber.Point {
+	return t.base.Clone()
+}
+
+// Mul returns a new point set to s times the precomputed point.
+func (t *g1Table) Mul(s kyber.Scalar) kyber.Point {
+	if t.table == nil {
+		return new(G1Elt).Mul(s, &t.base)
+	}
+	digits := msm.Digits([]kyber.Scalar{s}, precomp.Window)[0]
+	if len(digits) > tableWindows {
+		return new(G1Elt).Mul(s, &t.base)
+	}
+
+	acc := new(G1Elt)
+	acc.inner.SetIdentity()
+	for i, d := range digits {
+		if d != 0 {
+			acc.inner.Add(&acc.inner, &t.table[i*tableRow+int(d)-1])
+		}
+	}
+	return acc
+}
+
+// g2Table is a kyber.PrecomputedPoint for G2, holding the points
+// d * 2^(precomp.Window*i) * base at index i*tableRow + d-1. The lookups
+// depend on the scalar, so the table is only used once variable time has
+// been allowed; until then Mul falls back to the constant time ScalarMult.
+type g2Table struct {
+	base  G2Elt
+	table []bls12381.G2
+}
+
+// Precompute returns a table of multiples of p that speeds up its
+// multiplication by public scalars.
+func (p *G2Elt) Precompute() kyber.PrecomputedPoint {
+	return &g2Table{base: *p}
+}
+
+// AllowVarTime builds the table when varTime is true, and drops it
+// otherwise. It must be called before the table is shared between
+// goroutines.
+func (t *g2Table) AllowVarTime(varTime bool) {
+	if !varTime {
+		t.table = nil
+		return
+	}
+	if t.table != nil {
+		return
+	}
+
+	t.table = make([]bls12381.G2, tableWindows*tableRow)
+	b := t.base.inner
+	for i := 0; i < tableWindows; i++ {
+		row := t.table[i*tableRow : (i+1)*tableRow]
+		row[0] = b
+		for d := 1; d < tableRow; d++ {
+			row[d].Add(&row[d-1], &b)
+		}
+		b.Add(&row[tableRow-1], &b)
+	}
+}
+
+// Point returns a copy of the precomputed point.
+func (t *g2Table) Point() kyber.Point {
+	return t.base.Clone()
+}
+
+// Mul returns a new point set to s times the precomputed point.
+func (t *g2Table) Mul(s kyber.Scalar) kyber.Point {
+	if t.table == nil {
+		return new(G2Elt).Mul(s, &t.base)
+	}
+	digits := msm.Digits([]kyber.Scalar{s}, precomp.Window)[0]
+	if len(digits) > tableWindows {
+		return new(G2Elt).Mul(s, &t.base)
+	}
+
+	acc := new(G2Elt)
+	acc.inner.SetIdentity()
+	for i, d := range digits {
+		if d != 0 {
+			acc.inner.Add(&acc.inner, &t.table[i*tableRow+int(d)-1])
+		}
+	}
+	return acc
+}
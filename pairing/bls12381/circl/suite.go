@@ -25,20 +25,46 @@ func (s Suite) G2() kyber.Group { return G2 }
 func (s Suite) GT() kyber.Group { return GT }
 
 func (s Suite) Pair(p1, p2 kyber.Point) kyber.Point {
-	aa, bb := p1.(*G1Elt), p2.(*G2Elt)
-	return &GTElt{*bls12381.Pair(&aa.inner, &bb.inner)}
+	// Pair converts the point of G1 to affine coordinates in place
+	a, b := p1.(*G1Elt).inner, p2.(*G2Elt).inner
+	return &GTElt{*bls12381.Pair(&a, &b)}
 }
 func (s Suite) ValidatePairing(p1, p2, p3, p4 kyber.Point) bool {
-	a, b := p1.(*G1Elt), p2.(*G2Elt)
-	c, d := p3.(*G1Elt), p4.(*G2Elt)
+	// ProdPairFrac converts the points of G1 to affine coordinates in place
+	a, b := p1.(*G1Elt).inner, p2.(*G2Elt)
+	c, d := p3.(*G1Elt).inner, p4.(*G2Elt)
 	out := bls12381.ProdPairFrac(
-		[]*bls12381.G1{&a.inner, &c.inner},
+		[]*bls12381.G1{&a, &c},
 		[]*bls12381.G2{&b.inner, &d.inner},
 		[]int{1, -1},
 	)
 	return out.IsIdentity()
 }
 
+func (s Suite) MultiPair(g1s, g2s []kyber.Point) kyber.Point {
+	if len(g1s) != len(g2s) {
+		panic("bls12381: mismatched number of points in MultiPair")
+	}
+	// ProdPairFrac converts the points of G1 to affine coordinates in place
+	var P []*bls12381.G1
+	var Q []*bls12381.G2
+	var signs []int
+	for i := range g1s {
+		a, b := g1s[i].(*G1Elt).inner, &g2s[i].(*G2Elt).inner
+		// the pairing of the point at infinity is one
+		if a.IsIdentity() || b.IsIdentity() {
+			continue
+		}
+		P = append(P, &a)
+		Q = append(Q, b)
+		signs = append(signs, 1)
+	}
+	return &GTElt{*bls12381.ProdPairFrac(P, Q, signs)}
+}
+func (s Suite) PairingCheck(g1s, g2s []kyber.Point) bool {
+	return s.MultiPair(g1s, g2s).(*GTElt).inner.IsIdentity()
+}
+
 func (s Suite) Read(_ io.Reader, _ ...interface{}) error {
 	panic("Suite.Read(): deprecated in drand")
 }
@@ -0,0 +1,255 @@
+package bls12381
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	circl "go.dedis.ch/kyber/v4/pairing/bls12381/circl"
+	kilic "go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
+	"go.dedis.ch/kyber/v4/sign/bls"
+	"gopkg.in/yaml.v3"
+)
+
+// The conformance tests are the BLS test vectors of the Ethereum consensus
+// specifications, released in the archive of
+// github.com/ethereum/bls12-381-tests: each directory of the archive is a
+// handler holding one YAML file per case, and is extracted unchanged into
+// conformance_tests. The sign and hash_to_G2 directories hold cases in the same
+// format, with the outputs of the upstream sign cases and the vectors of
+// RFC 9380, appendix J.10.1. The cases of every handler are run against every
+// backend, and the handlers whose directory is missing are skipped.
+var conformanceTests = filepath.Join(basepath, "conformance_tests")
+
+// hashToG2DST is the domain separation tag of the hash_to_G2 vectors, which
+// are the ones of RFC 9380, appendix J.10.1.
+const hashToG2DST = "QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_"
+
+var conformanceSuites = map[string]pairing.Suite{
+	"kilic": kilic.NewBLS12381Suite(),
+	"circl": circl.NewSuiteBLS12381(),
+}
+
+// runConformance decodes each case of the handler into a new value returned
+// by newCase and checks it against every backend.
+func runConformance(t *testing.T, handler string, newCase func() interface{},
+	check func(t *testing.T, scheme *bls.IETFScheme, suite pairing.Suite, test interface{})) {
+	paths, err := filepath.Glob(filepath.Join(conformanceTests, handler, "*.yaml"))
+	require.NoError(t, err)
+	if len(paths) == 0 {
+		t.Skipf("no %s vectors in %s", handler, conformanceTests)
+	}
+
+	for name, suite := range conformanceSuites {
+		scheme := bls.NewIETFSchemeOnG2(suite, bls.ProofOfPossession)
+		for _, path := range paths {
+			t.Run(name+"/"+strings.TrimSuffix(filepath.Base(path), ".yaml"), func(t *testing.T) {
+				buf, err := os.ReadFile(path)
+				require.NoError(t, err)
+				test := newCase()
+				require.NoError(t, yaml.Unmarshal(buf, test))
+				check(t, scheme, suite, test)
+			})
+		}
+	}
+}
+
+func hexBytes(t *testing.T, s string) []byte {
+	buf, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	require.NoError(t, err)
+	return buf
+}
+
+// publicKeys decodes the public keys, returning false if any is invalid.
+func publicKeys(t *testing.T, suite pairing.Suite, encoded []string) ([]kyber.Point, bool) {
+	publics := make([]kyber.Point, len(encoded))
+	for i, s := range encoded {
+		publics[i] = suite.G1().Point()
+		if err := publics[i].UnmarshalBinary(hexBytes(t, s)); err != nil {
+			return nil, false
+		}
+	}
+	return publics, true
+}
+
+func TestConformanceSign(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Privkey string `yaml:"privkey"`
+			Message string `yaml:"message"`
+		}
+		Output *string `yaml:"output"`
+	}
+	runConformance(t, "sign", func() interface{} { return &Test{} },
+		func(t *testing.T, scheme *bls.IETFScheme, suite pairing.Suite, v interface{}) {
+			test := v.(*Test)
+			private := suite.G1().Scalar().SetBytes(hexBytes(t, test.Input.Privkey))
+			sig, err := scheme.Sign(private, hexBytes(t, test.Input.Message))
+			if test.Output == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, hexBytes(t, *test.Output), sig)
+		})
+}
+
+func TestConformanceVerify(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Pubkey    string `yaml:"pubkey"`
+			Message   string `yaml:"message"`
+			Signature string `yaml:"signature"`
+		}
+		Output bool `yaml:"output"`
+	}
+	runConformance(t, "verify", func() interface{} { return &Test{} },
+		func(t *testing.T, scheme *bls.IETFScheme, suite pairing.Suite, v interface{}) {
+			test := v.(*Test)
+			publics, ok := publicKeys(t, suite, []string{test.Input.Pubkey})
+			if ok {
+				err := scheme.Verify(publics[0], hexBytes(t, test.Input.Message), hexBytes(t, test.Input.Signature))
+				ok = err == nil
+			}
+			require.Equal(t, test.Output, ok)
+		})
+}
+
+func TestConformanceAggregate(t *testing.T) {
+	type Test struct {
+		Input  []string `yaml:"input"`
+		Output *string  `yaml:"output"`
+	}
+	runConformance(t, "aggregate", func() interface{} { return &Test{} },
+		func(t *testing.T, scheme *bls.IETFScheme, _ pairing.Suite, v interface{}) {
+			test := v.(*Test)
+			sigs := make([][]byte, len(test.Input))
+			for i, s := range test.Input {
+				sigs[i] = hexBytes(t, s)
+			}
+			agg, err := scheme.AggregateSignatures(sigs...)
+			if test.Output == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, hexBytes(t, *test.Output), agg)
+		})
+}
+
+func TestConformanceFastAggregateVerify(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Pubkeys   []string `yaml:"pubkeys"`
+			Message   string   `yaml:"message"`
+			Signature string   `yaml:"signature"`
+		}
+		Output bool `yaml:"output"`
+	}
+	runConformance(t, "fast_aggregate_verify", func() interface{} { return &Test{} },
+		func(t *testing.T, scheme *bls.IETFScheme, suite pairing.Suite, v interface{}) {
+			test := v.(*Test)
+			publics, ok := publicKeys(t, suite, test.Input.Pubkeys)
+			if ok {
+				err := scheme.FastAggregateVerify(publics, hexBytes(t, test.Input.Message),
+					hexBytes(t, test.Input.Signature))
+				ok = err == nil
+			}
+			require.Equal(t, test.Output, ok)
+		})
+}
+
+func TestConformanceAggregateVerify(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Pubkeys   []string `yaml:"pubkeys"`
+			Messages  []string `yaml:"messages"`
+			Signature string   `yaml:"signature"`
+		}
+		Output bool `yaml:"output"`
+	}
+	runConformance(t, "aggregate_verify", func() interface{} { return &Test{} },
+		func(t *testing.T, scheme *bls.IETFScheme, suite pairing.Suite, v interface{}) {
+			test := v.(*Test)
+			publics, ok := publicKeys(t, suite, test.Input.Pubkeys)
+			if ok {
+				msgs := make([][]byte, len(test.Input.Messages))
+				for i, m := range test.Input.Messages {
+					msgs[i] = hexBytes(t, m)
+				}
+				err := scheme.AggregateVerify(publics, msgs, hexBytes(t, test.Input.Signature))
+				ok = err == nil
+			}
+			require.Equal(t, test.Output, ok)
+		})
+}
+
+func TestConformanceBatchVerify(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Pubkeys    []string `yaml:"pubkeys"`
+			Messages   []string `yaml:"messages"`
+			Signatures []string `yaml:"signatures"`
+		}
+		Output bool `yaml:"output"`
+	}
+	runConformance(t, "batch_verify", func() interface{} { return &Test{} },
+		func(t *testing.T, scheme *bls.IETFScheme, suite pairing.Suite, v interface{}) {
+			test := v.(*Test)
+			publics, ok := publicKeys(t, suite, test.Input.Pubkeys)
+			if ok {
+				entries := make([]bls.BatchEntry, len(publics))
+				for i := range entries {
+					entries[i] = bls.BatchEntry{
+						Public: publics[i],
+						Msg:    hexBytes(t, test.Input.Messages[i]),
+						Sig:    hexBytes(t, test.Input.Signatures[i]),
+					}
+				}
+				invalid, err := scheme.NewBatchVerifier().Verify(entries)
+				require.NoError(t, err)
+				ok = invalid == nil
+			}
+			require.Equal(t, test.Output, ok)
+		})
+}
+
+func TestConformanceHashToG2(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Msg string `yaml:"msg"`
+		}
+		Output struct {
+			X string `yaml:"x"`
+			Y string `yaml:"y"`
+		}
+	}
+	runConformance(t, "hash_to_G2", func() interface{} { return &Test{} },
+		func(t *testing.T, _ *bls.IETFScheme, suite pairing.Suite, v interface{}) {
+			test := v.(*Test)
+
+			// the uncompressed encoding of a point of G2 is x.c1 || x.c0 || y.c1 || y.c0
+			var uncompressed []byte
+			for _, coord := range []string{test.Output.X, test.Output.Y} {
+				c := strings.Split(coord, ",")
+				require.Len(t, c, 2)
+				uncompressed = append(uncompressed, hexBytes(t, c[1])...)
+				uncompressed = append(uncompressed, hexBytes(t, c[0])...)
+			}
+			// only circl decodes uncompressed points, compare the compressed encodings
+			expected := circl.G2Elt{}
+			require.NoError(t, expected.UnmarshalBinary(uncompressed))
+			expectedBuf, err := expected.MarshalBinary()
+			require.NoError(t, err)
+
+			h := suite.G2().Point().(kyber.HashablePointWithDST).Hash2([]byte(test.Input.Msg), []byte(hashToG2DST))
+			buf, err := h.MarshalBinary()
+			require.NoError(t, err)
+			require.Equal(t, expectedBuf, buf)
+		})
+}
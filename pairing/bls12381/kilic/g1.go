@@ -5,10 +5,12 @@ import (
 	"crypto/cipher"
 	"encoding/hex"
 	"io"
+	"math/big"
 
 	bls12381 "github.com/kilic/bls12-381"
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/util/msm"
 )
 
 // domainG1 is the DST used for hash to curve on G1, this is the default from the RFC.
@@ -113,6 +115,22 @@ func (k *G1Elt) Mul(s kyber.Scalar, q kyber.Point) kyber.Point {
 	return k
 }
 
+// MultiScalarMul sets k to the sum of scalars[i] * points[i], computed with
+// the bucket method of the underlying library. It runs in variable time.
+func (k *G1Elt) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	msm.CheckLengths(scalars, points)
+	ps := make([]*bls12381.PointG1, len(points))
+	ss := make([]*big.Int, len(scalars))
+	for i := range points {
+		ps[i] = points[i].(*G1Elt).p
+		ss[i] = &scalars[i].(*mod.Int).V
+	}
+	if _, err := bls12381.NewG1().MultiExpBig(k.p, ps, ss); err != nil {
+		panic(err)
+	}
+	return k
+}
+
 // MarshalBinary returns a compressed point, without any domain separation tag information
 func (k *G1Elt) MarshalBinary() ([]byte, error) {
 	// we need to clone the point because of https://github.com/kilic/bls12-381/issues/37
@@ -167,6 +185,14 @@ func (k *G1Elt) Hash(m []byte) kyber.Point {
 	return k
 }
 
+// Hash2 hashes m to a point of G1 using the domain separation tag dst
+// instead of the one of k.
+func (k *G1Elt) Hash2(m, dst []byte) kyber.Point {
+	p, _ := bls12381.NewG1().HashToCurve(m, dst)
+	k.p = p
+	return k
+}
+
 func (k *G1Elt) IsInCorrectGroup() bool {
 	return bls12381.NewG1().InCorrectSubgroup(k.p)
 }
@@ -0,0 +1,137 @@
+//nolint:dupl // unavoidable duplication between g1 and g2
+package kilic
+
+import (
+	bls12381 "github.com/kilic/bls12-381"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/msm"
+	"go.dedis.ch/kyber/v4/util/precomp"
+)
+
+// tableWindows is the number of digits of a scalar in base 2^precomp.Window.
+const tableWindows = (8*32 + precomp.Window - 1) / precomp.Window
+
+// tableRow is the number of non-zero digits in base 2^precomp.Window.
+const tableRow = 1<<precomp.Window - 1
+
+// g1Table is a kyber.PrecomputedPoint for G1, holding the affine points
+// d * 2^(precomp.Window*i) * base at index i*tableRow + d-1, so that they can
+// be used in mixed additions.
+type g1Table struct {
+	base  *G1Elt
+	table []bls12381.PointG1
+}
+
+// Precompute returns a table of multiples of k that speeds up its
+// multiplication by any scalar. Like Mul, it runs in variable time.
+func (k *G1Elt) Precompute() kyber.PrecomputedPoint {
+	g := bls12381.NewG1()
+	t := &g1Table{
+		base:  k.Clone().(*G1Elt),
+		table: make([]bls12381.PointG1, tableWindows*tableRow),
+	}
+
+	b, next := new(bls12381.PointG1).Set(k.p), new(bls12381.PointG1)
+	affine := make([]*bls12381.PointG1, 0, len(t.table))
+	for i := 0; i < tableWindows; i++ {
+		row := t.table[i*tableRow : (i+1)*tableRow]
+		row[0].Set(b)
+		for d := 1; d < tableRow; d++ {
+			g.Add(&row[d], &row[d-1], b)
+		}
+		// avoid aliasing the output with b, which may be affine
+		b.Set(g.Add(next, &row[tableRow-1], b))
+
+		for d := range row {
+			// the batch inversion would fail on the point at infinity
+			if !g.IsZero(&row[d]) {
+				affine = append(affine, &row[d])
+			}
+		}
+	}
+	g.AffineBatch(affine)
+	return t
+}
+
+// Point returns a copy of the precomputed point.
+func (t *g1Table) Point() kyber.Point {
+	return t.base.Clone()
+}
+
+// Mul returns a new point set to s times the precomputed point.
+func (t *g1Table) Mul(s kyber.Scalar) kyber.Point {
+	digits := msm.Digits([]kyber.Scalar{s}, precomp.Window)[0]
+	if len(digits) > tableWindows {
+		return NullG1(t.base.dst...).Mul(s, t.base)
+	}
+
+	g := bls12381.NewG1()
+	acc := g.Zero()
+	for i, d := range digits {
+		if d != 0 {
+			g.Add(acc, acc, &t.table[i*tableRow+int(d)-1])
+		}
+	}
+	return newG1(acc, t.base.dst)
+}
+
+// g2Table is a kyber.PrecomputedPoint for G2, holding the affine points
+// d * 2^(precomp.Window*i) * base at index i*tableRow + d-1, so that they can
+// be used in mixed additions.
+type g2Table struct {
+	base  *G2Elt
+	table []bls12381.PointG2
+}
+
+// Precompute returns a table of multiples of k that speeds up its
+// multiplication by any scalar. Like Mul, it runs in variable time.
+func (k *G2Elt) Precompute() kyber.PrecomputedPoint {
+	g := bls12381.NewG2()
+	t := &g2Table{
+		base:  k.Clone().(*G2Elt),
+		table: make([]bls12381.PointG2, tableWindows*tableRow),
+	}
+
+	b, next := new(bls12381.PointG2).Set(k.p), new(bls12381.PointG2)
+	affine := make([]*bls12381.PointG2, 0, len(t.table))
+	for i := 0; i < tableWindows; i++ {
+		row := t.table[i*tableRow : (i+1)*tableRow]
+		row[0].Set(b)
+		for d := 1; d < tableRow; d++ {
+			g.Add(&row[d], &row[d-1], b)
+		}
+		// avoid aliasing the output with b, which may be affine
+		b.Set(g.Add(next, &row[tableRow-1], b))
+
+		for d := range row {
+			// the batch inversion would fail on the point at infinity
+			if !g.IsZero(&row[d]) {
+				affine = append(affine, &row[d])
+			}
+		}
+	}
+	g.AffineBatch(affine)
+	return t
+}
+
+// Point returns a copy of the precomputed point.
+func (t *g2Table) Point() kyber.Point {
+	return t.base.Clone()
+}
+
+// Mul returns a new point set to s times the precomputed point.
+func (t *g2Table) Mul(s kyber.Scalar) kyber.Point {
+	digits := msm.Digits([]kyber.Scalar{s}, precomp.Window)[0]
+	if len(digits) > tableWindows {
+		return NullG2(t.base.dst...).Mul(s, t.base)
+	}
+
+	g := bls12381.NewG2()
+	acc := g.Zero()
+	for i, d := range digits {
+		if d != 0 {
+			g.Add(acc, acc, &t.table[i*tableRow+int(d)-1])
+		}
+	}
+	return newG2(acc, t.base.dst)
+}
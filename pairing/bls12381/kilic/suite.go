@@ -65,13 +65,39 @@ func (s *Suite) ValidatePairing(p1, p2, p3, p4 kyber.Point) bool {
 	return e.Check()
 }
 
+// Pair implements the `pairing.Suite` interface
 func (s *Suite) Pair(p1, p2 kyber.Point) kyber.Point {
 	e := bls12381.NewEngine()
-	g1point := p1.(*G1Elt).p
-	g2point := p2.(*G2Elt).p
+	// the engine converts the points to affine coordinates in place
+	g1point := new(bls12381.PointG1).Set(p1.(*G1Elt).p)
+	g2point := new(bls12381.PointG2).Set(p2.(*G2Elt).p)
 	return newGT(e.AddPair(g1point, g2point).Result())
 }
 
+// MultiPair implements the `pairing.Suite` interface
+func (s *Suite) MultiPair(g1s, g2s []kyber.Point) kyber.Point {
+	return newGT(s.multiPairEngine(g1s, g2s).Result())
+}
+
+// PairingCheck implements the `pairing.Suite` interface
+func (s *Suite) PairingCheck(g1s, g2s []kyber.Point) bool {
+	return s.multiPairEngine(g1s, g2s).Check()
+}
+
+func (s *Suite) multiPairEngine(g1s, g2s []kyber.Point) *bls12381.Engine {
+	if len(g1s) != len(g2s) {
+		panic("bls12381: mismatched number of points in MultiPair")
+	}
+	e := bls12381.NewEngine()
+	for i := range g1s {
+		// the engine converts the points to affine coordinates in place
+		g1point := new(bls12381.PointG1).Set(g1s[i].(*G1Elt).p)
+		g2point := new(bls12381.PointG2).Set(g2s[i].(*G2Elt).p)
+		e.AddPair(g1point, g2point)
+	}
+	return e
+}
+
 // New implements the kyber.Encoding interface.
 func (s *Suite) New(_ reflect.Type) interface{} {
 	panic("Suite.Encoding: deprecated in kyber")
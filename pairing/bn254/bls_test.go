@@ -5,6 +5,7 @@ import (
 
 	"go.dedis.ch/kyber/v4/internal/test"
 	"go.dedis.ch/kyber/v4/sign/bls"
+	"go.dedis.ch/kyber/v4/sign/tbls"
 )
 
 func TestBLSSchemeBN254G1(t *testing.T) {
@@ -12,3 +13,15 @@ func TestBLSSchemeBN254G1(t *testing.T) {
 	s := bls.NewSchemeOnG1(suite)
 	test.SchemeTesting(t, s)
 }
+
+func TestBLSSchemeBN254G2(t *testing.T) {
+	suite := NewSuite()
+	s := bls.NewSchemeOnG2(suite)
+	test.SchemeTesting(t, s)
+}
+
+func TestTBLSSchemeBN254G2(t *testing.T) {
+	suite := NewSuite()
+	s := tbls.NewThresholdSchemeOnG2(suite)
+	test.ThresholdTest(t, suite.G1(), s)
+}
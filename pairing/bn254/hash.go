@@ -0,0 +1,78 @@
+package bn254
+
+import (
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/xmd"
+	"go.dedis.ch/kyber/v4/pairing/internal/svdw"
+	"golang.org/x/crypto/sha3"
+)
+
+// g2Map is the Shallue-van de Woestijne map to the twist y² = x³ + 3/ξ.
+var g2Map = svdw.New(p, gfP2ToElement(twistB))
+
+// g2Cofactor is the cofactor 2p - n of G₂ in the group of the points of the
+// twist over GF(p²).
+var g2Cofactor = new(big.Int).Sub(new(big.Int).Lsh(p, 1), Order)
+
+// Hash hashes m to G₂ with the domain separation tag of the group, see
+// hashToG2.
+func (p *pointG2) Hash(m []byte) kyber.Point {
+	return hashToG2(p.dst, m)
+}
+
+// hashToG2 implements the hash_to_curve of RFC 9380, section 3, with
+// expand_message_xmd and Keccak-256, the Shallue-van de Woestijne map to the
+// twist and the clearing of the cofactor by scalar multiplication.
+func hashToG2(domain, m []byte) kyber.Point {
+	if len(domain) == 0 {
+		domain = newDefaultDomainG2()
+	}
+	u, err := xmd.HashToField(sha3.NewLegacyKeccak256, m, domain, p, 128, 4)
+	if err != nil {
+		panic(err)
+	}
+	q0 := mapToTwist(svdw.Element{A: u[0], B: u[1]})
+	q1 := mapToTwist(svdw.Element{A: u[2], B: u[3]})
+	q0.Add(q0, q1)
+
+	r := newPointG2(domain)
+	r.g.Mul(q0, g2Cofactor)
+	return r
+}
+
+// mapToTwist returns the image of u on the twist, not necessarily in G₂.
+func mapToTwist(u svdw.Element) *twistPoint {
+	x, y := g2Map.MapToCurve(u)
+	t := &twistPoint{}
+	t.x = elementToGFp2(x)
+	t.y = elementToGFp2(y)
+	t.z.SetOne()
+	t.t.SetOne()
+	return t
+}
+
+// gfP2ToElement converts xi + y to the element y + xi of the map.
+func gfP2ToElement(e *gfP2) svdw.Element {
+	return svdw.Element{A: gfPToBig(&e.y), B: gfPToBig(&e.x)}
+}
+
+func elementToGFp2(e svdw.Element) gfP2 {
+	return gfP2{x: gfPFromBig(e.B), y: gfPFromBig(e.A)}
+}
+
+func gfPToBig(e *gfP) *big.Int {
+	d := &gfP{}
+	montDecode(d, e)
+	buf := make([]byte, 32)
+	d.Marshal(buf)
+	return new(big.Int).SetBytes(buf)
+}
+
+func gfPFromBig(x *big.Int) gfP {
+	e := gfP{}
+	_ = e.Unmarshal(zeroPadBytes(x.Bytes(), 32))
+	montEncode(&e, &e)
+	return e
+}
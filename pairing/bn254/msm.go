@@ -0,0 +1,106 @@
+package bn254
+
+import (
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/msm"
+)
+
+// MultiScalarMul sets p to the sum of scalars[i] * points[i], computed with
+// Pippenger's bucket method. It runs in variable time.
+func (p *pointG1) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	msm.CheckLengths(scalars, points)
+	if len(points) == 0 {
+		p.g.SetInfinity()
+		return p
+	}
+
+	c := msm.WindowSize(len(points), Order.BitLen())
+	digits := msm.Digits(scalars, c)
+	buckets := make([]curvePoint, 1<<c-1)
+	sum, window, t := &curvePoint{}, &curvePoint{}, &curvePoint{}
+	// accumulate in acc, as p may be one of the points
+	acc := &curvePoint{}
+	acc.SetInfinity()
+
+	for w := len(digits[0]) - 1; w >= 0; w-- {
+		for i := 0; i < c; i++ {
+			t.Double(acc)
+			acc.Set(t)
+		}
+
+		for k := range buckets {
+			buckets[k].SetInfinity()
+		}
+		for i, d := range digits {
+			if k := d[w]; k != 0 {
+				t.Add(&buckets[k-1], points[i].(*pointG1).g)
+				buckets[k-1].Set(t)
+			}
+		}
+
+		// window = sum_k k * buckets[k-1], computed with running sums
+		sum.SetInfinity()
+		window.SetInfinity()
+		for k := len(buckets) - 1; k >= 0; k-- {
+			t.Add(sum, &buckets[k])
+			sum.Set(t)
+			t.Add(window, sum)
+			window.Set(t)
+		}
+
+		t.Add(acc, window)
+		acc.Set(t)
+	}
+	p.g.Set(acc)
+	return p
+}
+
+// MultiScalarMul sets p to the sum of scalars[i] * points[i], computed with
+// Pippenger's bucket method. It runs in variable time.
+func (p *pointG2) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	msm.CheckLengths(scalars, points)
+	if len(points) == 0 {
+		p.g.SetInfinity()
+		return p
+	}
+
+	c := msm.WindowSize(len(points), Order.BitLen())
+	digits := msm.Digits(scalars, c)
+	buckets := make([]twistPoint, 1<<c-1)
+	sum, window, t := &twistPoint{}, &twistPoint{}, &twistPoint{}
+	// accumulate in acc, as p may be one of the points
+	acc := &twistPoint{}
+	acc.SetInfinity()
+
+	for w := len(digits[0]) - 1; w >= 0; w-- {
+		for i := 0; i < c; i++ {
+			t.Double(acc)
+			acc.Set(t)
+		}
+
+		for k := range buckets {
+			buckets[k].SetInfinity()
+		}
+		for i, d := range digits {
+			if k := d[w]; k != 0 {
+				t.Add(&buckets[k-1], points[i].(*pointG2).g)
+				buckets[k-1].Set(t)
+			}
+		}
+
+		// window = sum_k k * buckets[k-1], computed with running sums
+		sum.SetInfinity()
+		window.SetInfinity()
+		for k := len(buckets) - 1; k >= 0; k-- {
+			t.Add(sum, &buckets[k])
+			sum.Set(t)
+			t.Add(window, sum)
+			window.Set(t)
+		}
+
+		t.Add(acc, window)
+		acc.Set(t)
+	}
+	p.g.Set(acc)
+	return p
+}
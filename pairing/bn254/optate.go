@@ -120,86 +120,104 @@ var sixuPlus2NAF = []int8{0, 0, 0, 1, 0, 1, 0, -1, 0, 0, 1, -1, 0, 0, 1, 0,
 // miller implements the Miller loop for calculating the Optimal Ate pairing.
 // See algorithm 1 from http://cryptojedi.org/papers/dclxvi-20100714.pdf
 func miller(q *twistPoint, p *curvePoint) *gfP12 {
+	return multiMiller([]*twistPoint{q}, []*curvePoint{p})
+}
+
+// multiMiller computes the product of the Miller loops of the pairs (qs[i],
+// ps[i]) in a single loop, whose squarings are shared by all the pairs.
+func multiMiller(qs []*twistPoint, ps []*curvePoint) *gfP12 {
 	ret := (&gfP12{}).SetOne()
 
-	aAffine := &twistPoint{}
-	aAffine.Set(q)
-	aAffine.MakeAffine()
+	aAffine := make([]*twistPoint, len(qs))
+	minusA := make([]*twistPoint, len(qs))
+	bAffine := make([]*curvePoint, len(qs))
+	r := make([]*twistPoint, len(qs))
+	r2 := make([]*gfP2, len(qs))
+	for j := range qs {
+		aAffine[j] = &twistPoint{}
+		aAffine[j].Set(qs[j])
+		aAffine[j].MakeAffine()
 
-	bAffine := &curvePoint{}
-	bAffine.Set(p)
-	bAffine.MakeAffine()
+		minusA[j] = &twistPoint{}
+		minusA[j].Neg(aAffine[j])
 
-	r := &twistPoint{}
-	r.Set(aAffine)
+		bAffine[j] = &curvePoint{}
+		bAffine[j].Set(ps[j])
+		bAffine[j].MakeAffine()
 
-	r2 := (&gfP2{}).Square(&aAffine.y)
+		r[j] = &twistPoint{}
+		r[j].Set(aAffine[j])
+
+		r2[j] = (&gfP2{}).Square(&aAffine[j].y)
+	}
 
 	for i := len(sixuPlus2NAF) - 1; i > 0; i-- {
-		a, b, c, newR := lineFunctionDouble(r, bAffine)
 		if i != len(sixuPlus2NAF)-1 {
 			ret.Square(ret)
 		}
 
-		mulLine(ret, a, b, c)
-		r = newR
-
-		switch sixuPlus2NAF[i-1] {
-		case 1:
-			a, b, c, newR = lineFunctionAdd(r, aAffine, bAffine, r2)
-		case -1:
-			minusA := &twistPoint{}
-			minusA.Neg(aAffine)
-			a, b, c, newR = lineFunctionAdd(r, minusA, bAffine, r2)
-		default:
-			continue
+		for j := range r {
+			a, b, c, newR := lineFunctionDouble(r[j], bAffine[j])
+			mulLine(ret, a, b, c)
+			r[j] = newR
+
+			switch sixuPlus2NAF[i-1] {
+			case 1:
+				a, b, c, newR = lineFunctionAdd(r[j], aAffine[j], bAffine[j], r2[j])
+			case -1:
+				a, b, c, newR = lineFunctionAdd(r[j], minusA[j], bAffine[j], r2[j])
+			default:
+				continue
+			}
+
+			mulLine(ret, a, b, c)
+			r[j] = newR
 		}
+	}
 
+	for j := range r {
+		// In order to calculate Q1 we have to convert q from the sextic twist
+		// to the full GF(p^12) group, apply the Frobenius there, and convert
+		// back.
+		//
+		// The twist isomorphism is (x', y') -> (xω², yω³). If we consider just
+		// x for a moment, then after applying the Frobenius, we have x̄ω^(2p)
+		// where x̄ is the conjugate of x. If we are going to apply the inverse
+		// isomorphism we need a value with a single coefficient of ω² so we
+		// rewrite this as x̄ω^(2p-2)ω². ξ⁶ = ω and, due to the construction of
+		// p, 2p-2 is a multiple of six. Therefore we can rewrite as
+		// x̄ξ^((p-1)/3)ω² and applying the inverse isomorphism eliminates the
+		// ω².
+		//
+		// A similar argument can be made for the y value.
+
+		q1 := &twistPoint{}
+		q1.x.Conjugate(&aAffine[j].x).Mul(&q1.x, xiToPMinus1Over3)
+		q1.y.Conjugate(&aAffine[j].y).Mul(&q1.y, xiToPMinus1Over2)
+		q1.z.SetOne()
+		q1.t.SetOne()
+
+		// For Q2 we are applying the p² Frobenius. The two conjugations cancel
+		// out and we are left only with the factors from the isomorphism. In
+		// the case of x, we end up with a pure number which is why
+		// xiToPSquaredMinus1Over3 is ∈ GF(p). With y we get a factor of -1. We
+		// ignore this to end up with -Q2.
+
+		minusQ2 := &twistPoint{}
+		minusQ2.x.MulScalar(&aAffine[j].x, xiToPSquaredMinus1Over3)
+		minusQ2.y.Set(&aAffine[j].y)
+		minusQ2.z.SetOne()
+		minusQ2.t.SetOne()
+
+		r2[j].Square(&q1.y)
+		a, b, c, newR := lineFunctionAdd(r[j], q1, bAffine[j], r2[j])
 		mulLine(ret, a, b, c)
-		r = newR
-	}
+		r[j] = newR
 
-	// In order to calculate Q1 we have to convert q from the sextic twist
-	// to the full GF(p^12) group, apply the Frobenius there, and convert
-	// back.
-	//
-	// The twist isomorphism is (x', y') -> (xω², yω³). If we consider just
-	// x for a moment, then after applying the Frobenius, we have x̄ω^(2p)
-	// where x̄ is the conjugate of x. If we are going to apply the inverse
-	// isomorphism we need a value with a single coefficient of ω² so we
-	// rewrite this as x̄ω^(2p-2)ω². ξ⁶ = ω and, due to the construction of
-	// p, 2p-2 is a multiple of six. Therefore we can rewrite as
-	// x̄ξ^((p-1)/3)ω² and applying the inverse isomorphism eliminates the
-	// ω².
-	//
-	// A similar argument can be made for the y value.
-
-	q1 := &twistPoint{}
-	q1.x.Conjugate(&aAffine.x).Mul(&q1.x, xiToPMinus1Over3)
-	q1.y.Conjugate(&aAffine.y).Mul(&q1.y, xiToPMinus1Over2)
-	q1.z.SetOne()
-	q1.t.SetOne()
-
-	// For Q2 we are applying the p² Frobenius. The two conjugations cancel
-	// out and we are left only with the factors from the isomorphism. In
-	// the case of x, we end up with a pure number which is why
-	// xiToPSquaredMinus1Over3 is ∈ GF(p). With y we get a factor of -1. We
-	// ignore this to end up with -Q2.
-
-	minusQ2 := &twistPoint{}
-	minusQ2.x.MulScalar(&aAffine.x, xiToPSquaredMinus1Over3)
-	minusQ2.y.Set(&aAffine.y)
-	minusQ2.z.SetOne()
-	minusQ2.t.SetOne()
-
-	r2.Square(&q1.y)
-	a, b, c, newR := lineFunctionAdd(r, q1, bAffine, r2)
-	mulLine(ret, a, b, c)
-	r = newR
-
-	r2.Square(&minusQ2.y)
-	a, b, c, _ = lineFunctionAdd(r, minusQ2, bAffine, r2)
-	mulLine(ret, a, b, c)
+		r2[j].Square(&minusQ2.y)
+		a, b, c, _ = lineFunctionAdd(r[j], minusQ2, bAffine[j], r2[j])
+		mulLine(ret, a, b, c)
+	}
 
 	return ret
 }
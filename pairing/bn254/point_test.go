@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/hex"
 	"errors"
+	"strings"
 	"testing"
 
+	"go.dedis.ch/kyber/v4"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -199,3 +201,76 @@ func gnarkExpandMsgXmd(msg, dst []byte, lenInBytes int) ([]byte, error) {
 	}
 	return res, nil
 }
+
+func TestPointG2_HashToPoint(t *testing.T) {
+	suite := NewSuite()
+	msg := []byte("The Times 03/Jan/2009 Chancellor on brink of second bailout for banks")
+
+	h := suite.G2().Point().(kyber.HashablePoint).Hash(msg).(*pointG2)
+	if !h.g.IsOnCurve() || h.g.IsInfinity() {
+		t.Fatal("hash is not a point of the twist")
+	}
+	q := &twistPoint{}
+	q.Mul(h.g, Order)
+	if !q.IsInfinity() {
+		t.Fatal("hash is not of order n")
+	}
+	if !h.Equal(suite.G2().Point().(kyber.HashablePoint).Hash(msg)) {
+		t.Fatal("hash is not deterministic")
+	}
+
+	suite.SetDomainG2([]byte("domain_separation_tag_test_12345"))
+	h2 := suite.G2().Point().(kyber.HashablePoint).Hash(msg)
+	if h.Equal(h2) {
+		t.Fatal("hash does not depend on the domain")
+	}
+	if !h2.Equal(hashToG2([]byte("domain_separation_tag_test_12345"), msg)) {
+		t.Fatal("hash does not use the domain of the group")
+	}
+}
+
+// hashToG2Vectors are the encodings of the hashes to G₂ with the default
+// domain separation tag, computed with an independent implementation of
+// RFC 9380 and the map and cofactor of hashToG2.
+var hashToG2Vectors = []struct {
+	msg      string
+	expected string
+}{
+	{
+		msg: "",
+		expected: "1067a400a3a88aabcb5b475aabd563a4886df4b64be6342a0f4613dfb47a12fa1cac48cba286eaa3e93c1070de711a357b0d98e723113e94c24e41c5ff8229d0" +
+			"22fd478a6c4aa810f1aa559aa1759236d2ce156633dab715c2658442c4b074fd29de8a8c5f5396e79d931100893df02845f8c3ab9030e49cd0d347b6b09252f1",
+	},
+	{
+		msg: "abc",
+		expected: "1ef4a722930a3d214df05ca2b25bd6ae8b5da244bcf90e11b8d8053a365ff75113e60316b029bae2c2006d95114c3551b8e2f5ef8f65c6f53ccf69a45abb6cea" +
+			"13e9233f2d0d5dca29a6e9f7b0a6be07d0dfd058dcf25c77ef134c18e64205fc2423cd7c4498e6b264561f70b3fbc76bf2cf0720795e720c798246a46de8bc07",
+	},
+	{
+		msg: "abcdef0123456789",
+		expected: "001532caa3a949b0b96d1102eb6709995e3f5a351a58d23dda4a47d416f82a291260821884ae7e4b51fb4f04be81f53e587f00a9493ea855f67ed4ff101d41cb" +
+			"281a6291e0236b3e5843329fee7d3a3f49a5aea23a26767cc6b5b97be6646a402a301fe2f44742f54ba23884ba2fedac44aeee82804419934e29e86d285cd5eb",
+	},
+	{
+		msg: "q128_" + strings.Repeat("q", 128),
+		expected: "2c5174a3746e9497b3e1c7519bac183042402faa1f35f1ccacff330fedd75a300159fbb3d4964fef153fa99dc62d2bdd6168ec67d8a9320147d99e93ebfa982b" +
+			"0a60b1c5abf72857c89b763e65609cc342ef04bb71aee523ab1e94eec36063501c47eaf094c9e09d5125582b0f1cdecfe76e5da25621694bae360e1a7978e6c1",
+	},
+	{
+		msg: "a512_" + strings.Repeat("a", 512),
+		expected: "2257e2a8536a8b5bfbaf61e5c0b4a675784aeba120598a40fcda61b207b302840071cee483f6ac605a6cc06a496369cfcdad7333553f70211e4ea97d88cb93c1" +
+			"24c4d4dcfb485be0e0ca160c619f5aa6359a9d302289c56eb21c2532fbc6454e2ab21832d4cc3b2f90c098a1e8d3f5aa00bb806b2a7bf8973b131fc7540c74f3",
+	},
+}
+
+func TestPointG2_HashToPointVectors(t *testing.T) {
+	for _, v := range hashToG2Vectors {
+		buf, err := hashToG2(nil, []byte(v.msg)).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(buf) != v.expected {
+			t.Fatalf("hash of %q doesn't match the known value", v.msg)
+		}
+	}
+}
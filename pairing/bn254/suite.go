@@ -42,7 +42,7 @@ func newDefaultDomainG1() []byte {
 }
 
 func newDefaultDomainG2() []byte {
-	return []byte("BN254G2_XMD:KECCAK-256_SSWU_RO_")
+	return []byte("BN254G2_XMD:KECCAK-256_SVDW_RO_")
 }
 
 // NewSuite generates and returns a new BN254 pairing suite.
@@ -132,13 +132,38 @@ func (s *Suite) Pair(p1 kyber.Point, p2 kyber.Point) kyber.Point {
 	return s.GT().Point().(*pointGT).Pair(p1, p2)
 }
 
-// NB: Not safe for concurrent calls
+// ValidatePairing checks that e(p1, p2) = e(inv1, inv2) with a single
+// Miller loop and final exponentiation.
 func (s *Suite) ValidatePairing(p1, p2, inv1, inv2 kyber.Point) bool {
-	p2Norm := p2.Clone()
-	inv2Norm := inv2.Clone()
-	p2Norm.(*pointG2).g.MakeAffine()
-	inv2Norm.(*pointG2).g.MakeAffine()
-	return s.Pair(p1, p2Norm).Equal(s.Pair(inv1, inv2Norm))
+	return s.PairingCheck([]kyber.Point{p1, s.G1().Point().Neg(inv1)}, []kyber.Point{p2, inv2})
+}
+
+// MultiPair takes the points g1s[i] and g2s[i] in groups G1 and G2,
+// respectively, and computes the product of their pairings in GT with a
+// single Miller loop and final exponentiation.
+func (s *Suite) MultiPair(g1s, g2s []kyber.Point) kyber.Point {
+	if len(g1s) != len(g2s) {
+		panic("bn254: mismatched number of points in MultiPair")
+	}
+	var qs []*twistPoint
+	var ps []*curvePoint
+	for i := range g1s {
+		a := g1s[i].(*pointG1).g
+		b := g2s[i].(*pointG2).g
+		// the pairing of the point at infinity is one
+		if a.IsInfinity() || b.IsInfinity() {
+			continue
+		}
+		ps = append(ps, a)
+		qs = append(qs, b)
+	}
+	return &pointGT{g: finalExponentiation(multiMiller(qs, ps))}
+}
+
+// PairingCheck reports whether the product of the pairings of the points
+// g1s[i] and g2s[i] is one.
+func (s *Suite) PairingCheck(g1s, g2s []kyber.Point) bool {
+	return s.MultiPair(g1s, g2s).Equal(s.GT().Point().Null())
 }
 
 // Not used other than for reflect.TypeOf()
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.dedis.ch/kyber/v4/internal/test"
 	"go.dedis.ch/kyber/v4/sign/bls"
+	"go.dedis.ch/kyber/v4/sign/tbls"
 	"go.dedis.ch/kyber/v4/util/random"
 )
 
@@ -15,6 +16,18 @@ func TestBLSSchemeBN256G1(t *testing.T) {
 	test.SchemeTesting(t, s)
 }
 
+func TestBLSSchemeBN256G2(t *testing.T) {
+	suite := NewSuite()
+	s := bls.NewSchemeOnG2(suite)
+	test.SchemeTesting(t, s)
+}
+
+func TestTBLSSchemeBN256G2(t *testing.T) {
+	suite := NewSuite()
+	s := tbls.NewThresholdSchemeOnG2(suite)
+	test.ThresholdTest(t, suite.G1(), s)
+}
+
 func TestBinaryMarshalAfterAggregation_issue400(t *testing.T) {
 	suite := NewSuite()
 	s := bls.NewSchemeOnG1(suite)
@@ -27,6 +27,7 @@ func (g *groupG1) Point() kyber.Point {
 type groupG2 struct {
 	common
 	*commonSuite
+	dst []byte
 }
 
 func (g *groupG2) String() string {
@@ -34,11 +35,11 @@ func (g *groupG2) String() string {
 }
 
 func (g *groupG2) PointLen() int {
-	return newPointG2().MarshalSize()
+	return newPointG2(g.dst).MarshalSize()
 }
 
 func (g *groupG2) Point() kyber.Point {
-	return newPointG2()
+	return newPointG2(g.dst)
 }
 
 type groupGT struct {
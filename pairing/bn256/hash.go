@@ -1,6 +1,13 @@
 package bn256
 
-import "go.dedis.ch/kyber/v4"
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/xmd"
+	"go.dedis.ch/kyber/v4/pairing/internal/svdw"
+)
 
 // HashG1 implements a hashing function into the G1 group.
 //
@@ -106,3 +113,52 @@ func mapToCurve(t *gfP) kyber.Point {
 	pg1 := pointG1{cp}
 	return pg1.Clone()
 }
+
+// g2Map is the Shallue-van de Woestijne map to the twist y² = x³ + 3/ξ.
+var g2Map = svdw.New(p, svdw.Element{A: twistB.y.BigInt(), B: twistB.x.BigInt()})
+
+// g2Cofactor is the cofactor 2p - n of G₂ in the group of the points of the
+// twist over GF(p²).
+var g2Cofactor = new(big.Int).Sub(new(big.Int).Lsh(p, 1), Order)
+
+// Hash hashes m to G₂ with the domain separation tag of the group, see
+// HashG2.
+func (p *pointG2) Hash(m []byte) kyber.Point {
+	return HashG2(m, p.dst)
+}
+
+// HashG2 implements the hash_to_curve of RFC 9380, section 3, into the G2
+// group, with expand_message_xmd and SHA-256, the Shallue-van de Woestijne
+// map to the twist and the clearing of the cofactor by scalar
+// multiplication.
+//
+// dst represents domain separation tag, similar to salt, for the hash. An
+// empty dst selects the default tag of the suites.
+func HashG2(msg, dst []byte) kyber.Point {
+	if len(dst) == 0 {
+		dst = newDefaultDomainG2()
+	}
+	u, err := xmd.HashToField(sha256.New, msg, dst, p, 128, 4)
+	if err != nil {
+		panic(err)
+	}
+	q0 := mapToTwist(svdw.Element{A: u[0], B: u[1]})
+	q1 := mapToTwist(svdw.Element{A: u[2], B: u[3]})
+	q0.Add(q0, q1)
+
+	r := newPointG2(dst)
+	r.g.Mul(q0, g2Cofactor)
+	return r
+}
+
+// mapToTwist returns the image of u on the twist, not necessarily in G₂.
+func mapToTwist(u svdw.Element) *twistPoint {
+	x, y := g2Map.MapToCurve(u)
+	one := *newGFp(1)
+	return &twistPoint{
+		x: gfP2{*newGFpFromBigInt(x.B), *newGFpFromBigInt(x.A)},
+		y: gfP2{*newGFpFromBigInt(y.B), *newGFpFromBigInt(y.A)},
+		z: gfP2{*newGFp(0), one},
+		t: gfP2{*newGFp(0), one},
+	}
+}
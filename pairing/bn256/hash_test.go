@@ -1,9 +1,13 @@
 package bn256
 
 import (
+	"bytes"
+	"encoding/hex"
+	"strings"
 	"testing"
 
-	"bytes"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
 )
 
 func TestKnownHashes(t *testing.T) {
@@ -53,3 +57,63 @@ var marshaledHashes = [11][64]byte{
 	[64]byte{45, 115, 123, 118, 162, 144, 82, 134, 198, 17, 162, 200, 91, 168, 191, 115, 31, 66, 81, 201, 111, 250, 133, 16, 247, 62, 92, 251, 227, 234, 116, 183, 16, 117, 103, 177, 94, 201, 169, 155, 59, 218, 174, 242, 28, 66, 171, 113, 245, 247, 98, 236, 193, 26, 85, 62, 215, 101, 229, 214, 191, 153, 176, 168},
 	[64]byte{143, 123, 127, 149, 167, 27, 159, 25, 254, 211, 196, 88, 17, 185, 138, 237, 62, 140, 84, 177, 134, 58, 193, 141, 25, 152, 79, 6, 41, 39, 248, 117, 52, 208, 167, 215, 212, 60, 250, 228, 1, 232, 111, 254, 154, 18, 209, 55, 207, 200, 68, 60, 163, 106, 59, 27, 12, 72, 130, 141, 182, 103, 16, 80},
 }
+
+func TestHashG2(t *testing.T) {
+	msg := []byte("The Times 03/Jan/2009 Chancellor on brink of second bailout for banks")
+	suite := NewSuite()
+
+	h := suite.G2().Point().(kyber.HashablePoint).Hash(msg).(*pointG2)
+	require.True(t, h.g.IsOnCurve())
+	require.False(t, h.g.IsInfinity())
+	q := &twistPoint{}
+	q.Mul(h.g, Order)
+	require.True(t, q.IsInfinity(), "hash is not of order n")
+	require.True(t, h.Equal(HashG2(msg, nil)))
+
+	suite.SetDomainG2([]byte("domain_separation_tag_test_12345"))
+	h2 := suite.G2().Point().(kyber.HashablePoint).Hash(msg)
+	require.False(t, h.Equal(h2))
+	require.True(t, h2.Equal(HashG2(msg, []byte("domain_separation_tag_test_12345"))))
+}
+
+// hashG2Vectors are the encodings of the hashes to G2 with the default domain
+// separation tag, computed with an independent implementation of RFC 9380
+// and the map and cofactor of HashG2.
+var hashG2Vectors = []struct {
+	msg      string
+	expected string
+}{
+	{
+		msg: "",
+		expected: "6beec843f4ff688d5740905a285c9bc37bb2a20d3fcb77e0a903b7f60bd4eec3737905514d4a012240cd6b551beaf083a7c1e826b9a0fa42440b45564ee9a5b5" +
+			"640b5b81d991fd1cd54530852a1d2d258cdb137614d24a9d2deb5e248a379594266d2af3ce2ef62d98d716ae7e3a946036f5a930dda249d3e7b6a9064f492be3",
+	},
+	{
+		msg: "abc",
+		expected: "0f147e9acff7b7d8b4f13c70b786a1ac82cf28abdc2e3740a4716f00b3f44029094e78c85dcec7168493eff56e85876e1885548676ac9ab8b064942e9a0d6412" +
+			"17bed404435aeeafbc4290b6d39521de61434707d83c307b5eaf87b208cb15d448751b3dc2938b7b236a36ce0b06d8589fff3aa5daa10bb27aacc93d9be58737",
+	},
+	{
+		msg: "abcdef0123456789",
+		expected: "617e03799a10573ba27438734c3006dc7519f5e80603b5f5b761b259549997ce6400178e5df6b5e1b502d3c840b8f9f650556218b37f357fb1c72f0bc4414ab9" +
+			"63575e0857065456a51dac3fc804d027465f852b1c7ccfb37f4159d01840b1c88f51855faa6a9d9aeef2c7236b78f6183bc79b1d203d75db5cd2ebd9982ecdeb",
+	},
+	{
+		msg: "q128_" + strings.Repeat("q", 128),
+		expected: "822aa1ae882d9fea04e96534d52c7bed31d08715dab47764ce596efd60888580453b7c359da705cb61021c2df4bf63f4922a828de7f6909730a2f6614ac35a64" +
+			"2eb0231733c2a38bb8cc9706e53ff3a660287a3b8bb36be533c998b94eddfb244d33f929c127bb936f35244f8ee22f573354d7213039b9d9e0921951543b28a1",
+	},
+	{
+		msg: "a512_" + strings.Repeat("a", 512),
+		expected: "8af7cb7cd8335bddc6abdff5fa8e2e19fe46fbfd7f810d31e1724ff3af9e080f2cc27743eca47c5095ec0f1d9e8007ea237ad2b0a35237fbf09f854ae771cd72" +
+			"0eaec109d5d279531092925cec57552131d3839f62ccaa897921fc4f260a7524168641ec176da90395d82d41941ff8f854805e4faf116877b886d58994046a40",
+	},
+}
+
+func TestHashG2Vectors(t *testing.T) {
+	for _, v := range hashG2Vectors {
+		buf, err := HashG2([]byte(v.msg), nil).MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, v.expected, hex.EncodeToString(buf), v.msg)
+	}
+}
@@ -292,11 +292,12 @@ func deriveY(x *big.Int) *big.Int {
 }
 
 type pointG2 struct {
-	g *twistPoint
+	g   *twistPoint
+	dst []byte
 }
 
-func newPointG2() *pointG2 {
-	p := &pointG2{g: &twistPoint{}}
+func newPointG2(dst []byte) *pointG2 {
+	p := &pointG2{g: &twistPoint{}, dst: dst}
 	return p
 }
 
@@ -331,7 +332,7 @@ func (p *pointG2) Set(q kyber.Point) kyber.Point {
 
 // Clone makes a hard copy of the field
 func (p *pointG2) Clone() kyber.Point {
-	q := newPointG2()
+	q := newPointG2(p.dst)
 	q.g = p.g.Clone()
 	return q
 }
@@ -356,7 +357,7 @@ func (p *pointG2) Add(a, b kyber.Point) kyber.Point {
 }
 
 func (p *pointG2) Sub(a, b kyber.Point) kyber.Point {
-	q := newPointG2()
+	q := newPointG2(p.dst)
 	return p.Add(a, q.Neg(b))
 }
 
@@ -368,7 +369,7 @@ func (p *pointG2) Neg(q kyber.Point) kyber.Point {
 
 func (p *pointG2) Mul(s kyber.Scalar, q kyber.Point) kyber.Point {
 	if q == nil {
-		q = newPointG2().Base()
+		q = newPointG2(p.dst).Base()
 	}
 	t := s.(*mod.Int).V
 	r := q.(*pointG2).g
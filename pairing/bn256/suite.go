@@ -37,11 +37,18 @@ type Suite struct {
 	gt *groupGT
 }
 
+func newDefaultDomainG2() []byte {
+	return []byte("BN256G2_XMD:SHA-256_SVDW_RO_")
+}
+
 // NewSuite generates and returns a new BN256 pairing suite.
 func NewSuite() *Suite {
 	s := &Suite{commonSuite: &commonSuite{}}
 	s.g1 = &groupG1{commonSuite: s.commonSuite}
-	s.g2 = &groupG2{commonSuite: s.commonSuite}
+	s.g2 = &groupG2{
+		commonSuite: s.commonSuite,
+		dst:         newDefaultDomainG2(),
+	}
 	s.gt = &groupGT{commonSuite: s.commonSuite}
 	return s
 }
@@ -72,11 +79,21 @@ func NewSuiteGT() *Suite {
 func NewSuiteRand(rand cipher.Stream) *Suite {
 	s := &Suite{commonSuite: &commonSuite{s: rand}}
 	s.g1 = &groupG1{commonSuite: s.commonSuite}
-	s.g2 = &groupG2{commonSuite: s.commonSuite}
+	s.g2 = &groupG2{
+		commonSuite: s.commonSuite,
+		dst:         newDefaultDomainG2(),
+	}
 	s.gt = &groupGT{commonSuite: s.commonSuite}
 	return s
 }
 
+// SetDomainG2 sets the domain separation tag of the hash to G2.
+func (s *Suite) SetDomainG2(dst []byte) {
+	newDST := make([]byte, len(dst))
+	copy(newDST, dst)
+	s.g2.dst = newDST
+}
+
 // G1 returns the group G1 of the BN256 pairing.
 func (s *Suite) G1() kyber.Group {
 	return s.g1
@@ -98,8 +115,38 @@ func (s *Suite) Pair(p1 kyber.Point, p2 kyber.Point) kyber.Point {
 	return s.GT().Point().(*pointGT).Pair(p1, p2)
 }
 
+// ValidatePairing checks that e(p1, p2) = e(inv1, inv2) with a single
+// Miller loop and final exponentiation.
 func (s *Suite) ValidatePairing(p1, p2, inv1, inv2 kyber.Point) bool {
-	return s.Pair(p1, p2).Equal(s.Pair(inv1, inv2))
+	return s.PairingCheck([]kyber.Point{p1, s.G1().Point().Neg(inv1)}, []kyber.Point{p2, inv2})
+}
+
+// MultiPair takes the points g1s[i] and g2s[i] in groups G1 and G2,
+// respectively, and computes the product of their pairings in GT with a
+// single Miller loop and final exponentiation.
+func (s *Suite) MultiPair(g1s, g2s []kyber.Point) kyber.Point {
+	if len(g1s) != len(g2s) {
+		panic("bn256: mismatched number of points in MultiPair")
+	}
+	var qs []*twistPoint
+	var ps []*curvePoint
+	for i := range g1s {
+		a := g1s[i].(*pointG1).g
+		b := g2s[i].(*pointG2).g
+		// the pairing of the point at infinity is one
+		if a.IsInfinity() || b.IsInfinity() {
+			continue
+		}
+		ps = append(ps, a)
+		qs = append(qs, b)
+	}
+	return &pointGT{g: finalExponentiation(multiMiller(qs, ps))}
+}
+
+// PairingCheck reports whether the product of the pairings of the points
+// g1s[i] and g2s[i] is one.
+func (s *Suite) PairingCheck(g1s, g2s []kyber.Point) bool {
+	return s.MultiPair(g1s, g2s).Equal(s.GT().Point().Null())
 }
 
 // Not used other than for reflect.TypeOf()
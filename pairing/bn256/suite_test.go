@@ -414,7 +414,7 @@ func BenchmarkBn256(b *testing.B) {
 	e := suite.G2().Scalar()
 
 	p1 := newPointG1()
-	p2 := newPointG2()
+	p2 := newPointG2(nil)
 
 	b.Run("Add", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
@@ -446,3 +446,52 @@ func BenchmarkBn256(b *testing.B) {
 		}
 	})
 }
+
+func TestMultiScalarMul(t *testing.T) {
+	suite := NewSuite()
+	for _, g := range []kyber.Group{suite.G1(), suite.G2()} {
+		for _, n := range []int{0, 1, 40} {
+			scalars := make([]kyber.Scalar, n)
+			points := make([]kyber.Point, n)
+			expected := g.Point().Null()
+			for i := range points {
+				scalars[i] = g.Scalar().Pick(random.New())
+				points[i] = g.Point().Pick(random.New())
+				expected.Add(expected, g.Point().Mul(scalars[i], points[i]))
+			}
+			p := g.Point().(kyber.MultiScalarMultiplier).MultiScalarMul(scalars, points)
+			require.True(t, expected.Equal(p), "%s: mismatch for %d terms", g, n)
+		}
+	}
+}
+
+func TestMultiPair(t *testing.T) {
+	suite := NewSuite()
+	var g1s, g2s []kyber.Point
+	expected := suite.GT().Point().Null()
+	for i := 0; i < 4; i++ {
+		p1 := suite.G1().Point().Pick(random.New())
+		p2 := suite.G2().Point().Pick(random.New())
+		g1s = append(g1s, p1)
+		g2s = append(g2s, p2)
+		expected.Add(expected, suite.Pair(p1, p2))
+	}
+	// the pairings of the points at infinity are one
+	g1s = append(g1s, suite.G1().Point().Null(), suite.G1().Point().Base())
+	g2s = append(g2s, suite.G2().Point().Base(), suite.G2().Point().Null())
+	require.True(t, expected.Equal(suite.MultiPair(g1s, g2s)))
+	require.True(t, suite.MultiPair(nil, nil).Equal(suite.GT().Point().Null()))
+
+	// e(aG, bH) * e(-abG, H) = 1
+	a := suite.G1().Scalar().Pick(random.New())
+	b := suite.G1().Scalar().Pick(random.New())
+	aG := suite.G1().Point().Mul(a, nil)
+	bH := suite.G2().Point().Mul(b, nil)
+	abG := suite.G1().Point().Mul(suite.G1().Scalar().Mul(a, b), nil)
+	H := suite.G2().Point().Base()
+	require.True(t, suite.PairingCheck([]kyber.Point{aG, abG.Clone().Neg(abG)}, []kyber.Point{bH, H}))
+	require.False(t, suite.PairingCheck([]kyber.Point{aG, abG}, []kyber.Point{bH, H}))
+	require.True(t, suite.ValidatePairing(aG, bH, abG, H))
+	require.False(t, suite.ValidatePairing(aG, bH, aG, H))
+	require.Panics(t, func() { suite.MultiPair(g1s, g2s[1:]) })
+}
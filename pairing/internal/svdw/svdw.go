@@ -0,0 +1,227 @@
+// Package svdw implements the Shallue-van de Woestijne map of RFC 9380,
+// section 6.6.1, to the curves y² = x³ + B over GF(p²) = GF(p)[i]/(i²+1),
+// for the primes p = 3 mod 4. It is used to hash to the group G₂ of the BN
+// curves, whose twists have this form.
+//
+// The arithmetic is done with math/big and is not constant time, like the
+// hash to G₁ of the BN curves.
+package svdw
+
+import (
+	"math/big"
+)
+
+// Element is the element a + b·i of GF(p²), with a and b reduced modulo p.
+type Element struct {
+	A, B *big.Int
+}
+
+// Map is the Shallue-van de Woestijne map to a curve y² = x³ + B.
+type Map struct {
+	p *big.Int
+	b Element
+	// z and the constants c1 to c4 of RFC 9380, section 6.6.1
+	z, c1, c2, c3, c4 Element
+	// exponents of the square roots and the quadratic residuosity
+	sqrtExp, legendreExp *big.Int
+}
+
+// New returns the map to the curve y² = x³ + b over GF(p²). Its Z is the
+// first of 1, -1, 2, -2, ... that meets the requirements of RFC 9380,
+// appendix H.1.
+func New(p *big.Int, b Element) *Map {
+	m := &Map{p: p, b: b}
+	m.sqrtExp = new(big.Int).Rsh(new(big.Int).Sub(p, big.NewInt(3)), 2)
+	m.legendreExp = new(big.Int).Rsh(new(big.Int).Sub(p, big.NewInt(1)), 1)
+
+	for ctr := int64(1); ; ctr++ {
+		for _, z := range []int64{ctr, -ctr} {
+			if m.setZ(m.fromInt(z)) {
+				return m
+			}
+		}
+	}
+}
+
+// setZ sets the constants of the map for z if it meets the requirements of
+// the map, with A = 0.
+func (m *Map) setZ(z Element) bool {
+	gz := m.g(z)
+	// 3Z² + 4A
+	d := m.mul(m.fromInt(3), m.mul(z, z))
+	if m.isZero(gz) || m.isZero(d) {
+		return false
+	}
+	// -(3Z² + 4A) / (4g(Z)) must be a non-zero square
+	t := m.mul(m.neg(d), m.inv(m.mul(m.fromInt(4), gz)))
+	if m.isZero(t) || !m.isSquare(t) {
+		return false
+	}
+	// g(Z) or g(-Z/2) must be a square
+	halfZ := m.mul(m.neg(z), m.inv(m.fromInt(2)))
+	if !m.isSquare(gz) && !m.isSquare(m.g(halfZ)) {
+		return false
+	}
+
+	m.z = z
+	m.c1 = gz
+	m.c2 = halfZ
+	c3, _ := m.sqrt(m.neg(m.mul(gz, d)))
+	if m.sgn0(c3) == 1 {
+		c3 = m.neg(c3)
+	}
+	m.c3 = c3
+	m.c4 = m.mul(m.neg(m.mul(m.fromInt(4), gz)), m.inv(d))
+	return true
+}
+
+// Z returns the constant Z of the map.
+func (m *Map) Z() Element {
+	return m.z
+}
+
+// MapToCurve returns the affine coordinates of the image of u on the curve.
+func (m *Map) MapToCurve(u Element) (x, y Element) {
+	one := m.fromInt(1)
+	tv1 := m.mul(m.mul(u, u), m.c1)
+	tv2 := m.add(one, tv1)
+	tv1 = m.sub(one, tv1)
+	tv3 := m.inv(m.mul(tv1, tv2))
+	tv4 := m.mul(m.mul(m.mul(u, tv1), tv3), m.c3)
+	x1 := m.sub(m.c2, tv4)
+	x2 := m.add(m.c2, tv4)
+	tv2 = m.mul(tv2, tv2)
+	tv2 = m.mul(tv2, tv3)
+	tv2 = m.mul(tv2, tv2)
+	x3 := m.add(m.z, m.mul(tv2, m.c4))
+
+	var ok bool
+	switch {
+	case m.isSquare(m.g(x1)):
+		x = x1
+	case m.isSquare(m.g(x2)):
+		x = x2
+	default:
+		x = x3
+	}
+	y, ok = m.sqrt(m.g(x))
+	if !ok {
+		panic("svdw: no square root")
+	}
+	if m.sgn0(u) != m.sgn0(y) {
+		y = m.neg(y)
+	}
+	return x, y
+}
+
+// g returns x³ + B.
+func (m *Map) g(x Element) Element {
+	return m.add(m.mul(m.mul(x, x), x), m.b)
+}
+
+func (m *Map) fromInt(v int64) Element {
+	a := big.NewInt(v)
+	return Element{a.Mod(a, m.p), new(big.Int)}
+}
+
+func (m *Map) isZero(x Element) bool {
+	return x.A.Sign() == 0 && x.B.Sign() == 0
+}
+
+func (m *Map) add(x, y Element) Element {
+	a := new(big.Int).Add(x.A, y.A)
+	b := new(big.Int).Add(x.B, y.B)
+	return Element{a.Mod(a, m.p), b.Mod(b, m.p)}
+}
+
+func (m *Map) sub(x, y Element) Element {
+	a := new(big.Int).Sub(x.A, y.A)
+	b := new(big.Int).Sub(x.B, y.B)
+	return Element{a.Mod(a, m.p), b.Mod(b, m.p)}
+}
+
+func (m *Map) neg(x Element) Element {
+	return m.sub(Element{new(big.Int), new(big.Int)}, x)
+}
+
+// mul returns (a + bi)(c + di) = (ac - bd) + (ad + bc)i.
+func (m *Map) mul(x, y Element) Element {
+	a := new(big.Int).Mul(x.A, y.A)
+	a.Sub(a, new(big.Int).Mul(x.B, y.B))
+	b := new(big.Int).Mul(x.A, y.B)
+	b.Add(b, new(big.Int).Mul(x.B, y.A))
+	return Element{a.Mod(a, m.p), b.Mod(b, m.p)}
+}
+
+// norm returns a² + b², the norm of a + bi in GF(p).
+func (m *Map) norm(x Element) *big.Int {
+	n := new(big.Int).Mul(x.A, x.A)
+	n.Add(n, new(big.Int).Mul(x.B, x.B))
+	return n.Mod(n, m.p)
+}
+
+// inv returns the inverse of x, (a - bi) / (a² + b²), or 0 if x is 0.
+func (m *Map) inv(x Element) Element {
+	n := m.norm(x)
+	if n.Sign() == 0 {
+		return Element{new(big.Int), new(big.Int)}
+	}
+	n.ModInverse(n, m.p)
+	a := new(big.Int).Mul(x.A, n)
+	b := new(big.Int).Mul(x.B, n)
+	b.Neg(b)
+	return Element{a.Mod(a, m.p), b.Mod(b, m.p)}
+}
+
+func (m *Map) exp(x Element, e *big.Int) Element {
+	r := m.fromInt(1)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = m.mul(r, r)
+		if e.Bit(i) == 1 {
+			r = m.mul(r, x)
+		}
+	}
+	return r
+}
+
+// isSquare reports whether x is a square in GF(p²), which is the case when
+// its norm is a square in GF(p).
+func (m *Map) isSquare(x Element) bool {
+	return new(big.Int).Exp(m.norm(x), m.legendreExp, m.p).Cmp(big.NewInt(1)) <= 0
+}
+
+// sqrt returns a square root of x with the algorithm 9 of "Square root
+// computation over even extension fields" by Adj and Rodríguez-Henríquez,
+// and false if x is not a square.
+func (m *Map) sqrt(x Element) (Element, bool) {
+	one := m.fromInt(1)
+	minusOne := m.neg(one)
+	a1 := m.exp(x, m.sqrtExp)
+	alpha := m.mul(m.mul(a1, a1), x)
+	x0 := m.mul(a1, x)
+
+	var r Element
+	if m.equal(alpha, minusOne) {
+		// r = i·x0
+		r = Element{m.neg(x0).B, x0.A}
+	} else {
+		b := m.exp(m.add(one, alpha), m.legendreExp)
+		r = m.mul(b, x0)
+	}
+	if !m.equal(m.mul(r, r), x) {
+		return r, false
+	}
+	return r, true
+}
+
+func (m *Map) equal(x, y Element) bool {
+	return x.A.Cmp(y.A) == 0 && x.B.Cmp(y.B) == 0
+}
+
+// sgn0 implements the sgn0 of RFC 9380, section 4.1, for GF(p²).
+func (m *Map) sgn0(x Element) int {
+	if x.A.Sign() != 0 {
+		return int(x.A.Bit(0))
+	}
+	return int(x.B.Bit(0))
+}
@@ -0,0 +1,56 @@
+package svdw
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+func bigFromBase10(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+// newTwistMap returns the map to the twist y² = x³ + 3/ξ over GF(p²).
+func newTwistMap(p *big.Int, xi int64) *Map {
+	m := &Map{p: p}
+	b := m.mul(m.fromInt(3), m.inv(Element{big.NewInt(xi), big.NewInt(1)}))
+	return New(p, b)
+}
+
+func TestMapToCurve(t *testing.T) {
+	for _, tc := range []struct {
+		p  *big.Int
+		xi int64
+		z  int64
+	}{
+		// BN254, with ξ = 9 + i
+		{bigFromBase10("21888242871839275222246405745257275088696311157297823662689037894645226208583"), 9, 1},
+		// the BN curve of pairing/bn256, with ξ = 3 + i
+		{bigFromBase10("65000549695646603732796438742359905742825358107623003571877145026864184071783"), 3, 1},
+	} {
+		m := newTwistMap(tc.p, tc.xi)
+		require.True(t, m.equal(m.fromInt(tc.z), m.Z()))
+
+		for i := 0; i < 32; i++ {
+			u := Element{
+				random.Int(tc.p, random.New()),
+				random.Int(tc.p, random.New()),
+			}
+			if i == 0 {
+				u = m.fromInt(0)
+			}
+			x, y := m.MapToCurve(u)
+			require.True(t, m.equal(m.mul(y, y), m.g(x)))
+			require.Equal(t, m.sgn0(u), m.sgn0(y))
+
+			s := m.mul(u, u)
+			r, ok := m.sqrt(s)
+			require.True(t, ok)
+			require.True(t, m.equal(m.mul(r, r), s))
+			require.True(t, m.isSquare(s))
+		}
+	}
+}
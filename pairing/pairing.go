@@ -5,6 +5,9 @@ import "go.dedis.ch/kyber/v4"
 // Suite interface represents a triplet of elliptic curve groups (G₁, G₂
 // and GT) such that there exists a function e(g₁ˣ,g₂ʸ)=gTˣʸ (where gₓ is a
 // generator of the respective group) which is called a pairing.
+//
+// The implementations are safe for concurrent use: the pairings and the
+// hashes to the groups do not modify their arguments or any shared state.
 type Suite interface {
 	G1() kyber.Group
 	G2() kyber.Group
@@ -13,6 +16,13 @@ type Suite interface {
 	// ValidatePairing is a simpler way to verify a pairing equation.
 	// e(p1,p2) =?= e(inv1^-1, inv2^-1)
 	ValidatePairing(p1, p2, inv1, inv2 kyber.Point) bool
+	// MultiPair computes the product of the pairings e(g1s[i], g2s[i]) with
+	// a single final exponentiation. It panics if the slices have different
+	// lengths.
+	MultiPair(g1s, g2s []kyber.Point) kyber.Point
+	// PairingCheck reports whether the product of the pairings
+	// e(g1s[i], g2s[i]) is the identity of GT.
+	PairingCheck(g1s, g2s []kyber.Point) bool
 	kyber.Encoding
 	kyber.HashFactory
 	kyber.XOFFactory
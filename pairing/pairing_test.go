@@ -0,0 +1,78 @@
+package pairing_test
+
+import (
+	"sync"
+	"testing"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/circl"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
+	"go.dedis.ch/kyber/v4/pairing/bn254"
+	"go.dedis.ch/kyber/v4/pairing/bn256"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var suites = map[string]pairing.Suite{
+	"bn254":          bn254.NewSuite(),
+	"bn256":          bn256.NewSuite(),
+	"bls12381-kilic": kilic.NewBLS12381Suite(),
+	"bls12381-circl": circl.NewSuiteBLS12381(),
+}
+
+// TestConcurrentPairings shares the same points and suite between goroutines
+// that pair and hash concurrently. Run it with -race to detect the shared
+// mutable state.
+func TestConcurrentPairings(t *testing.T) {
+	const goroutines = 8
+	rounds := 4
+	if testing.Short() {
+		rounds = 1
+	}
+
+	for name, s := range suites {
+		t.Run(name, func(t *testing.T) {
+			a := s.G1().Scalar().Pick(random.New())
+			b := s.G1().Scalar().Pick(random.New())
+			// sums of points, which are not in affine coordinates in the
+			// backends that use projective ones
+			aG := s.G1().Point().Mul(a, nil)
+			aG.Add(aG, s.G1().Point().Null())
+			bH := s.G2().Point().Mul(b, nil)
+			bH.Add(bH, s.G2().Point().Null())
+			abG := s.G1().Point().Mul(b, aG)
+			H := s.G2().Point().Base()
+
+			msg := []byte("concurrent pairings")
+			expected := s.Pair(aG, bH)
+			h1 := s.G1().Point().(kyber.HashablePoint).Hash(msg)
+			h2 := s.G2().Point().(kyber.HashablePoint).Hash(msg)
+
+			failures := make(chan string, goroutines*rounds*5)
+			check := func(ok bool, what string) {
+				if !ok {
+					failures <- what
+				}
+			}
+			var wg sync.WaitGroup
+			for i := 0; i < goroutines; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for r := 0; r < rounds; r++ {
+						check(s.Pair(aG, bH).Equal(expected), "Pair")
+						check(s.ValidatePairing(aG, bH, abG, H), "ValidatePairing")
+						check(s.PairingCheck([]kyber.Point{aG, abG.Clone().Neg(abG)}, []kyber.Point{bH, H}), "PairingCheck")
+						check(s.G1().Point().(kyber.HashablePoint).Hash(msg).Equal(h1), "Hash on G1")
+						check(s.G2().Point().(kyber.HashablePoint).Hash(msg).Equal(h2), "Hash on G2")
+					}
+				}()
+			}
+			wg.Wait()
+			close(failures)
+			for what := range failures {
+				t.Errorf("%s failed under concurrent calls", what)
+			}
+		})
+	}
+}
@@ -0,0 +1,488 @@
+package dkg
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/share"
+)
+
+// Transcript is the public view of a run of the DKG by a node: the bundles it
+// processed in each phase, each signed by its author, including the
+// conflicting bundles sent by equivocating nodes. The node that emitted the
+// transcript signs it, so it can be held accountable for its content.
+type Transcript struct {
+	// SessionID is the nonce of the run
+	SessionID      []byte
+	Deals          []*DealBundle
+	Responses      []*ResponseBundle
+	Justifications []*JustificationBundle
+	// Signer is the longterm public key of the node that emitted the
+	// transcript.
+	Signer kyber.Point
+	// Signature over the hash of the transcript
+	Signature []byte
+}
+
+// Hash returns the hash of the transcript, that is signed by its emitter.
+func (t *Transcript) Hash() ([]byte, error) {
+	h := sha256.New()
+	_, _ = h.Write(t.SessionID)
+	write := func(packets []Packet) error {
+		if err := binary.Write(h, binary.BigEndian, uint32(len(packets))); err != nil {
+			return err
+		}
+		for _, p := range packets {
+			hash, err := p.Hash()
+			if err != nil {
+				return err
+			}
+			_, _ = h.Write(hash)
+		}
+		return nil
+	}
+	for _, packets := range t.packets() {
+		if err := write(packets); err != nil {
+			return nil, err
+		}
+	}
+	if t.Signer != nil {
+		if _, err := t.Signer.MarshalTo(h); err != nil {
+			return nil, err
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+// packets returns the packets of each phase.
+func (t *Transcript) packets() [3][]Packet {
+	var packets [3][]Packet
+	for _, d := range t.Deals {
+		packets[0] = append(packets[0], d)
+	}
+	for _, r := range t.Responses {
+		packets[1] = append(packets[1], r)
+	}
+	for _, j := range t.Justifications {
+		packets[2] = append(packets[2], j)
+	}
+	return packets
+}
+
+func (t *Transcript) sign(c *Config) error {
+	t.Signer = c.Suite.Point().Mul(c.Longterm, nil)
+	hash, err := t.Hash()
+	if err != nil {
+		return err
+	}
+	t.Signature, err = c.Auth.Sign(c.Longterm, hash)
+	return err
+}
+
+// Misbehaviour is the evidence that a node deviated from the protocol. Since
+// the packets are signed by their authors, anyone knowing the public keys of
+// the nodes can check it. The nodes that didn't send a packet have no
+// evidence against them, besides the complaints of the other nodes.
+type Misbehaviour struct {
+	// Index of the node: a dealer index for the deals and the justifications,
+	// and a share holder index for the responses.
+	Index  Index
+	Reason string
+	// Packets proving the misbehaviour
+	Packets []Packet
+}
+
+func (m *Misbehaviour) String() string {
+	return fmt.Sprintf("node %d: %s", m.Index, m.Reason)
+}
+
+// AuditResult is the outcome of the audit of a transcript.
+type AuditResult struct {
+	QUAL []Node
+	// Commits holds the coefficients of the distributed public polynomial.
+	Commits       []kyber.Point
+	Misbehaviours []*Misbehaviour
+}
+
+// Public returns the distributed public key.
+func (r *AuditResult) Public() kyber.Point {
+	return r.Commits[0]
+}
+
+// Audit replays the public part of the DKG from the transcript emitted by a
+// node, and returns the qualified nodes and the distributed public polynomial
+// that every honest node obtained, along with the misbehaviours found in the
+// transcript. The config holds the public parameters of the run: Longterm and
+// the private share, if any, are not used. If the DKG failed, Audit returns
+// the misbehaviours along with the error.
+//
+// The auditor can't decrypt the deals, so it relies on the complaints of the
+// share holders, which are justified publicly by the dealers. An invalid deal
+// that no share holder complained about is thus considered valid, as it is by
+// the nodes.
+func Audit(c *Config, t *Transcript) (*AuditResult, error) {
+	a, err := newAuditor(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkTranscript(t); err != nil {
+		return nil, err
+	}
+	packets := t.packets()
+	a.processDeals(a.filter(packets[0]))
+	if a.processResponses(a.filter(packets[1])) {
+		a.processJustifications(a.filter(packets[2]))
+	}
+	return a.result()
+}
+
+type auditor struct {
+	c           Config
+	threshold   int
+	isResharing bool
+	olddpub     *share.PubPoly
+	statuses    *StatusMatrix
+	deals       map[Index]*DealBundle
+	publics     map[Index]*share.PubPoly
+	// complaint response bundles against each dealer
+	complaints     map[Index][]Packet
+	evicted        []Index
+	evictedHolders []Index
+	res            AuditResult
+}
+
+func newAuditor(c *Config) (*auditor, error) {
+	a := &auditor{
+		c:          *c,
+		threshold:  c.Threshold,
+		deals:      make(map[Index]*DealBundle),
+		publics:    make(map[Index]*share.PubPoly),
+		complaints: make(map[Index][]Packet),
+	}
+	if a.threshold == 0 {
+		a.threshold = MinimumT(len(c.NewNodes))
+	}
+	oldCoeffs := c.PublicCoeffs
+	if oldCoeffs == nil && c.Share != nil {
+		oldCoeffs = c.Share.Commits
+	}
+	switch {
+	case c.Refresh:
+		if oldCoeffs == nil {
+			return nil, errors.New("dkg: audit of a refresh needs the current commitments")
+		}
+		a.c.OldNodes = c.NewNodes
+	case oldCoeffs != nil:
+		a.isResharing = true
+		if len(c.OldNodes) == 0 || c.OldThreshold == 0 {
+			return nil, errors.New("dkg: audit of a resharing needs the old nodes and threshold")
+		}
+		a.olddpub = share.NewPubPoly(c.Suite, c.Suite.Point().Base(), oldCoeffs)
+	default:
+		a.c.OldNodes = c.NewNodes
+	}
+	a.c.PublicCoeffs = oldCoeffs
+	if len(a.c.NewNodes) == 0 {
+		return nil, errors.New("dkg: can't audit with empty node list")
+	}
+	if a.c.FastSync {
+		a.statuses = NewStatusMatrix(a.c.OldNodes, a.c.NewNodes, Complaint)
+	} else {
+		a.statuses = NewStatusMatrix(a.c.OldNodes, a.c.NewNodes, Success)
+	}
+	return a, nil
+}
+
+// checkTranscript checks that the transcript is from this run and signed by
+// one of its nodes.
+func (a *auditor) checkTranscript(t *Transcript) error {
+	if !bytes.Equal(t.SessionID, a.c.Nonce) {
+		return errors.New("dkg: transcript from another session")
+	}
+	if t.Signer == nil {
+		return errors.New("dkg: unsigned transcript")
+	}
+	if _, ok := findPub(a.c.OldNodes, t.Signer); !ok {
+		if _, ok := findPub(a.c.NewNodes, t.Signer); !ok {
+			return errors.New("dkg: transcript signer is not a node")
+		}
+	}
+	hash, err := t.Hash()
+	if err != nil {
+		return err
+	}
+	if err := a.c.Auth.Verify(t.Signer, hash, t.Signature); err != nil {
+		return fmt.Errorf("dkg: invalid transcript signature: %w", err)
+	}
+	return nil
+}
+
+func (a *auditor) misbehave(index Index, reason string, packets ...Packet) {
+	a.res.Misbehaviours = append(a.res.Misbehaviours, &Misbehaviour{
+		Index:   index,
+		Reason:  reason,
+		Packets: packets,
+	})
+}
+
+func (a *auditor) evict(index Index, reason string, packets ...Packet) {
+	a.evicted = append(a.evicted, index)
+	a.misbehave(index, reason, packets...)
+}
+
+func (a *auditor) evictHolder(index Index, reason string, packets ...Packet) {
+	a.evictedHolders = append(a.evictedHolders, index)
+	a.misbehave(index, reason, packets...)
+}
+
+// filter returns the packets with a valid signature, without the ones of the
+// nodes that sent conflicting packets, as the nodes do when receiving them.
+// The packets with an invalid signature can't be attributed, so they are
+// ignored.
+func (a *auditor) filter(packets []Packet) []Packet {
+	seen := make(map[Index]Packet)
+	var bad []Index
+	var order []Index
+	for _, p := range packets {
+		if VerifyPacketSignature(&a.c, p) != nil || contains(bad, p.Index()) {
+			continue
+		}
+		prev, ok := seen[p.Index()]
+		if !ok {
+			seen[p.Index()] = p
+			order = append(order, p.Index())
+			continue
+		}
+		h1, err1 := prev.Hash()
+		h2, err2 := p.Hash()
+		if err1 == nil && err2 == nil && bytes.Equal(h1, h2) {
+			continue
+		}
+		bad = append(bad, p.Index())
+		a.misbehave(p.Index(), "equivocation", prev, p)
+	}
+	var res []Packet
+	for _, idx := range order {
+		if !contains(bad, idx) {
+			res = append(res, seen[idx])
+		}
+	}
+	return res
+}
+
+// processDeals is the public part of DistKeyGenerator.ProcessDeals.
+func (a *auditor) processDeals(packets []Packet) {
+	for _, p := range packets {
+		bundle := p.(*DealBundle)
+		if !isIndexIncluded(a.c.OldNodes, bundle.DealerIndex) {
+			continue
+		}
+		if !bytes.Equal(bundle.SessionID, a.c.Nonce) {
+			a.evict(bundle.DealerIndex, "deal with invalid session ID", bundle)
+			continue
+		}
+		if len(bundle.Public) != a.threshold {
+			a.evict(bundle.DealerIndex, "deal with invalid public polynomial", bundle)
+			continue
+		}
+		pubPoly := share.NewPubPoly(a.c.Suite, a.c.Suite.Point().Base(), bundle.Public)
+		if a.c.Refresh && !pubPoly.Commit().Equal(a.c.Suite.Point().Null()) {
+			a.evict(bundle.DealerIndex, "deal with non zero secret in refresh mode", bundle)
+			continue
+		}
+		a.deals[bundle.DealerIndex] = bundle
+		a.publics[bundle.DealerIndex] = pubPoly
+		for _, deal := range bundle.Deals {
+			if !isIndexIncluded(a.c.NewNodes, deal.ShareIndex) {
+				a.evict(bundle.DealerIndex, "deal for an invalid share holder", bundle)
+				break
+			}
+		}
+	}
+	for _, dealer := range a.c.OldNodes {
+		if _, ok := a.deals[dealer.Index]; !ok && !contains(a.evicted, dealer.Index) {
+			a.evict(dealer.Index, "missing deal")
+		}
+		// the nodes present in both groups don't check their own share
+		if nidx, ok := findPub(a.c.NewNodes, dealer.Public); ok {
+			a.statuses.Set(dealer.Index, nidx, Success)
+		}
+	}
+}
+
+// processResponses is the public part of DistKeyGenerator.ProcessResponses.
+// It returns false if the DKG finishes without justifications.
+func (a *auditor) processResponses(packets []Packet) bool {
+	var authors []Index
+	var foundComplaint bool
+	for _, p := range packets {
+		bundle := p.(*ResponseBundle)
+		if !isIndexIncluded(a.c.NewNodes, bundle.ShareIndex) {
+			continue
+		}
+		if !bytes.Equal(bundle.SessionID, a.c.Nonce) {
+			a.evictHolder(bundle.ShareIndex, "response with invalid session ID", bundle)
+			continue
+		}
+		for _, response := range bundle.Responses {
+			if !isIndexIncluded(a.c.OldNodes, response.DealerIndex) {
+				a.evictHolder(bundle.ShareIndex, "response for an invalid dealer", bundle)
+				continue
+			}
+			if !a.c.FastSync && response.Status == Success {
+				a.evictHolder(bundle.ShareIndex, "success response in regular mode", bundle)
+				continue
+			}
+			a.statuses.Set(response.DealerIndex, bundle.ShareIndex, response.Status)
+			if response.Status == Complaint {
+				foundComplaint = true
+				a.complaints[response.DealerIndex] = append(a.complaints[response.DealerIndex], bundle)
+			}
+			// like the nodes, only a valid response counts as sent
+			authors = append(authors, bundle.ShareIndex)
+		}
+	}
+	if a.c.FastSync {
+		for _, n := range a.c.NewNodes {
+			if !contains(authors, n.Index) && !contains(a.evictedHolders, n.Index) {
+				a.evictHolder(n.Index, "missing response")
+			}
+		}
+	}
+	if !foundComplaint && a.statuses.CompleteSuccess() {
+		return false
+	}
+	for _, n := range a.c.OldNodes {
+		if len(a.complaints[n.Index]) >= a.threshold && !contains(a.evicted, n.Index) {
+			a.evict(n.Index, "too many complaints", a.complaints[n.Index]...)
+		}
+	}
+	return true
+}
+
+// processJustifications is the public part of
+// DistKeyGenerator.ProcessJustifications.
+func (a *auditor) processJustifications(packets []Packet) {
+	for _, p := range packets {
+		bundle := p.(*JustificationBundle)
+		if !isIndexIncluded(a.c.OldNodes, bundle.DealerIndex) || contains(a.evicted, bundle.DealerIndex) {
+			continue
+		}
+		if !bytes.Equal(bundle.SessionID, a.c.Nonce) {
+			a.evict(bundle.DealerIndex, "justification with invalid session ID", bundle)
+			continue
+		}
+		pubPoly := a.publics[bundle.DealerIndex]
+		for _, justif := range bundle.Justifications {
+			if !isIndexIncluded(a.c.NewNodes, justif.ShareIndex) {
+				a.evict(bundle.DealerIndex, "justification for an invalid share holder", bundle)
+				continue
+			}
+			commit := a.c.Suite.Point().Mul(justif.Share, nil)
+			if !commit.Equal(pubPoly.Eval(justif.ShareIndex).V) {
+				a.evict(bundle.DealerIndex, "invalid justification", a.deals[bundle.DealerIndex], bundle)
+				continue
+			}
+			if a.isResharing && !a.olddpub.Eval(bundle.DealerIndex).V.Equal(pubPoly.Commit()) {
+				a.evict(bundle.DealerIndex, "deal inconsistent with the previous share", a.deals[bundle.DealerIndex])
+				continue
+			}
+			a.statuses.Set(bundle.DealerIndex, justif.ShareIndex, Success)
+		}
+	}
+	for _, n := range a.c.OldNodes {
+		if !contains(a.evicted, n.Index) && !a.statuses.AllTrue(n.Index) {
+			a.misbehave(n.Index, "missing justification", a.complaints[n.Index]...)
+		}
+	}
+}
+
+// result is the public part of DistKeyGenerator.computeResult.
+func (a *auditor) result() (*AuditResult, error) {
+	for _, index := range a.evicted {
+		a.statuses.SetAll(index, Complaint)
+	}
+	var good int
+	for _, n := range a.c.OldNodes {
+		if a.statuses.AllTrue(n.Index) {
+			good++
+		}
+	}
+	target := a.threshold
+	if a.isResharing {
+		target = a.c.OldThreshold
+	}
+	if good < target {
+		return &a.res, fmt.Errorf("dkg: only %d/%d valid deals", good, target)
+	}
+	if a.isResharing {
+		return a.resharingResult()
+	}
+	return a.dkgResult()
+}
+
+func (a *auditor) dkgResult() (*AuditResult, error) {
+	var finalPub *share.PubPoly
+	if a.c.Refresh {
+		finalPub = share.NewPubPoly(a.c.Suite, a.c.Suite.Point().Base(), a.c.PublicCoeffs)
+	}
+	for _, n := range a.c.OldNodes {
+		if !a.statuses.AllTrue(n.Index) || contains(a.evictedHolders, n.Index) {
+			continue
+		}
+		if finalPub == nil {
+			finalPub = a.publics[n.Index]
+		} else {
+			var err error
+			if finalPub, err = finalPub.Add(a.publics[n.Index]); err != nil {
+				return &a.res, err
+			}
+		}
+		a.res.QUAL = append(a.res.QUAL, n)
+	}
+	if finalPub == nil || (a.c.Refresh && len(a.res.QUAL) < a.threshold) {
+		return &a.res, fmt.Errorf("dkg: only %d/%d valid deals", len(a.res.QUAL), a.threshold)
+	}
+	_, a.res.Commits = finalPub.Info()
+	return &a.res, nil
+}
+
+func (a *auditor) resharingResult() (*AuditResult, error) {
+	oldT := len(a.c.PublicCoeffs)
+	a.res.Commits = make([]kyber.Point, a.threshold)
+	for i := range a.res.Commits {
+		var coeffs []*share.PubShare
+		for _, n := range a.c.OldNodes {
+			if a.statuses.AllTrue(n.Index) {
+				_, commits := a.publics[n.Index].Info()
+				coeffs = append(coeffs, &share.PubShare{I: n.Index, V: commits[i]})
+			}
+		}
+		coeff, err := share.RecoverCommit(a.c.Suite, coeffs, oldT, len(a.c.OldNodes))
+		if err != nil {
+			return &a.res, err
+		}
+		a.res.Commits[i] = coeff
+	}
+	for _, newNode := range a.c.NewNodes {
+		var invalid bool
+		for _, oldNode := range a.c.OldNodes {
+			if !a.statuses.AllTrue(oldNode.Index) && oldNode.Public.Equal(newNode.Public) {
+				invalid = true
+				break
+			}
+		}
+		if !invalid && !contains(a.evictedHolders, newNode.Index) {
+			a.res.QUAL = append(a.res.QUAL, newNode)
+		}
+	}
+	if len(a.res.QUAL) < a.threshold {
+		return &a.res, fmt.Errorf("dkg: too many uncompliant new participants %d/%d",
+			len(a.res.QUAL), a.threshold)
+	}
+	return &a.res, nil
+}
@@ -0,0 +1,291 @@
+package dkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/sign/schnorr"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// auditDKG runs the DKG like RunDKG and returns the results along with the
+// transcript of the run, signed by the first node.
+func auditDKG(t *testing.T, tns []*TestNode, conf Config, dm MapDeal,
+	jm MapJustif) (*Transcript, []*Result) {
+	tr := new(Transcript)
+	results := RunDKG(t, tns, conf, func(deals []*DealBundle) []*DealBundle {
+		tr.Deals = deals
+		if dm != nil {
+			deals = dm(deals)
+		}
+		return deals
+	}, func(resps []*ResponseBundle) []*ResponseBundle {
+		tr.Responses = resps
+		return resps
+	}, func(justifs []*JustificationBundle) []*JustificationBundle {
+		if jm != nil {
+			justifs = jm(justifs)
+		}
+		tr.Justifications = justifs
+		return justifs
+	})
+	tr.SessionID = tns[0].dkg.c.Nonce
+	require.NoError(t, tr.sign(tns[0].dkg.c))
+	return tr, results
+}
+
+// auditConfig returns the public config of the run.
+func auditConfig(tns []*TestNode) *Config {
+	c := *tns[0].dkg.c
+	c.Longterm = nil
+	return &c
+}
+
+// requireAudit checks that the audit gives the public part of the result.
+func requireAudit(t *testing.T, res *Result, audit *AuditResult) {
+	pub := &Result{QUAL: audit.QUAL, Key: &DistKeyShare{Commits: audit.Commits}}
+	require.True(t, res.PublicEqual(pub))
+}
+
+func resign(t *testing.T, tn *TestNode, p Packet) []byte {
+	sig, err := tn.dkg.c.Auth.Sign(tn.Private, mustHash(t, p))
+	require.NoError(t, err)
+	return sig
+}
+
+func TestAuditProto(t *testing.T) {
+	n := 5
+	thr := 4
+	period := 1 * time.Second
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	tns := GenerateTestNodes(suite, n)
+	list := NodesFromTest(tns)
+	network := NewTestNetwork(n)
+	dkgConf := Config{
+		Suite:     suite,
+		NewNodes:  list,
+		Threshold: thr,
+		Auth:      schnorr.NewScheme(suite),
+	}
+	SetupNodes(tns, &dkgConf)
+	SetupProto(tns, period, network)
+
+	var resCh = make(chan OptionResult, 1)
+	for _, node := range tns {
+		go func(n *TestNode) { resCh <- <-n.proto.WaitEnd() }(node)
+	}
+	for _, node := range tns {
+		go node.phaser.Start()
+	}
+	time.Sleep(100 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		moveTime(tns, period)
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	var optRes []OptionResult
+	for res := range resCh {
+		require.NoError(t, res.Error)
+		optRes = append(optRes, res)
+		if len(optRes) == n {
+			break
+		}
+	}
+	conf := auditConfig(tns)
+	for _, res := range optRes {
+		require.NotNil(t, res.Transcript)
+		require.Len(t, res.Transcript.Deals, n)
+		audit, err := Audit(conf, res.Transcript)
+		require.NoError(t, err)
+		require.Empty(t, audit.Misbehaviours)
+		requireAudit(t, res.Result, audit)
+		require.True(t, res.Result.Key.Public().Equal(audit.Public()))
+	}
+
+	// the signature covers the whole transcript
+	tr := *optRes[0].Transcript
+	tr.Deals = tr.Deals[1:]
+	_, err := Audit(conf, &tr)
+	require.Error(t, err)
+
+	tr = *optRes[0].Transcript
+	tr.Signer = tns[0].Public
+	if tr.Signer.Equal(optRes[0].Transcript.Signer) {
+		tr.Signer = tns[1].Public
+	}
+	_, err = Audit(conf, &tr)
+	require.Error(t, err)
+
+	tr = *optRes[0].Transcript
+	conf.Nonce = GetNonce()
+	_, err = Audit(conf, &tr)
+	require.Error(t, err)
+}
+
+func TestAuditJustification(t *testing.T) {
+	n := 5
+	thr := 4
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	tns := GenerateTestNodes(suite, n)
+	conf := Config{
+		Suite:     suite,
+		NewNodes:  NodesFromTest(tns),
+		Threshold: thr,
+		Auth:      schnorr.NewScheme(suite),
+	}
+	// the dealer 1 sends an invalid share to the node 2, which complains, so
+	// the dealer reveals the share
+	tr, results := auditDKG(t, tns, conf, func(deals []*DealBundle) []*DealBundle {
+		for i := range deals[1].Deals {
+			if deals[1].Deals[i].ShareIndex == 2 {
+				deals[1].Deals[i].EncryptedShare[0] ^= 1
+			}
+		}
+		deals[1].Signature = resign(t, tns[1], deals[1])
+		return deals
+	}, nil)
+	require.Len(t, tr.Responses, 1)
+	require.Len(t, tr.Justifications, 1)
+	require.Len(t, results, n)
+
+	audit, err := Audit(auditConfig(tns), tr)
+	require.NoError(t, err)
+	require.Empty(t, audit.Misbehaviours)
+	for _, res := range results {
+		requireAudit(t, res, audit)
+	}
+}
+
+func TestAuditMisbehaviours(t *testing.T) {
+	n := 5
+	thr := 3
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	tns := GenerateTestNodes(suite, n)
+	conf := Config{
+		Suite:     suite,
+		NewNodes:  NodesFromTest(tns),
+		Threshold: thr,
+		Auth:      schnorr.NewScheme(suite),
+	}
+	var equivocation *DealBundle
+	tr, results := auditDKG(t, tns, conf, func(deals []*DealBundle) []*DealBundle {
+		// the dealer 1 sends an invalid share to the node 2
+		for i := range deals[1].Deals {
+			if deals[1].Deals[i].ShareIndex == 2 {
+				deals[1].Deals[i].EncryptedShare[0] ^= 1
+			}
+		}
+		deals[1].Signature = resign(t, tns[1], deals[1])
+		// the dealer 3 sends two different deals, which the nodes discard
+		cp := *deals[3]
+		cp.Deals = append([]Deal(nil), cp.Deals...)
+		cp.Deals[0].EncryptedShare = append([]byte(nil), cp.Deals[0].EncryptedShare...)
+		cp.Deals[0].EncryptedShare[0] ^= 1
+		cp.Signature = resign(t, tns[3], &cp)
+		equivocation = &cp
+		return append(deals[:3:3], deals[4])
+	}, func(justifs []*JustificationBundle) []*JustificationBundle {
+		// the dealer 1 reveals an invalid share
+		for _, j := range justifs {
+			if j.DealerIndex == 1 {
+				j.Justifications[0].Share = suite.Scalar().Pick(random.New())
+				j.Signature = resign(t, tns[1], j)
+			}
+		}
+		return justifs
+	})
+	tr.Deals = append(tr.Deals, equivocation)
+	require.NoError(t, tr.sign(tns[0].dkg.c))
+
+	audit, err := Audit(auditConfig(tns), tr)
+	require.NoError(t, err)
+	require.Len(t, audit.QUAL, n-2)
+	for _, res := range results {
+		// the misbehaving dealers don't check their own packets
+		if res.Key.Share.I == 1 || res.Key.Share.I == 3 {
+			continue
+		}
+		requireAudit(t, res, audit)
+	}
+	reasons := make(map[Index][]string)
+	for _, m := range audit.Misbehaviours {
+		reasons[m.Index] = append(reasons[m.Index], m.Reason)
+		for _, p := range m.Packets {
+			require.NoError(t, VerifyPacketSignature(auditConfig(tns), p))
+		}
+	}
+	require.Equal(t, []string{"invalid justification"}, reasons[1])
+	require.Equal(t, []string{"equivocation", "missing deal"}, reasons[3])
+	require.Len(t, reasons, 2)
+
+	// without enough qualified dealers, the evidence is still returned
+	conf2 := auditConfig(tns)
+	conf2.Threshold = n - 1
+	tr.Deals = tr.Deals[:1]
+	require.NoError(t, tr.sign(tns[0].dkg.c))
+	audit, err = Audit(conf2, tr)
+	require.Error(t, err)
+	require.NotEmpty(t, audit.Misbehaviours)
+}
+
+func TestAuditEmptyResponse(t *testing.T) {
+	n := 5
+	thr := 3
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	tns := GenerateTestNodes(suite, n)
+	conf := Config{
+		Suite:     suite,
+		NewNodes:  NodesFromTest(tns),
+		Threshold: thr,
+		Auth:      schnorr.NewScheme(suite),
+		FastSync:  true,
+	}
+	SetupNodes(tns, &conf)
+	tr := &Transcript{SessionID: tns[0].dkg.c.Nonce}
+	for _, node := range tns {
+		d, err := node.dkg.Deals()
+		require.NoError(t, err)
+		tr.Deals = append(tr.Deals, d)
+	}
+	for _, node := range tns {
+		resp, err := node.dkg.ProcessDeals(tr.Deals)
+		require.NoError(t, err)
+		// the node 2 signs a bundle without any response, which the other
+		// nodes treat as a missing response in fast sync mode
+		if node.Index == 2 {
+			resp.Responses = nil
+			resp.Signature = resign(t, node, resp)
+		}
+		tr.Responses = append(tr.Responses, resp)
+	}
+	// the node 2 sees no complaint as it ignores its own response, so only
+	// the other nodes go through the justification phase
+	others := append(tns[:2:2], tns[3:]...)
+	for _, node := range others {
+		res, just, err := node.dkg.ProcessResponses(tr.Responses)
+		require.NoError(t, err)
+		require.Nil(t, res)
+		if just != nil {
+			tr.Justifications = append(tr.Justifications, just)
+		}
+	}
+	var results []*Result
+	for _, node := range others {
+		res, err := node.dkg.ProcessJustifications(tr.Justifications)
+		require.NoError(t, err)
+		results = append(results, res)
+	}
+	require.NoError(t, tr.sign(tns[0].dkg.c))
+
+	audit, err := Audit(auditConfig(tns), tr)
+	require.NoError(t, err)
+	require.Len(t, audit.QUAL, n-1)
+	for _, res := range results {
+		requireAudit(t, res, audit)
+	}
+	require.Len(t, audit.Misbehaviours, 1)
+	require.Equal(t, tns[2].Index, audit.Misbehaviours[0].Index)
+	require.Equal(t, "missing response", audit.Misbehaviours[0].Reason)
+}
@@ -0,0 +1,289 @@
+// Package board provides implementations of the Board interface of the
+// pedersen DKG protocol: an in-process network of boards that can inject
+// faults, to test the protocol against misbehaving nodes, and a board
+// broadcasting the packets over TCP, to run the protocol between processes.
+package board
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"go.dedis.ch/kyber/v4"
+	dkg "go.dedis.ch/kyber/v4/share/dkg/pedersen"
+)
+
+// Everyone designates all the nodes in the fault injection methods of
+// Network.
+const Everyone dkg.Index = math.MaxUint32
+
+var _ dkg.Board = (*MemoryBoard)(nil)
+
+type link struct {
+	from, to dkg.Index
+}
+
+type fault struct {
+	drop  bool
+	delay time.Duration
+	alter func(dkg.Packet) dkg.Packet
+}
+
+// Network connects in-process boards: a packet pushed on one of them is
+// delivered to all of them, including the sender, unless a fault was injected
+// on the link between the sender and the recipient. Packets are delivered
+// asynchronously, in no particular order.
+type Network struct {
+	sync.Mutex
+	boards []*MemoryBoard
+	faults map[link]*fault
+}
+
+// NewNetwork returns a network without any board.
+func NewNetwork() *Network {
+	return &Network{faults: make(map[link]*fault)}
+}
+
+// Join returns a new board for the node of the given index, which identifies
+// the node in the fault injection methods.
+func (n *Network) Join(index dkg.Index) *MemoryBoard {
+	n.Lock()
+	defer n.Unlock()
+	b := &MemoryBoard{
+		index:   index,
+		network: n,
+		deals:   make(chan dkg.DealBundle),
+		resps:   make(chan dkg.ResponseBundle),
+		justifs: make(chan dkg.JustificationBundle),
+		done:    make(chan struct{}),
+	}
+	n.boards = append(n.boards, b)
+	return b
+}
+
+func (n *Network) fault(l link) *fault {
+	f, ok := n.faults[l]
+	if !ok {
+		f = &fault{}
+		n.faults[l] = f
+	}
+	return f
+}
+
+// Drop drops all the packets sent by the node from to the node to. Either
+// index can be Everyone.
+func (n *Network) Drop(from, to dkg.Index) {
+	n.Lock()
+	defer n.Unlock()
+	n.fault(link{from, to}).drop = true
+}
+
+// Delay delays by d the delivery of the packets sent by the node from to the
+// node to. Either index can be Everyone.
+func (n *Network) Delay(from, to dkg.Index, d time.Duration) {
+	n.Lock()
+	defer n.Unlock()
+	n.fault(link{from, to}).delay = d
+}
+
+// Equivocate makes the node to receive alter(p) instead of each packet p sent
+// by the node from, so that the node from appears to send different packets to
+// different nodes. The packet given to alter is a deep copy that it can modify,
+// down to its points and scalars; it can return nil to drop the packet. Either
+// index can be Everyone.
+func (n *Network) Equivocate(from, to dkg.Index, alter func(dkg.Packet) dkg.Packet) {
+	n.Lock()
+	defer n.Unlock()
+	n.fault(link{from, to}).alter = alter
+}
+
+// Heal removes all the faults injected in the network.
+func (n *Network) Heal() {
+	n.Lock()
+	defer n.Unlock()
+	n.faults = make(map[link]*fault)
+}
+
+// Close closes all the boards of the network, which stop delivering packets.
+func (n *Network) Close() {
+	n.Lock()
+	defer n.Unlock()
+	for _, b := range n.boards {
+		b.Close()
+	}
+}
+
+// faultOf returns the combination of the faults injected on the link between
+// the two nodes. It must be called with the lock held.
+func (n *Network) faultOf(from, to dkg.Index) fault {
+	var res fault
+	links := []link{{from, to}, {from, Everyone}, {Everyone, to}, {Everyone, Everyone}}
+	for _, l := range links {
+		f, ok := n.faults[l]
+		if !ok {
+			continue
+		}
+		res.drop = res.drop || f.drop
+		if f.delay > res.delay {
+			res.delay = f.delay
+		}
+		if res.alter == nil {
+			res.alter = f.alter
+		}
+	}
+	return res
+}
+
+func (n *Network) broadcast(from dkg.Index, p dkg.Packet) {
+	type delivery struct {
+		board *MemoryBoard
+		fault fault
+	}
+	n.Lock()
+	deliveries := make([]delivery, 0, len(n.boards))
+	for _, b := range n.boards {
+		var f fault
+		if b.index != from {
+			f = n.faultOf(from, b.index)
+		}
+		deliveries = append(deliveries, delivery{b, f})
+	}
+	n.Unlock()
+
+	for _, d := range deliveries {
+		if d.fault.drop {
+			continue
+		}
+		packet := p
+		if d.fault.alter != nil {
+			if packet = d.fault.alter(clonePacket(p)); packet == nil {
+				continue
+			}
+		}
+		d.board.deliver(packet, d.fault.delay)
+	}
+}
+
+// MemoryBoard is a Board of a Network.
+type MemoryBoard struct {
+	index   dkg.Index
+	network *Network
+	deals   chan dkg.DealBundle
+	resps   chan dkg.ResponseBundle
+	justifs chan dkg.JustificationBundle
+	done    chan struct{}
+	once    sync.Once
+}
+
+// PushDeals implements the dkg.Board interface.
+func (b *MemoryBoard) PushDeals(d *dkg.DealBundle) {
+	b.network.broadcast(b.index, d)
+}
+
+// IncomingDeal implements the dkg.Board interface.
+func (b *MemoryBoard) IncomingDeal() <-chan dkg.DealBundle {
+	return b.deals
+}
+
+// PushResponses implements the dkg.Board interface.
+func (b *MemoryBoard) PushResponses(r *dkg.ResponseBundle) {
+	b.network.broadcast(b.index, r)
+}
+
+// IncomingResponse implements the dkg.Board interface.
+func (b *MemoryBoard) IncomingResponse() <-chan dkg.ResponseBundle {
+	return b.resps
+}
+
+// PushJustifications implements the dkg.Board interface.
+func (b *MemoryBoard) PushJustifications(j *dkg.JustificationBundle) {
+	b.network.broadcast(b.index, j)
+}
+
+// IncomingJustification implements the dkg.Board interface.
+func (b *MemoryBoard) IncomingJustification() <-chan dkg.JustificationBundle {
+	return b.justifs
+}
+
+// Close stops the delivery of the packets to this board.
+func (b *MemoryBoard) Close() {
+	b.once.Do(func() { close(b.done) })
+}
+
+func (b *MemoryBoard) deliver(p dkg.Packet, delay time.Duration) {
+	go func() {
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-b.done:
+				return
+			}
+		}
+		deliver(p, b.deals, b.resps, b.justifs, b.done)
+	}()
+}
+
+// deliver sends the packet on the channel of its type, unless done is closed
+// first.
+func deliver(p dkg.Packet, deals chan dkg.DealBundle, resps chan dkg.ResponseBundle,
+	justifs chan dkg.JustificationBundle, done chan struct{}) {
+	switch p := p.(type) {
+	case *dkg.DealBundle:
+		select {
+		case deals <- *p:
+		case <-done:
+		}
+	case *dkg.ResponseBundle:
+		select {
+		case resps <- *p:
+		case <-done:
+		}
+	case *dkg.JustificationBundle:
+		select {
+		case justifs <- *p:
+		case <-done:
+		}
+	}
+}
+
+// clonePacket returns a deep copy of the packet, which doesn't share any
+// slice, point or scalar with it.
+func clonePacket(p dkg.Packet) dkg.Packet {
+	switch p := p.(type) {
+	case *dkg.DealBundle:
+		c := *p
+		c.Deals = make([]dkg.Deal, len(p.Deals))
+		for i, d := range p.Deals {
+			c.Deals[i] = dkg.Deal{
+				ShareIndex:     d.ShareIndex,
+				EncryptedShare: append([]byte(nil), d.EncryptedShare...),
+			}
+		}
+		c.Public = make([]kyber.Point, len(p.Public))
+		for i, pub := range p.Public {
+			c.Public[i] = pub.Clone()
+		}
+		c.SessionID = append([]byte(nil), p.SessionID...)
+		c.Signature = append([]byte(nil), p.Signature...)
+		return &c
+	case *dkg.ResponseBundle:
+		c := *p
+		c.Responses = append(c.Responses[:0:0], p.Responses...)
+		c.SessionID = append([]byte(nil), p.SessionID...)
+		c.Signature = append([]byte(nil), p.Signature...)
+		return &c
+	case *dkg.JustificationBundle:
+		c := *p
+		c.Justifications = make([]dkg.Justification, len(p.Justifications))
+		for i, j := range p.Justifications {
+			c.Justifications[i] = dkg.Justification{
+				ShareIndex: j.ShareIndex,
+				Share:      j.Share.Clone(),
+			}
+		}
+		c.SessionID = append([]byte(nil), p.SessionID...)
+		c.Signature = append([]byte(nil), p.Signature...)
+		return &c
+	}
+	return p
+}
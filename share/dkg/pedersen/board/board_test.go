@@ -0,0 +1,175 @@
+package board
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	dkg "go.dedis.ch/kyber/v4/share/dkg/pedersen"
+	"go.dedis.ch/kyber/v4/sign/schnorr"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+// configs returns the configurations of n nodes running a DKG of threshold
+// t with the same nonce.
+func configs(n, t int, fastSync bool) []*dkg.Config {
+	privates := make([]kyber.Scalar, n)
+	nodes := make([]dkg.Node, n)
+	for i := range nodes {
+		privates[i] = suite.Scalar().Pick(random.New())
+		nodes[i] = dkg.Node{Index: dkg.Index(i), Public: suite.Point().Mul(privates[i], nil)}
+	}
+	nonce := dkg.GetNonce()
+	confs := make([]*dkg.Config, n)
+	for i := range confs {
+		confs[i] = &dkg.Config{
+			Suite:     suite,
+			Longterm:  privates[i],
+			NewNodes:  nodes,
+			Threshold: t,
+			Nonce:     nonce,
+			Auth:      schnorr.NewScheme(suite),
+			FastSync:  fastSync,
+		}
+	}
+	return confs
+}
+
+// run runs the protocol for each config on the board of the same index and
+// returns the results, indexed like the configs.
+func run(t *testing.T, confs []*dkg.Config, boards []dkg.Board, period time.Duration) []dkg.OptionResult {
+	protos := make([]*dkg.Protocol, len(confs))
+	phasers := make([]*dkg.TimePhaser, len(confs))
+	for i, c := range confs {
+		phasers[i] = dkg.NewTimePhaser(period)
+		var err error
+		protos[i], err = dkg.NewProtocol(c, boards[i], phasers[i], false)
+		require.NoError(t, err)
+	}
+	for _, p := range phasers {
+		go p.Start()
+	}
+	results := make([]dkg.OptionResult, len(protos))
+	for i, p := range protos {
+		select {
+		case results[i] = <-p.WaitEnd():
+		case <-time.After(10 * period):
+			t.Fatal("protocol did not finish")
+		}
+	}
+	return results
+}
+
+// checkResults checks that the results of the nodes of the given indexes are
+// consistent and have the expected qualified nodes.
+func checkResults(t *testing.T, results []dkg.OptionResult, indexes []int, qual int) {
+	first := results[indexes[0]].Result
+	for _, i := range indexes {
+		require.NoError(t, results[i].Error)
+		require.Len(t, results[i].Result.QUAL, qual)
+		require.True(t, first.PublicEqual(results[i].Result))
+	}
+}
+
+func memoryBoards(n int) (*Network, []dkg.Board) {
+	network := NewNetwork()
+	boards := make([]dkg.Board, n)
+	for i := range boards {
+		boards[i] = network.Join(dkg.Index(i))
+	}
+	return network, boards
+}
+
+func TestMemoryBoard(t *testing.T) {
+	network, boards := memoryBoards(4)
+	defer network.Close()
+	results := run(t, configs(4, 3, true), boards, time.Second)
+	checkResults(t, results, []int{0, 1, 2, 3}, 4)
+}
+
+func TestMemoryBoardDrop(t *testing.T) {
+	network, boards := memoryBoards(4)
+	defer network.Close()
+	// nobody receives the packets of the node 3, which is not qualified
+	network.Drop(3, Everyone)
+	results := run(t, configs(4, 3, false), boards, 200*time.Millisecond)
+	checkResults(t, results, []int{0, 1, 2}, 3)
+}
+
+func TestMemoryBoardDelay(t *testing.T) {
+	network, boards := memoryBoards(4)
+	defer network.Close()
+	network.Delay(1, Everyone, 50*time.Millisecond)
+	network.Delay(Everyone, 2, 50*time.Millisecond)
+	results := run(t, configs(4, 3, false), boards, 200*time.Millisecond)
+	checkResults(t, results, []int{0, 1, 2, 3}, 4)
+}
+
+func TestMemoryBoardEquivocate(t *testing.T) {
+	network, boards := memoryBoards(4)
+	defer network.Close()
+	confs := configs(4, 3, false)
+	// the node 0 sends a signed deal with an invalid share to the node 1, so
+	// the node 1 complains and the node 0 has to reveal the share
+	network.Equivocate(0, 1, func(p dkg.Packet) dkg.Packet {
+		deal, ok := p.(*dkg.DealBundle)
+		if !ok {
+			return p
+		}
+		for i := range deal.Deals {
+			if deal.Deals[i].ShareIndex == 1 {
+				deal.Deals[i].EncryptedShare[0] ^= 1
+			}
+		}
+		hash, err := deal.Hash()
+		require.NoError(t, err)
+		deal.Signature, err = confs[0].Auth.Sign(confs[0].Longterm, hash)
+		require.NoError(t, err)
+		return deal
+	})
+	results := run(t, confs, boards, 200*time.Millisecond)
+	checkResults(t, results, []int{0, 1, 2, 3}, 4)
+}
+
+func TestMemoryBoardEquivocateCopy(t *testing.T) {
+	network, boards := memoryBoards(3)
+	defer network.Close()
+	// the node 1 receives a deal whose commitment is modified in place
+	network.Equivocate(0, 1, func(p dkg.Packet) dkg.Packet {
+		deal := p.(*dkg.DealBundle)
+		deal.Public[0].Add(deal.Public[0], suite.Point().Base())
+		return deal
+	})
+	public := suite.Point().Pick(random.New())
+	boards[0].PushDeals(&dkg.DealBundle{
+		DealerIndex: 0,
+		Public:      []kyber.Point{public.Clone()},
+	})
+
+	receive := func(board dkg.Board) dkg.DealBundle {
+		select {
+		case deal := <-board.IncomingDeal():
+			return deal
+		case <-time.After(time.Second):
+			t.Fatal("no deal received")
+		}
+		return dkg.DealBundle{}
+	}
+	altered := receive(boards[1])
+	require.True(t, altered.Public[0].Equal(suite.Point().Add(public, suite.Point().Base())))
+	// the other nodes receive the original deal
+	require.True(t, receive(boards[2]).Public[0].Equal(public))
+}
+
+func TestMemoryBoardHeal(t *testing.T) {
+	network, boards := memoryBoards(4)
+	defer network.Close()
+	network.Drop(Everyone, Everyone)
+	network.Heal()
+	results := run(t, configs(4, 3, true), boards, time.Second)
+	checkResults(t, results, []int{0, 1, 2, 3}, 4)
+}
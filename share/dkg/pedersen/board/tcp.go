@@ -0,0 +1,300 @@
+package board
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"go.dedis.ch/kyber/v4"
+	dkg "go.dedis.ch/kyber/v4/share/dkg/pedersen"
+)
+
+// MaxFrameLength is the maximum length of the frames accepted by a TCPBoard.
+const MaxFrameLength = 1 << 22
+
+const (
+	dialTimeout  = 5 * time.Second
+	writeTimeout = 10 * time.Second
+	retryPeriod  = 100 * time.Millisecond
+	// number of frames waiting to be sent to a peer before Push blocks
+	queueLength = 16
+)
+
+// types of the packets in the frames
+const (
+	dealFrame byte = iota + 1
+	responseFrame
+	justificationFrame
+)
+
+var _ dkg.Board = (*TCPBoard)(nil)
+
+// TCPBoard is a Board that broadcasts the packets to its peers over TCP, and
+// delivers them to itself. Each packet is sent in a frame made of its length
+// as a 4 bytes big-endian integer, the type of the packet on one byte and its
+// binary encoding. The connections to the peers are established when needed
+// and retried until the board is closed, so the peers don't need to be
+// listening when the board is created.
+type TCPBoard struct {
+	group    kyber.Group
+	listener net.Listener
+	deals    chan dkg.DealBundle
+	resps    chan dkg.ResponseBundle
+	justifs  chan dkg.JustificationBundle
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	sync.Mutex
+	peers  []chan []byte
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+// NewTCPBoard returns a board listening on the given TCP address, which
+// decodes the packets it receives with the given group.
+func NewTCPBoard(g kyber.Group, addr string) (*TCPBoard, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	b := &TCPBoard{
+		group:    g,
+		listener: l,
+		deals:    make(chan dkg.DealBundle),
+		resps:    make(chan dkg.ResponseBundle),
+		justifs:  make(chan dkg.JustificationBundle),
+		done:     make(chan struct{}),
+		conns:    make(map[net.Conn]struct{}),
+	}
+	b.wg.Add(1)
+	go b.accept()
+	return b, nil
+}
+
+// Addr returns the address the board listens on.
+func (b *TCPBoard) Addr() net.Addr {
+	return b.listener.Addr()
+}
+
+// Connect adds peers to which the packets are broadcast.
+func (b *TCPBoard) Connect(addrs ...string) {
+	b.Lock()
+	defer b.Unlock()
+	if b.closed {
+		return
+	}
+	for _, addr := range addrs {
+		out := make(chan []byte, queueLength)
+		b.peers = append(b.peers, out)
+		b.wg.Add(1)
+		go b.send(addr, out)
+	}
+}
+
+// Close stops the board and closes all its connections.
+func (b *TCPBoard) Close() error {
+	b.Lock()
+	if b.closed {
+		b.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.done)
+	err := b.listener.Close()
+	for conn := range b.conns {
+		conn.Close()
+	}
+	b.Unlock()
+	b.wg.Wait()
+	return err
+}
+
+// PushDeals implements the dkg.Board interface.
+func (b *TCPBoard) PushDeals(d *dkg.DealBundle) {
+	b.broadcast(dealFrame, d)
+}
+
+// IncomingDeal implements the dkg.Board interface.
+func (b *TCPBoard) IncomingDeal() <-chan dkg.DealBundle {
+	return b.deals
+}
+
+// PushResponses implements the dkg.Board interface.
+func (b *TCPBoard) PushResponses(r *dkg.ResponseBundle) {
+	b.broadcast(responseFrame, r)
+}
+
+// IncomingResponse implements the dkg.Board interface.
+func (b *TCPBoard) IncomingResponse() <-chan dkg.ResponseBundle {
+	return b.resps
+}
+
+// PushJustifications implements the dkg.Board interface.
+func (b *TCPBoard) PushJustifications(j *dkg.JustificationBundle) {
+	b.broadcast(justificationFrame, j)
+}
+
+// IncomingJustification implements the dkg.Board interface.
+func (b *TCPBoard) IncomingJustification() <-chan dkg.JustificationBundle {
+	return b.justifs
+}
+
+type binaryPacket interface {
+	dkg.Packet
+	MarshalBinary() ([]byte, error)
+}
+
+func (b *TCPBoard) broadcast(typ byte, p binaryPacket) {
+	buf, err := p.MarshalBinary()
+	if err != nil || len(buf)+1 > MaxFrameLength {
+		// the packets generated by the DKG are always valid
+		return
+	}
+	frame := make([]byte, 5, 5+len(buf))
+	binary.BigEndian.PutUint32(frame, uint32(len(buf)+1))
+	frame[4] = typ
+	frame = append(frame, buf...)
+
+	b.Lock()
+	peers := b.peers
+	b.Unlock()
+	for _, out := range peers {
+		select {
+		case out <- frame:
+		case <-b.done:
+			return
+		}
+	}
+	go deliver(p, b.deals, b.resps, b.justifs, b.done)
+}
+
+// track adds the connection to the ones closed with the board, returning
+// false if the board is already closed.
+func (b *TCPBoard) track(conn net.Conn) bool {
+	b.Lock()
+	defer b.Unlock()
+	if b.closed {
+		return false
+	}
+	b.conns[conn] = struct{}{}
+	return true
+}
+
+func (b *TCPBoard) untrack(conn net.Conn) {
+	b.Lock()
+	defer b.Unlock()
+	delete(b.conns, conn)
+	conn.Close()
+}
+
+// send writes the frames to the peer, connecting again after any failure.
+func (b *TCPBoard) send(addr string, out chan []byte) {
+	defer b.wg.Done()
+	var conn net.Conn
+	defer func() {
+		if conn != nil {
+			b.untrack(conn)
+		}
+	}()
+	for {
+		var frame []byte
+		select {
+		case frame = <-out:
+		case <-b.done:
+			return
+		}
+		for {
+			if conn == nil {
+				c, err := net.DialTimeout("tcp", addr, dialTimeout)
+				if err == nil && !b.track(c) {
+					c.Close()
+					return
+				}
+				if err != nil {
+					select {
+					case <-time.After(retryPeriod):
+						continue
+					case <-b.done:
+						return
+					}
+				}
+				conn = c
+			}
+			err := conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err == nil {
+				_, err = conn.Write(frame)
+			}
+			if err == nil {
+				break
+			}
+			b.untrack(conn)
+			conn = nil
+		}
+	}
+}
+
+func (b *TCPBoard) accept() {
+	defer b.wg.Done()
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		if !b.track(conn) {
+			conn.Close()
+			return
+		}
+		b.wg.Add(1)
+		go b.receive(conn)
+	}
+}
+
+// receive reads the frames of the connection until it fails or the board is
+// closed. A connection sending an invalid frame is closed.
+func (b *TCPBoard) receive(conn net.Conn) {
+	defer b.wg.Done()
+	defer b.untrack(conn)
+	for {
+		p, err := b.readFrame(conn)
+		if err != nil {
+			return
+		}
+		deliver(p, b.deals, b.resps, b.justifs, b.done)
+		select {
+		case <-b.done:
+			return
+		default:
+		}
+	}
+}
+
+func (b *TCPBoard) readFrame(r io.Reader) (dkg.Packet, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:])
+	if length == 0 || length > MaxFrameLength {
+		return nil, errors.New("board: invalid frame length")
+	}
+	frame := make([]byte, length)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+	switch frame[0] {
+	case dealFrame:
+		d := new(dkg.DealBundle)
+		return d, d.Decode(b.group, frame[1:])
+	case responseFrame:
+		r := new(dkg.ResponseBundle)
+		return r, r.UnmarshalBinary(frame[1:])
+	case justificationFrame:
+		j := new(dkg.JustificationBundle)
+		return j, j.Decode(b.group, frame[1:])
+	}
+	return nil, fmt.Errorf("board: unknown packet type %d", frame[0])
+}
@@ -0,0 +1,59 @@
+package board
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dkg "go.dedis.ch/kyber/v4/share/dkg/pedersen"
+)
+
+func tcpBoards(t *testing.T, n int) []*TCPBoard {
+	boards := make([]*TCPBoard, n)
+	for i := range boards {
+		var err error
+		boards[i], err = NewTCPBoard(suite, "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { boards[i].Close() })
+	}
+	for i, b := range boards {
+		for j, peer := range boards {
+			if i != j {
+				b.Connect(peer.Addr().String())
+			}
+		}
+	}
+	return boards
+}
+
+func TestTCPBoard(t *testing.T) {
+	n := 4
+	tcp := tcpBoards(t, n)
+	boards := make([]dkg.Board, n)
+	for i, b := range tcp {
+		boards[i] = b
+	}
+	results := run(t, configs(n, 3, true), boards, time.Second)
+	checkResults(t, results, []int{0, 1, 2, 3}, n)
+}
+
+func TestTCPBoardInvalidFrame(t *testing.T) {
+	b := tcpBoards(t, 1)[0]
+
+	conn, err := net.Dial("tcp", b.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], MaxFrameLength+1)
+	_, err = conn.Write(header[:])
+	require.NoError(t, err)
+
+	// the board closes the connection
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	_, err = conn.Read(header[:])
+	require.Error(t, err)
+	require.NotErrorIs(t, err, os.ErrDeadlineExceeded)
+}
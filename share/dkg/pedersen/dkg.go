@@ -30,7 +30,8 @@ type Suite interface {
 // (i.e. it belongs to the current group), the Share field must be filled in
 // with the current share of the node. If the node using this config is a new
 // addition and thus has no current share, the PublicCoeffs field be must be
-// filled in.
+// filled in. In the case of a refresh protocol, one must fill the following:
+// Suite, Longterm, NewNodes, Share and Refresh.
 type Config struct {
 	Suite Suite
 
@@ -100,6 +101,17 @@ type Config struct {
 	//  the responses messages are small.
 	FastSync bool
 
+	// Refresh is a mode where the current group re-randomizes its shares
+	// without changing the distributed key, the threshold or the members. Each
+	// node deals shares of a polynomial whose secret is zero, whose public
+	// polynomial anyone can check commits to the neutral element, and adds
+	// the shares it receives to its current one. It is cheaper than a
+	// resharing since there is no interpolation, and makes the shares leaked
+	// before the refresh useless against the shares issued after it. Every
+	// node must fill in its current share in the Share field, NewNodes must be
+	// the current group and OldNodes must be left empty.
+	Refresh bool
+
 	// Nonce is required to avoid replay attacks from previous runs of a DKG /
 	// resharing. The required property of the Nonce is that it must be unique
 	// accross runs. A Nonce must be of length 32 bytes. User can get a secure
@@ -185,6 +197,13 @@ type DistKeyGenerator struct {
 	oldPresent bool
 	// public polynomial of the old group
 	olddpub *share.PubPoly
+	// bundles sent by this node, kept in the state so a node restored after a
+	// restart sends the same ones again
+	dealBundle     *DealBundle
+	responseBundle *ResponseBundle
+	justifBundle   *JustificationBundle
+	// result of the protocol, once finished
+	result *Result
 }
 
 // NewDistKeyHandler takes a Config and returns a DistKeyGenerator that is able
@@ -201,7 +220,11 @@ func NewDistKeyHandler(c *Config) (*DistKeyGenerator, error) {
 	}
 
 	var isResharing bool
-	if c.Share != nil || c.PublicCoeffs != nil {
+	if c.Refresh {
+		if err := c.checkRefresh(); err != nil {
+			return nil, err
+		}
+	} else if c.Share != nil || c.PublicCoeffs != nil {
 		isResharing = true
 	}
 	if isResharing {
@@ -241,7 +264,16 @@ func NewDistKeyHandler(c *Config) (*DistKeyGenerator, error) {
 	var dpub *share.PubPoly
 	var olddpub *share.PubPoly
 	var oldThreshold int
-	if !isResharing && newPresent {
+	if c.Refresh {
+		if c.Share.Share.I != nidx {
+			return nil, errors.New("dkg: refreshed share index does not match the index in the group")
+		}
+		// refresh case: deal shares of zero
+		secretCoeff = c.Suite.Scalar().Zero()
+		c.OldNodes = c.NewNodes
+		oidx, oldPresent = findPub(c.OldNodes, pub)
+		canIssue = true
+	} else if !isResharing && newPresent {
 		// fresk DKG present
 		randomStream := random.New()
 		// if the user provided a reader, use it alone or combined with crypto/rand
@@ -367,7 +399,11 @@ func (d *DistKeyGenerator) Deals() (*DealBundle, error) {
 	}
 	var err error
 	bundle.Signature, err = d.sign(bundle)
-	return bundle, err
+	if err != nil {
+		return nil, err
+	}
+	d.dealBundle = bundle
+	return bundle, nil
 }
 
 // ProcessDeals process the deals from all the nodes. Each deal for this node is
@@ -427,6 +463,12 @@ func (d *DistKeyGenerator) ProcessDeals(bundles []*DealBundle) (*ResponseBundle,
 			continue
 		}
 		pubPoly := share.NewPubPoly(d.c.Suite, d.c.Suite.Point().Base(), bundle.Public)
+		if d.c.Refresh && !pubPoly.Commit().Equal(d.c.Suite.Point().Null()) {
+			// a refresh polynomial must share zero, anyone can see it
+			d.evicted = append(d.evicted, bundle.DealerIndex)
+			d.c.Error("Deal with non zero secret in refresh mode")
+			continue
+		}
 		if seenIndex[bundle.DealerIndex] {
 			// already saw a bundle from the same dealer - clear sign of
 			// cheating so we evict him from the list
@@ -538,6 +580,7 @@ func (d *DistKeyGenerator) ProcessDeals(bundles []*DealBundle) (*ResponseBundle,
 		bundle.Signature = sig
 	}
 	d.state = ResponsePhase
+	d.responseBundle = bundle
 	d.c.Info(fmt.Sprintf("sending back %d responses", len(responses)))
 	return bundle, nil
 }
@@ -713,6 +756,7 @@ func (d *DistKeyGenerator) ProcessResponses(bundles []*ResponseBundle) (
 		return nil, nil, err
 	}
 	bundle.Signature = signature
+	d.justifBundle = bundle
 	d.c.Info(fmt.Sprintf("%d justifications returned", len(justifications)))
 	return nil, bundle, nil
 }
@@ -862,12 +906,16 @@ func (d *DistKeyGenerator) computeResult() (*Result, error) {
 	}
 	// add all the shares and public polynomials together for the deals that are
 	// valid ( equivalently or all justified)
+	var res *Result
+	var err error
 	if d.isResharing {
 		// instead of adding, in this case, we interpolate all shares
-		return d.computeResharingResult()
+		res, err = d.computeResharingResult()
+	} else {
+		res, err = d.computeDKGResult()
 	}
-
-	return d.computeDKGResult()
+	d.result = res
+	return res, err
 }
 
 func (d *DistKeyGenerator) computeResharingResult() (*Result, error) {
@@ -986,6 +1034,11 @@ func (d *DistKeyGenerator) computeDKGResult() (*Result, error) {
 	finalShare := d.c.Suite.Scalar().Zero()
 	var err error
 	var finalPub *share.PubPoly
+	if d.c.Refresh {
+		// the shares of zero are added on top of the current share
+		finalShare = d.c.Share.Share.V.Clone()
+		finalPub = share.NewPubPoly(d.suite, d.suite.Point().Base(), d.c.Share.Commits)
+	}
 	var nodes []Node
 	for _, n := range d.c.OldNodes {
 		if !d.statuses.AllTrue(n.Index) {
@@ -1023,6 +1076,9 @@ func (d *DistKeyGenerator) computeDKGResult() (*Result, error) {
 	if finalPub == nil {
 		return nil, fmt.Errorf("BUG: final public polynomial is nil")
 	}
+	if d.c.Refresh && len(nodes) < d.c.Threshold {
+		return nil, fmt.Errorf("dkg: only %d/%d valid refresh deals", len(nodes), d.c.Threshold)
+	}
 	_, commits := finalPub.Info()
 	return &Result{
 		QUAL: nodes,
@@ -1159,6 +1215,23 @@ func (c *Config) Error(keyvals ...interface{}) {
 	}
 }
 
+// checkRefresh returns an error if the configuration is not suitable for a
+// refresh protocol.
+func (c *Config) checkRefresh() error {
+	if c.Share == nil || c.Share.Share == nil {
+		return errors.New("dkg: refresh config needs the current share")
+	}
+	if c.PublicCoeffs != nil || len(c.OldNodes) != 0 {
+		return errors.New("dkg: refresh config can't change the group")
+	}
+	if c.Threshold == 0 {
+		c.Threshold = len(c.Share.Commits)
+	} else if c.Threshold != len(c.Share.Commits) {
+		return errors.New("dkg: refresh config can't change the threshold")
+	}
+	return nil
+}
+
 // CheckForDuplicates looks at the lits of node indices in the OldNodes and
 // NewNodes list. It returns an error if there is a duplicate in either list.
 // NOTE: It only looks at indices because it is plausible that one party may
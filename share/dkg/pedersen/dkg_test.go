@@ -575,6 +575,214 @@ func TestDKGResharing(t *testing.T) {
 	require.Error(t, scheme.VerifyPartial(poly, msg, newPartial))
 }
 
+// runRefresh runs a refresh of the shares held by the nodes, letting dm
+// modify the deals, and returns the results.
+func runRefresh(t *testing.T, tns []*TestNode, conf *Config, dm MapDeal) []*Result {
+	SetupReshareNodes(tns, conf, nil)
+	var deals []*DealBundle
+	for _, node := range tns {
+		d, err := node.dkg.Deals()
+		require.NoError(t, err)
+		deals = append(deals, d)
+	}
+	if dm != nil {
+		deals = dm(deals)
+	}
+
+	var responses []*ResponseBundle
+	for _, node := range tns {
+		resp, err := node.dkg.ProcessDeals(deals)
+		require.NoError(t, err)
+		if resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+
+	var results []*Result
+	var justifs []*JustificationBundle
+	var pending []*TestNode
+	for _, node := range tns {
+		res, just, err := node.dkg.ProcessResponses(responses)
+		if errors.Is(err, ErrEvicted) {
+			continue
+		}
+		require.NoError(t, err)
+		if res != nil {
+			results = append(results, res)
+			continue
+		}
+		if just != nil {
+			justifs = append(justifs, just)
+		}
+		pending = append(pending, node)
+	}
+
+	for _, node := range pending {
+		res, err := node.dkg.ProcessJustifications(justifs)
+		if errors.Is(err, ErrEvicted) {
+			continue
+		}
+		require.NoError(t, err)
+		results = append(results, res)
+	}
+	return results
+}
+
+func TestDKGRefresh(t *testing.T) {
+	n := 5
+	thr := 3
+	var suite = bn256.NewSuiteG2()
+	var sigSuite = bn256.NewSuiteG1()
+	tns := GenerateTestNodes(suite, n)
+	list := NodesFromTest(tns)
+	conf := Config{
+		Suite:     suite,
+		NewNodes:  list,
+		Threshold: thr,
+		Auth:      schnorr.NewScheme(suite),
+	}
+
+	results := RunDKG(t, tns, conf, nil, nil, nil)
+	for i, t := range tns {
+		t.res = results[i]
+	}
+	testResults(t, suite, thr, n, results)
+
+	msg := []byte("Hello World")
+	scheme := tbls.NewThresholdSchemeOnG1(sigSuite)
+	oldPartial, err := scheme.Sign(results[0].Key.Share, msg)
+	require.NoError(t, err)
+	oldPoly := share.NewPubPoly(suite, suite.Point().Base(), results[0].Key.Commits)
+
+	refreshConf := &Config{
+		Suite:    suite,
+		NewNodes: list,
+		Refresh:  true,
+		Auth:     schnorr.NewScheme(suite),
+	}
+	newResults := runRefresh(t, tns, refreshConf, nil)
+	require.Len(t, newResults, n)
+	testResults(t, suite, thr, n, newResults)
+
+	for i, res := range newResults {
+		require.Len(t, res.QUAL, n)
+		require.True(t, res.Key.Public().Equal(results[0].Key.Public()))
+		require.Equal(t, results[i].Key.Share.I, res.Key.Share.I)
+		require.False(t, res.Key.Share.V.Equal(results[i].Key.Share.V))
+	}
+
+	// the old partial signatures do not match the refreshed polynomial
+	newPoly := share.NewPubPoly(suite, suite.Point().Base(), newResults[0].Key.Commits)
+	require.NoError(t, scheme.VerifyPartial(oldPoly, msg, oldPartial))
+	require.Error(t, scheme.VerifyPartial(newPoly, msg, oldPartial))
+	newPartial, err := scheme.Sign(newResults[0].Key.Share, msg)
+	require.NoError(t, err)
+	require.NoError(t, scheme.VerifyPartial(newPoly, msg, newPartial))
+}
+
+func TestDKGRefreshNonZeroSecret(t *testing.T) {
+	n := 5
+	thr := 3
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	tns := GenerateTestNodes(suite, n)
+	list := NodesFromTest(tns)
+	conf := Config{
+		Suite:     suite,
+		NewNodes:  list,
+		Threshold: thr,
+		Auth:      schnorr.NewScheme(suite),
+	}
+	results := RunDKG(t, tns, conf, nil, nil, nil)
+	for i, t := range tns {
+		t.res = results[i]
+	}
+
+	refreshConf := &Config{
+		Suite:    suite,
+		NewNodes: list,
+		Refresh:  true,
+		Auth:     schnorr.NewScheme(suite),
+	}
+	// the first dealer tries to shift the distributed key
+	cheater := tns[0]
+	newResults := runRefresh(t, tns, refreshConf, func(deals []*DealBundle) []*DealBundle {
+		deals[0].Public[0] = suite.Point().Add(deals[0].Public[0], suite.Point().Base())
+		var err error
+		deals[0].Signature, err = refreshConf.Auth.Sign(cheater.Private, mustHash(t, deals[0]))
+		require.NoError(t, err)
+		return deals
+	})
+
+	// every honest node evicts the cheater and keeps the distributed key
+	var honest int
+	for _, res := range newResults {
+		if res.Key.Share.I == cheater.Index {
+			// the cheater does not check its own deal
+			continue
+		}
+		honest++
+		require.Len(t, res.QUAL, n-1)
+		for _, node := range res.QUAL {
+			require.NotEqual(t, cheater.Index, node.Index)
+		}
+		require.True(t, res.Key.Public().Equal(results[0].Key.Public()))
+	}
+	require.Equal(t, n-1, honest)
+}
+
+func TestDKGRefreshConfig(t *testing.T) {
+	n := 5
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	tns := GenerateTestNodes(suite, n)
+	list := NodesFromTest(tns)
+	results := RunDKG(t, tns, Config{
+		Suite:     suite,
+		NewNodes:  list,
+		Threshold: 3,
+		Auth:      schnorr.NewScheme(suite),
+	}, nil, nil, nil)
+
+	newConf := func() *Config {
+		return &Config{
+			Suite:    suite,
+			NewNodes: list,
+			Refresh:  true,
+			Longterm: tns[0].Private,
+			Share:    results[0].Key,
+			Nonce:    GetNonce(),
+			Auth:     schnorr.NewScheme(suite),
+		}
+	}
+	_, err := NewDistKeyHandler(newConf())
+	require.NoError(t, err)
+
+	c := newConf()
+	c.Share = nil
+	_, err = NewDistKeyHandler(c)
+	require.Error(t, err)
+
+	c = newConf()
+	c.Threshold = 4
+	_, err = NewDistKeyHandler(c)
+	require.Error(t, err)
+
+	c = newConf()
+	c.OldNodes = list
+	_, err = NewDistKeyHandler(c)
+	require.Error(t, err)
+
+	c = newConf()
+	c.Share = results[1].Key
+	_, err = NewDistKeyHandler(c)
+	require.Error(t, err)
+}
+
+func mustHash(t *testing.T, p Packet) []byte {
+	h, err := p.Hash()
+	require.NoError(t, err)
+	return h
+}
+
 func TestDKGThreshold(t *testing.T) {
 	n := 5
 	thr := 4
@@ -0,0 +1,616 @@
+package dkg
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/share"
+)
+
+// EncodingVersion is the version of the binary encoding of the bundles and of
+// DistKeyShare, whose schema is in dkg.proto.
+const EncodingVersion = 1
+
+// gcmTagLength is the length of the authentication tag that ECIES adds to the
+// encrypted shares.
+const gcmTagLength = 16
+
+var (
+	_ encoding.BinaryMarshaler   = (*DealBundle)(nil)
+	_ encoding.BinaryMarshaler   = (*ResponseBundle)(nil)
+	_ encoding.BinaryUnmarshaler = (*ResponseBundle)(nil)
+	_ encoding.BinaryMarshaler   = (*JustificationBundle)(nil)
+	_ encoding.BinaryMarshaler   = (*DistKeyShare)(nil)
+)
+
+// MarshalBinary returns the canonical encoding of the bundle, the DealBundle
+// message of dkg.proto.
+func (d *DealBundle) MarshalBinary() ([]byte, error) {
+	var e wireEncoder
+	e.uint32(1, EncodingVersion)
+	e.uint32(2, d.DealerIndex)
+	for _, deal := range d.Deals {
+		var m wireEncoder
+		m.uint32(1, deal.ShareIndex)
+		m.bytes(2, deal.EncryptedShare)
+		e.element(3, m.buf)
+	}
+	for _, p := range d.Public {
+		buf, err := p.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		e.element(4, buf)
+	}
+	e.bytes(5, d.SessionID)
+	e.bytes(6, d.Signature)
+	return e.buf, nil
+}
+
+// Decode decodes a bundle encoded by MarshalBinary, whose public
+// coefficients are points of the given group. It returns an error if the
+// encoding is not canonical, if a point is invalid or if a field doesn't have
+// the expected length.
+func (d *DealBundle) Decode(g kyber.Group, buf []byte) error {
+	var b DealBundle
+	dec := wireDecoder{buf: buf}
+	version, err := dec.version()
+	if err != nil {
+		return err
+	}
+	for dec.more() && err == nil {
+		var field, wt int
+		var raw []byte
+		if field, wt, err = dec.next(3, 4); err != nil {
+			break
+		}
+		switch field {
+		case 2:
+			b.DealerIndex, err = dec.uint32(wt)
+		case 3:
+			if raw, err = dec.element(wt); err == nil {
+				var deal Deal
+				deal, err = decodeDeal(g, raw)
+				b.Deals = append(b.Deals, deal)
+			}
+		case 4:
+			if raw, err = dec.element(wt); err == nil {
+				var p kyber.Point
+				p, err = decodePoint(g, raw)
+				b.Public = append(b.Public, p)
+			}
+		case 5:
+			b.SessionID, err = dec.bytes(wt)
+		case 6:
+			b.Signature, err = dec.bytes(wt)
+		default:
+			err = dec.unknown(field)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	if len(b.Public) == 0 {
+		return errors.New("dkg: deal bundle without public polynomial")
+	}
+	for i := range b.Deals {
+		for j := 0; j < i; j++ {
+			if b.Deals[i].ShareIndex == b.Deals[j].ShareIndex {
+				return fmt.Errorf("dkg: duplicate deal for share holder %d", b.Deals[i].ShareIndex)
+			}
+		}
+	}
+	if err := checkSessionID(b.SessionID); err != nil {
+		return err
+	}
+	*d = b
+	return nil
+}
+
+func decodeDeal(g kyber.Group, buf []byte) (Deal, error) {
+	var deal Deal
+	dec := wireDecoder{buf: buf}
+	for dec.more() {
+		field, wt, err := dec.next()
+		if err != nil {
+			return deal, err
+		}
+		switch field {
+		case 1:
+			deal.ShareIndex, err = dec.uint32(wt)
+		case 2:
+			deal.EncryptedShare, err = dec.bytes(wt)
+		default:
+			err = dec.unknown(field)
+		}
+		if err != nil {
+			return deal, err
+		}
+	}
+	// the ephemeral point and the share encrypted with AES-GCM
+	if len(deal.EncryptedShare) != g.PointLen()+g.ScalarLen()+gcmTagLength {
+		return deal, errors.New("dkg: invalid encrypted share length")
+	}
+	return deal, nil
+}
+
+// MarshalBinary returns the canonical encoding of the bundle, the
+// ResponseBundle message of dkg.proto.
+func (b *ResponseBundle) MarshalBinary() ([]byte, error) {
+	var e wireEncoder
+	e.uint32(1, EncodingVersion)
+	e.uint32(2, b.ShareIndex)
+	for _, resp := range b.Responses {
+		var m wireEncoder
+		m.uint32(1, resp.DealerIndex)
+		m.uint32(2, uint32(resp.Status))
+		e.element(3, m.buf)
+	}
+	e.bytes(4, b.SessionID)
+	e.bytes(5, b.Signature)
+	return e.buf, nil
+}
+
+// UnmarshalBinary decodes a bundle encoded by MarshalBinary. It returns an
+// error if the encoding is not canonical or if a field doesn't have the
+// expected length.
+func (b *ResponseBundle) UnmarshalBinary(buf []byte) error {
+	var r ResponseBundle
+	dec := wireDecoder{buf: buf}
+	version, err := dec.version()
+	if err != nil {
+		return err
+	}
+	for dec.more() && err == nil {
+		var field, wt int
+		var raw []byte
+		if field, wt, err = dec.next(3); err != nil {
+			break
+		}
+		switch field {
+		case 2:
+			r.ShareIndex, err = dec.uint32(wt)
+		case 3:
+			if raw, err = dec.element(wt); err == nil {
+				var resp Response
+				resp, err = decodeResponse(raw)
+				r.Responses = append(r.Responses, resp)
+			}
+		case 4:
+			r.SessionID, err = dec.bytes(wt)
+		case 5:
+			r.Signature, err = dec.bytes(wt)
+		default:
+			err = dec.unknown(field)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	for i := range r.Responses {
+		for j := 0; j < i; j++ {
+			if r.Responses[i].DealerIndex == r.Responses[j].DealerIndex {
+				return fmt.Errorf("dkg: duplicate response for dealer %d", r.Responses[i].DealerIndex)
+			}
+		}
+	}
+	if err := checkSessionID(r.SessionID); err != nil {
+		return err
+	}
+	*b = r
+	return nil
+}
+
+func decodeResponse(buf []byte) (Response, error) {
+	var resp Response
+	dec := wireDecoder{buf: buf}
+	for dec.more() {
+		field, wt, err := dec.next()
+		if err != nil {
+			return resp, err
+		}
+		switch field {
+		case 1:
+			resp.DealerIndex, err = dec.uint32(wt)
+		case 2:
+			var status uint32
+			status, err = dec.uint32(wt)
+			resp.Status = Status(status)
+			if err == nil && resp.Status != Success && resp.Status != Complaint {
+				err = fmt.Errorf("dkg: invalid status %d", status)
+			}
+		default:
+			err = dec.unknown(field)
+		}
+		if err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// MarshalBinary returns the canonical encoding of the bundle, the
+// JustificationBundle message of dkg.proto.
+func (j *JustificationBundle) MarshalBinary() ([]byte, error) {
+	var e wireEncoder
+	e.uint32(1, EncodingVersion)
+	e.uint32(2, j.DealerIndex)
+	for _, just := range j.Justifications {
+		buf, err := just.Share.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		var m wireEncoder
+		m.uint32(1, just.ShareIndex)
+		m.bytes(2, buf)
+		e.element(3, m.buf)
+	}
+	e.bytes(4, j.SessionID)
+	e.bytes(5, j.Signature)
+	return e.buf, nil
+}
+
+// Decode decodes a bundle encoded by MarshalBinary, whose shares
+// are scalars of the given group. It returns an error if the encoding is not
+// canonical, if a share is invalid or if a field doesn't have the expected
+// length.
+func (j *JustificationBundle) Decode(g kyber.Group, buf []byte) error {
+	var b JustificationBundle
+	dec := wireDecoder{buf: buf}
+	version, err := dec.version()
+	if err != nil {
+		return err
+	}
+	for dec.more() && err == nil {
+		var field, wt int
+		var raw []byte
+		if field, wt, err = dec.next(3); err != nil {
+			break
+		}
+		switch field {
+		case 2:
+			b.DealerIndex, err = dec.uint32(wt)
+		case 3:
+			if raw, err = dec.element(wt); err == nil {
+				var just Justification
+				just, err = decodeJustification(g, raw)
+				b.Justifications = append(b.Justifications, just)
+			}
+		case 4:
+			b.SessionID, err = dec.bytes(wt)
+		case 5:
+			b.Signature, err = dec.bytes(wt)
+		default:
+			err = dec.unknown(field)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	for i := range b.Justifications {
+		for k := 0; k < i; k++ {
+			if b.Justifications[i].ShareIndex == b.Justifications[k].ShareIndex {
+				return fmt.Errorf("dkg: duplicate justification for share holder %d",
+					b.Justifications[i].ShareIndex)
+			}
+		}
+	}
+	if err := checkSessionID(b.SessionID); err != nil {
+		return err
+	}
+	*j = b
+	return nil
+}
+
+func decodeJustification(g kyber.Group, buf []byte) (Justification, error) {
+	var just Justification
+	var priv []byte
+	dec := wireDecoder{buf: buf}
+	for dec.more() {
+		field, wt, err := dec.next()
+		if err != nil {
+			return just, err
+		}
+		switch field {
+		case 1:
+			just.ShareIndex, err = dec.uint32(wt)
+		case 2:
+			priv, err = dec.bytes(wt)
+		default:
+			err = dec.unknown(field)
+		}
+		if err != nil {
+			return just, err
+		}
+	}
+	var err error
+	just.Share, err = decodeScalar(g, priv)
+	return just, err
+}
+
+// MarshalBinary returns the canonical encoding of the share, the DistKeyShare
+// message of dkg.proto. The encoding contains the private share.
+func (d *DistKeyShare) MarshalBinary() ([]byte, error) {
+	var e wireEncoder
+	e.uint32(1, EncodingVersion)
+	for _, c := range d.Commits {
+		buf, err := c.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		e.element(2, buf)
+	}
+	buf, err := d.Share.V.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	e.uint32(3, d.Share.I)
+	e.bytes(4, buf)
+	return e.buf, nil
+}
+
+// Decode decodes a share encoded by MarshalBinary, whose points and
+// scalar are elements of the given group. It returns an error if the encoding
+// is not canonical, if an element is invalid or if a field doesn't have the
+// expected length.
+func (d *DistKeyShare) Decode(g kyber.Group, buf []byte) error {
+	var s DistKeyShare
+	var index uint32
+	var priv []byte
+	dec := wireDecoder{buf: buf}
+	version, err := dec.version()
+	if err != nil {
+		return err
+	}
+	for dec.more() && err == nil {
+		var field, wt int
+		var raw []byte
+		if field, wt, err = dec.next(2); err != nil {
+			break
+		}
+		switch field {
+		case 2:
+			if raw, err = dec.element(wt); err == nil {
+				var p kyber.Point
+				p, err = decodePoint(g, raw)
+				s.Commits = append(s.Commits, p)
+			}
+		case 3:
+			index, err = dec.uint32(wt)
+		case 4:
+			priv, err = dec.bytes(wt)
+		default:
+			err = dec.unknown(field)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	if len(s.Commits) == 0 {
+		return errors.New("dkg: distributed key share without commitments")
+	}
+	v, err := decodeScalar(g, priv)
+	if err != nil {
+		return err
+	}
+	s.Share = &share.PriShare{I: index, V: v}
+	*d = s
+	return nil
+}
+
+func checkVersion(version uint32) error {
+	if version != EncodingVersion {
+		return fmt.Errorf("dkg: unsupported encoding version %d", version)
+	}
+	return nil
+}
+
+func checkSessionID(id []byte) error {
+	if len(id) != NonceLength {
+		return errors.New("dkg: invalid session ID length")
+	}
+	return nil
+}
+
+// decodePoint decodes a point of the group, returning an error if buf is not
+// the canonical encoding of a valid point.
+func decodePoint(g kyber.Group, buf []byte) (kyber.Point, error) {
+	if len(buf) != g.PointLen() {
+		return nil, errors.New("dkg: invalid point length")
+	}
+	p := g.Point()
+	if err := p.UnmarshalBinary(buf); err != nil {
+		return nil, fmt.Errorf("dkg: invalid point: %w", err)
+	}
+	if enc, err := p.MarshalBinary(); err != nil || !bytes.Equal(enc, buf) {
+		return nil, errors.New("dkg: non canonical point encoding")
+	}
+	return p, nil
+}
+
+// decodeScalar decodes a scalar of the group, returning an error if buf is not
+// the canonical encoding of a scalar.
+func decodeScalar(g kyber.Group, buf []byte) (kyber.Scalar, error) {
+	if len(buf) != g.ScalarLen() {
+		return nil, errors.New("dkg: invalid scalar length")
+	}
+	s := g.Scalar()
+	if err := s.UnmarshalBinary(buf); err != nil {
+		return nil, fmt.Errorf("dkg: invalid scalar: %w", err)
+	}
+	if enc, err := s.MarshalBinary(); err != nil || !bytes.Equal(enc, buf) {
+		return nil, errors.New("dkg: non canonical scalar encoding")
+	}
+	return s, nil
+}
+
+// protobuf wire types used by the encoding
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// wireEncoder writes protobuf fields in the canonical form: the fields must be
+// written in the order of their numbers and the ones with a default value are
+// omitted, except for the elements of repeated fields.
+type wireEncoder struct {
+	buf []byte
+}
+
+func (e *wireEncoder) tag(field, wireType int) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(field<<3|wireType))
+}
+
+func (e *wireEncoder) uint32(field int, v uint32) {
+	if v == 0 {
+		return
+	}
+	e.tag(field, wireVarint)
+	e.buf = binary.AppendUvarint(e.buf, uint64(v))
+}
+
+func (e *wireEncoder) bytes(field int, b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	e.element(field, b)
+}
+
+// element writes an element of a repeated field of bytes or messages.
+func (e *wireEncoder) element(field int, b []byte) {
+	e.tag(field, wireBytes)
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+// wireDecoder reads the protobuf fields written by a wireEncoder, rejecting
+// any encoding that is not canonical: the fields out of order, repeated or
+// set explicitly to their default value.
+type wireDecoder struct {
+	buf  []byte
+	last int
+}
+
+func (d *wireDecoder) more() bool {
+	return len(d.buf) > 0
+}
+
+// next reads the key of the next field, checking that the fields come in the
+// order of their numbers and that only the given repeated fields appear more
+// than once.
+func (d *wireDecoder) next(repeated ...int) (field, wireType int, err error) {
+	key, err := d.varint()
+	if err != nil {
+		return 0, 0, err
+	}
+	if key>>3 > math.MaxInt32 {
+		return 0, 0, errors.New("dkg: invalid field number")
+	}
+	field, wireType = int(key>>3), int(key&7)
+	if field == 0 {
+		return 0, 0, errors.New("dkg: invalid field number")
+	}
+	if field < d.last {
+		return 0, 0, errors.New("dkg: fields out of order")
+	}
+	if field == d.last {
+		isRepeated := false
+		for _, r := range repeated {
+			isRepeated = isRepeated || r == field
+		}
+		if !isRepeated {
+			return 0, 0, fmt.Errorf("dkg: duplicate field %d", field)
+		}
+	}
+	d.last = field
+	return field, wireType, nil
+}
+
+// version reads the version, which is the first field of the messages.
+func (d *wireDecoder) version() (uint32, error) {
+	if len(d.buf) == 0 || d.buf[0] != 1<<3|wireVarint {
+		return 0, errors.New("dkg: missing encoding version")
+	}
+	_, wireType, err := d.next()
+	if err != nil {
+		return 0, err
+	}
+	return d.uint32(wireType)
+}
+
+func (d *wireDecoder) unknown(field int) error {
+	return fmt.Errorf("dkg: unknown field %d", field)
+}
+
+func (d *wireDecoder) varint() (uint64, error) {
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		return 0, errors.New("dkg: invalid varint")
+	}
+	if len(binary.AppendUvarint(nil, v)) != n {
+		return 0, errors.New("dkg: non minimal varint")
+	}
+	d.buf = d.buf[n:]
+	return v, nil
+}
+
+func (d *wireDecoder) uint32(wireType int) (uint32, error) {
+	if wireType != wireVarint {
+		return 0, errors.New("dkg: invalid wire type")
+	}
+	v, err := d.varint()
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxUint32 {
+		return 0, errors.New("dkg: integer overflow")
+	}
+	if v == 0 {
+		return 0, errors.New("dkg: explicit default value")
+	}
+	return uint32(v), nil
+}
+
+func (d *wireDecoder) bytes(wireType int) ([]byte, error) {
+	b, err := d.element(wireType)
+	if err == nil && len(b) == 0 {
+		return nil, errors.New("dkg: explicit default value")
+	}
+	return b, err
+}
+
+// element reads an element of a repeated field of bytes or messages, which
+// may be empty.
+func (d *wireDecoder) element(wireType int) ([]byte, error) {
+	if wireType != wireBytes {
+		return nil, errors.New("dkg: invalid wire type")
+	}
+	l, err := d.varint()
+	if err != nil {
+		return nil, err
+	}
+	if l > uint64(len(d.buf)) {
+		return nil, errors.New("dkg: truncated field")
+	}
+	b := make([]byte, l)
+	copy(b, d.buf)
+	d.buf = d.buf[l:]
+	return b, nil
+}
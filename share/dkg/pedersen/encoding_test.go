@@ -0,0 +1,306 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/sign/schnorr"
+	"go.dedis.ch/protobuf"
+)
+
+// bundles runs a DKG where the dealer 1 sends an invalid share to the node 2,
+// returning one bundle of each type and a result.
+func bundles(t *testing.T) (*DealBundle, *ResponseBundle, *JustificationBundle, *Result) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	tns := GenerateTestNodes(suite, 4)
+	conf := Config{
+		Suite:     suite,
+		NewNodes:  NodesFromTest(tns),
+		Threshold: 3,
+		Auth:      schnorr.NewScheme(suite),
+	}
+	var deals []*DealBundle
+	var resps []*ResponseBundle
+	var justifs []*JustificationBundle
+	results := RunDKG(t, tns, conf, func(d []*DealBundle) []*DealBundle {
+		d[1].Deals[1].EncryptedShare[0] ^= 1
+		deals = d
+		return d
+	}, func(r []*ResponseBundle) []*ResponseBundle {
+		resps = r
+		return r
+	}, func(j []*JustificationBundle) []*JustificationBundle {
+		justifs = j
+		return j
+	})
+	require.Len(t, resps, 1)
+	require.Len(t, justifs, 1)
+	return deals[0], resps[0], justifs[0], results[0]
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	deal, resp, justif, res := bundles(t)
+
+	buf, err := deal.MarshalBinary()
+	require.NoError(t, err)
+	deal2 := new(DealBundle)
+	require.NoError(t, deal2.Decode(suite, buf))
+	require.Equal(t, mustHash(t, deal), mustHash(t, deal2))
+	require.Equal(t, deal.Signature, deal2.Signature)
+
+	buf, err = resp.MarshalBinary()
+	require.NoError(t, err)
+	resp2 := new(ResponseBundle)
+	require.NoError(t, resp2.UnmarshalBinary(buf))
+	require.Equal(t, resp, resp2)
+
+	buf, err = justif.MarshalBinary()
+	require.NoError(t, err)
+	justif2 := new(JustificationBundle)
+	require.NoError(t, justif2.Decode(suite, buf))
+	require.Equal(t, mustHash(t, justif), mustHash(t, justif2))
+	require.Equal(t, justif.Signature, justif2.Signature)
+
+	buf, err = res.Key.MarshalBinary()
+	require.NoError(t, err)
+	key := new(DistKeyShare)
+	require.NoError(t, key.Decode(suite, buf))
+	require.Equal(t, res.Key.Share.I, key.Share.I)
+	require.True(t, res.Key.Share.V.Equal(key.Share.V))
+	require.Len(t, key.Commits, len(res.Key.Commits))
+	for i := range key.Commits {
+		require.True(t, res.Key.Commits[i].Equal(key.Commits[i]))
+	}
+}
+
+// TestEncodingProtobuf checks that the encoding is the protobuf encoding of
+// the messages of dkg.proto.
+func TestEncodingProtobuf(t *testing.T) {
+	type Deal struct {
+		ShareIndex     uint32
+		EncryptedShare []byte
+	}
+	type DealBundle struct {
+		Version     uint32
+		DealerIndex uint32
+		Deals       []Deal
+		Public      [][]byte
+		SessionID   []byte
+		Signature   []byte
+	}
+	type Response struct {
+		DealerIndex uint32
+		Status      uint32
+	}
+	type ResponseBundle struct {
+		Version    uint32
+		ShareIndex uint32
+		Responses  []Response
+		SessionID  []byte
+		Signature  []byte
+	}
+
+	deal, resp, _, _ := bundles(t)
+	buf, err := deal.MarshalBinary()
+	require.NoError(t, err)
+	var pbDeal DealBundle
+	require.NoError(t, protobuf.Decode(buf, &pbDeal))
+	require.Equal(t, uint32(EncodingVersion), pbDeal.Version)
+	require.Equal(t, deal.DealerIndex, pbDeal.DealerIndex)
+	require.Len(t, pbDeal.Deals, len(deal.Deals))
+	for i, d := range deal.Deals {
+		require.Equal(t, d.ShareIndex, pbDeal.Deals[i].ShareIndex)
+		require.Equal(t, d.EncryptedShare, pbDeal.Deals[i].EncryptedShare)
+	}
+	require.Len(t, pbDeal.Public, len(deal.Public))
+	require.Equal(t, deal.SessionID, pbDeal.SessionID)
+
+	buf, err = resp.MarshalBinary()
+	require.NoError(t, err)
+	var pbResp ResponseBundle
+	require.NoError(t, protobuf.Decode(buf, &pbResp))
+	require.Equal(t, resp.ShareIndex, pbResp.ShareIndex)
+	require.Len(t, pbResp.Responses, len(resp.Responses))
+	require.Equal(t, uint32(Complaint), pbResp.Responses[0].Status)
+}
+
+func TestEncodingInvalid(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	deal, resp, justif, res := bundles(t)
+
+	decodeDeal := func(d *DealBundle) error {
+		buf, err := d.MarshalBinary()
+		require.NoError(t, err)
+		return new(DealBundle).Decode(suite, buf)
+	}
+	require.NoError(t, decodeDeal(deal))
+
+	invalid := *deal
+	invalid.SessionID = invalid.SessionID[1:]
+	require.Error(t, decodeDeal(&invalid))
+
+	invalid = *deal
+	invalid.Deals = append([]Deal{}, deal.Deals...)
+	invalid.Deals[0].EncryptedShare = invalid.Deals[0].EncryptedShare[1:]
+	require.Error(t, decodeDeal(&invalid))
+
+	invalid = *deal
+	invalid.Deals = append(invalid.Deals, deal.Deals[0])
+	require.Error(t, decodeDeal(&invalid))
+
+	invalid = *deal
+	invalid.Public = nil
+	require.Error(t, decodeDeal(&invalid))
+
+	buf, err := deal.MarshalBinary()
+	require.NoError(t, err)
+	// truncated
+	require.Error(t, new(DealBundle).Decode(suite, buf[:len(buf)-1]))
+	// unknown field
+	require.Error(t, new(DealBundle).Decode(suite, append(buf, 7<<3|wireVarint, 1)))
+	// field out of order
+	require.Error(t, new(DealBundle).Decode(suite, append(buf, 2<<3|wireVarint, 1)))
+	// unknown version
+	versioned := append([]byte{}, buf...)
+	versioned[1] = 2
+	require.Error(t, new(DealBundle).Decode(suite, versioned))
+	// non minimal varint
+	require.Error(t, new(DealBundle).Decode(suite, append([]byte{buf[0], 0x81, 0}, buf[2:]...)))
+
+	// invalid point: the y coordinate of edwards25519 is not reduced
+	p := suite.Point().Null()
+	var e wireEncoder
+	e.uint32(1, EncodingVersion)
+	pbuf, err := p.MarshalBinary()
+	require.NoError(t, err)
+	pbuf[0] = 0xee
+	for i := 1; i < 31; i++ {
+		pbuf[i] = 0xff
+	}
+	pbuf[31] = 0x7f
+	e.element(2, pbuf)
+	e.uint32(3, 1)
+	sbuf, err := suite.Scalar().One().MarshalBinary()
+	require.NoError(t, err)
+	e.bytes(4, sbuf)
+	require.Error(t, new(DistKeyShare).Decode(suite, e.buf))
+
+	invalidResp := *resp
+	invalidResp.Responses = []Response{{DealerIndex: 1, Status: 2}}
+	buf, err = invalidResp.MarshalBinary()
+	require.NoError(t, err)
+	require.Error(t, new(ResponseBundle).UnmarshalBinary(buf))
+
+	buf, err = justif.MarshalBinary()
+	require.NoError(t, err)
+	// truncated signature
+	require.Error(t, new(JustificationBundle).Decode(suite, buf[:len(buf)-1]))
+
+	invalidKey := *res.Key
+	invalidKey.Commits = nil
+	buf, err = invalidKey.MarshalBinary()
+	require.NoError(t, err)
+	require.Error(t, new(DistKeyShare).Decode(suite, buf))
+}
+
+// TestEncodingNonCanonical checks that the messages whose fields are set
+// explicitly to their default value, repeated or out of order are rejected.
+func TestEncodingNonCanonical(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	deal, resp, _, _ := bundles(t)
+	encrypted := deal.Deals[0].EncryptedShare
+
+	// encodeDeal encodes the deal bundle with the given deal messages
+	encodeDeal := func(deals ...[]byte) []byte {
+		var e wireEncoder
+		e.uint32(1, EncodingVersion)
+		e.uint32(2, deal.DealerIndex)
+		for _, d := range deals {
+			e.element(3, d)
+		}
+		for _, p := range deal.Public {
+			buf, err := p.MarshalBinary()
+			require.NoError(t, err)
+			e.element(4, buf)
+		}
+		e.bytes(5, deal.SessionID)
+		e.bytes(6, deal.Signature)
+		return e.buf
+	}
+	var valid wireEncoder
+	valid.uint32(1, 3)
+	valid.bytes(2, encrypted)
+	require.NoError(t, new(DealBundle).Decode(suite, encodeDeal(valid.buf)))
+
+	var zeroIndex wireEncoder
+	zeroIndex.tag(1, wireVarint)
+	zeroIndex.buf = append(zeroIndex.buf, 0)
+	zeroIndex.bytes(2, encrypted)
+	require.Error(t, new(DealBundle).Decode(suite, encodeDeal(zeroIndex.buf)))
+
+	var repeated wireEncoder
+	repeated.uint32(1, 3)
+	repeated.uint32(1, 3)
+	repeated.bytes(2, encrypted)
+	require.Error(t, new(DealBundle).Decode(suite, encodeDeal(repeated.buf)))
+
+	var unordered wireEncoder
+	unordered.bytes(2, encrypted)
+	unordered.uint32(1, 3)
+	require.Error(t, new(DealBundle).Decode(suite, encodeDeal(unordered.buf)))
+
+	var fieldZero wireEncoder
+	fieldZero.uint32(0, 3)
+	fieldZero.uint32(1, 3)
+	fieldZero.bytes(2, encrypted)
+	require.Error(t, new(DealBundle).Decode(suite, encodeDeal(fieldZero.buf)))
+
+	// empty signature of the bundle
+	buf := encodeDeal(valid.buf)
+	buf = buf[:len(buf)-len(deal.Signature)-2]
+	require.NoError(t, new(DealBundle).Decode(suite, buf))
+	require.Error(t, new(DealBundle).Decode(suite, append(buf, 6<<3|wireBytes, 0)))
+
+	// encodeResponse encodes the response bundle with the given response
+	// message
+	encodeResponse := func(r []byte) []byte {
+		var e wireEncoder
+		e.uint32(1, EncodingVersion)
+		e.uint32(2, resp.ShareIndex)
+		e.element(3, r)
+		e.bytes(4, resp.SessionID)
+		e.bytes(5, resp.Signature)
+		return e.buf
+	}
+	var success wireEncoder
+	success.uint32(1, 2)
+	require.NoError(t, new(ResponseBundle).UnmarshalBinary(encodeResponse(success.buf)))
+
+	var explicitSuccess wireEncoder
+	explicitSuccess.uint32(1, 2)
+	explicitSuccess.tag(2, wireVarint)
+	explicitSuccess.buf = append(explicitSuccess.buf, byte(Success))
+	require.Error(t, new(ResponseBundle).UnmarshalBinary(encodeResponse(explicitSuccess.buf)))
+
+	var repeatedStatus wireEncoder
+	repeatedStatus.uint32(1, 2)
+	repeatedStatus.uint32(2, uint32(Complaint))
+	repeatedStatus.uint32(2, uint32(Complaint))
+	require.Error(t, new(ResponseBundle).UnmarshalBinary(encodeResponse(repeatedStatus.buf)))
+
+	var unorderedResp wireEncoder
+	unorderedResp.uint32(2, uint32(Complaint))
+	unorderedResp.uint32(1, 2)
+	require.Error(t, new(ResponseBundle).UnmarshalBinary(encodeResponse(unorderedResp.buf)))
+
+	// explicit zero share index of the bundle
+	var e wireEncoder
+	e.uint32(1, EncodingVersion)
+	e.tag(2, wireVarint)
+	e.buf = append(e.buf, 0)
+	e.element(3, success.buf)
+	e.bytes(4, resp.SessionID)
+	require.Error(t, new(ResponseBundle).UnmarshalBinary(e.buf))
+}
@@ -2,6 +2,7 @@ package dkg
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -76,6 +77,9 @@ type Protocol struct {
 	canIssue  bool
 	res       chan OptionResult
 	skipVerif bool
+	store     StateStore
+	// packets processed so far
+	transcript Transcript
 }
 
 func NewProtocol(c *Config, b Board, phaser Phaser, skipVerification bool) (*Protocol, error) {
@@ -83,6 +87,35 @@ func NewProtocol(c *Config, b Board, phaser Phaser, skipVerification bool) (*Pro
 	if err != nil {
 		return nil, err
 	}
+	return newProtocol(dkg, b, phaser, skipVerification, nil), nil
+}
+
+// NewResumableProtocol returns a Protocol that saves the state of the DKG in
+// the store before sending each of its packets. If the store already holds a
+// state for the nonce of the config, the protocol resumes from it: the packets
+// already sent are pushed again on the board and the phases already done are
+// skipped. Packets received before the restart are lost, so the board must
+// deliver them again: the node can't verify the justifications of a dealer
+// whose deal bundle it missed. The transcript of a resumed protocol only holds
+// the packets received after the restart.
+func NewResumableProtocol(c *Config, b Board, phaser Phaser, skipVerification bool,
+	store StateStore) (*Protocol, error) {
+	var dkg *DistKeyGenerator
+	state, err := store.Load(c.Nonce)
+	switch {
+	case errors.Is(err, ErrNoState):
+		dkg, err = NewDistKeyHandler(c)
+	case err == nil:
+		dkg, err = RestoreDistKeyGenerator(c, state)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newProtocol(dkg, b, phaser, skipVerification, store), nil
+}
+
+func newProtocol(dkg *DistKeyGenerator, b Board, phaser Phaser, skipVerification bool,
+	store StateStore) *Protocol {
 	p := &Protocol{
 		board:     b,
 		phaser:    phaser,
@@ -90,9 +123,11 @@ func NewProtocol(c *Config, b Board, phaser Phaser, skipVerification bool) (*Pro
 		canIssue:  dkg.canIssue,
 		res:       make(chan OptionResult, 1),
 		skipVerif: skipVerification,
+		store:     store,
 	}
+	p.transcript.SessionID = dkg.c.Nonce
 	go p.Start()
-	return p, nil
+	return p
 }
 
 func (p *Protocol) Info(keyvals ...interface{}) {
@@ -104,6 +139,9 @@ func (p *Protocol) Error(keyvals ...interface{}) {
 }
 
 func (p *Protocol) Start() {
+	if p.resume() {
+		return
+	}
 	var fastSync = p.dkg.c.FastSync
 	if fastSync {
 		p.startFast()
@@ -122,15 +160,15 @@ func (p *Protocol) Start() {
 					return
 				}
 			case ResponsePhase:
-				if !p.sendResponses(deals.ToDeals()) {
+				if !p.sendResponses(deals) {
 					return
 				}
 			case JustifPhase:
-				if !p.sendJustifications(resps.ToResponses()) {
+				if !p.sendJustifications(resps) {
 					return
 				}
 			case FinishPhase:
-				p.finish(justifs.ToJustifications())
+				p.finish(justifs)
 				return
 			}
 		case newDeal := <-p.board.IncomingDeal():
@@ -170,14 +208,14 @@ func (p *Protocol) startFast() {
 		if !p.canIssue && phase() != InitPhase {
 			return true
 		}
-		return p.sendResponses(deals.ToDeals())
+		return p.sendResponses(deals)
 	}
 
 	toJust := func() bool {
 		if phase() != ResponsePhase {
 			return true
 		}
-		return p.sendJustifications(resps.ToResponses())
+		return p.sendJustifications(resps)
 	}
 	// always return false when we are in the finish phase - we quit the
 	// protocol.
@@ -185,7 +223,7 @@ func (p *Protocol) startFast() {
 		if phase() != JustifPhase {
 			return true
 		}
-		p.finish(justifs.ToJustifications())
+		p.finish(justifs)
 		return false
 	}
 	for {
@@ -279,11 +317,52 @@ func (p *Protocol) verify(packet Packet) error {
 	return VerifyPacketSignature(p.dkg.c, packet)
 }
 
+// resume pushes again the packets sent before the state of the DKG was
+// restored, and returns true if the DKG was already finished.
+func (p *Protocol) resume() bool {
+	if p.dkg.dealBundle != nil {
+		p.board.PushDeals(p.dkg.dealBundle)
+	}
+	if p.dkg.responseBundle != nil {
+		p.board.PushResponses(p.dkg.responseBundle)
+	}
+	if p.dkg.justifBundle != nil {
+		p.board.PushJustifications(p.dkg.justifBundle)
+	}
+	if p.dkg.state != FinishPhase {
+		return false
+	}
+	res := OptionResult{Result: p.dkg.result}
+	if p.dkg.canReceive && p.dkg.result == nil {
+		res.Error = errors.New("dkg: protocol already finished without result")
+	}
+	p.res <- res
+	return true
+}
+
+// checkpoint saves the state of the DKG in the store, if any. It must be
+// called before sending a packet: a node restarting from an older state would
+// otherwise produce different deals than the ones already sent.
+func (p *Protocol) checkpoint() error {
+	if p.store == nil {
+		return nil
+	}
+	state, err := p.dkg.MarshalState()
+	if err != nil {
+		return err
+	}
+	return p.store.Save(p.dkg.c.Nonce, state)
+}
+
 func (p *Protocol) sendDeals() bool {
-	if !p.canIssue {
+	if !p.canIssue || p.dkg.state != InitPhase {
+		// deals already sent before a restart are pushed again by resume
 		return true
 	}
 	bundle, err := p.dkg.Deals()
+	if err == nil {
+		err = p.checkpoint()
+	}
 	if err != nil {
 		p.res <- OptionResult{
 			Error: err,
@@ -297,11 +376,22 @@ func (p *Protocol) sendDeals() bool {
 	return true
 }
 
-func (p *Protocol) sendResponses(deals []*DealBundle) bool {
+func (p *Protocol) sendResponses(set *set) bool {
+	if p.dkg.state >= ResponsePhase {
+		return true
+	}
+	deals := set.ToDeals()
+	for _, d := range set.All() {
+		p.transcript.Deals = append(p.transcript.Deals, d.(*DealBundle))
+	}
 	bundle, err := p.dkg.ProcessDeals(deals)
+	if err == nil {
+		err = p.checkpoint()
+	}
 	if err != nil {
 		p.res <- OptionResult{
-			Error: err,
+			Error:      err,
+			Transcript: p.signTranscript(),
 		}
 		// we signal the end since we can't go on
 		return false
@@ -313,12 +403,23 @@ func (p *Protocol) sendResponses(deals []*DealBundle) bool {
 	return true
 }
 
-func (p *Protocol) sendJustifications(resps []*ResponseBundle) bool {
+func (p *Protocol) sendJustifications(set *set) bool {
+	if p.dkg.state >= JustifPhase {
+		return true
+	}
+	resps := set.ToResponses()
+	for _, r := range set.All() {
+		p.transcript.Responses = append(p.transcript.Responses, r.(*ResponseBundle))
+	}
 	res, just, err := p.dkg.ProcessResponses(resps)
+	if err == nil {
+		err = p.checkpoint()
+	}
 	if err != nil || res != nil {
 		p.res <- OptionResult{
-			Error:  err,
-			Result: res,
+			Error:      err,
+			Result:     res,
+			Transcript: p.signTranscript(),
 		}
 		return false
 	}
@@ -331,14 +432,34 @@ func (p *Protocol) sendJustifications(resps []*ResponseBundle) bool {
 	return true
 }
 
-func (p *Protocol) finish(justifs []*JustificationBundle) {
-	res, err := p.dkg.ProcessJustifications(justifs)
+func (p *Protocol) finish(set *set) {
+	for _, j := range set.All() {
+		p.transcript.Justifications = append(p.transcript.Justifications, j.(*JustificationBundle))
+	}
+	res, err := p.dkg.ProcessJustifications(set.ToJustifications())
+	if err == nil {
+		if cerr := p.checkpoint(); cerr != nil {
+			p.Error("finish", "can't save the final state:", cerr)
+		}
+	}
 	p.res <- OptionResult{
-		Error:  err,
-		Result: res,
+		Error:      err,
+		Result:     res,
+		Transcript: p.signTranscript(),
 	}
 }
 
+// signTranscript returns the transcript of the packets processed so far,
+// signed with the longterm key of the node.
+func (p *Protocol) signTranscript() *Transcript {
+	t := p.transcript
+	if err := t.sign(p.dkg.c); err != nil {
+		p.Error("transcript", "can't sign the transcript:", err)
+		return nil
+	}
+	return &t
+}
+
 func (p *Protocol) WaitEnd() <-chan OptionResult {
 	return p.res
 }
@@ -346,11 +467,16 @@ func (p *Protocol) WaitEnd() <-chan OptionResult {
 type OptionResult struct {
 	Result *Result
 	Error  error
+	// Transcript of the packets processed by the node, which can be checked
+	// with Audit. It is nil for a protocol resumed after it finished.
+	Transcript *Transcript
 }
 
 type set struct {
 	vals map[Index]Packet
 	bad  []Index
+	// the first two conflicting packets of each bad index
+	conflicts []Packet
 }
 
 func newSet() *set {
@@ -373,6 +499,7 @@ func (s *set) Push(p Packet) {
 			// bad behavior - we evict
 			delete(s.vals, idx)
 			s.bad = append(s.bad, idx)
+			s.conflicts = append(s.conflicts, prev, p)
 		}
 		// same packet just rebroadcasted - all good
 		return
@@ -413,6 +540,16 @@ func (s *set) ToJustifications() []*JustificationBundle {
 	return justs
 }
 
+// All returns the packets of the set along with the conflicting packets of the
+// evicted indexes.
+func (s *set) All() []Packet {
+	all := make([]Packet, 0, len(s.vals)+len(s.conflicts))
+	for _, p := range s.vals {
+		all = append(all, p)
+	}
+	return append(all, s.conflicts...)
+}
+
 func (s *set) Len() int {
 	return len(s.vals)
 }
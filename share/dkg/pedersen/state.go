@@ -0,0 +1,285 @@
+package dkg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/protobuf"
+)
+
+// stateVersion is the version of the encoding of the state of a
+// DistKeyGenerator.
+const stateVersion = 1
+
+// ErrNoState is returned by a StateStore that holds no state for a nonce.
+var ErrNoState = errors.New("dkg: no state stored for this nonce")
+
+// StateStore persists the state of a DistKeyGenerator, indexed by the nonce of
+// the protocol run, so that a node can resume the protocol after a restart.
+// The state contains the secret polynomial and the shares of the node, so it
+// must be stored as securely as the longterm key.
+type StateStore interface {
+	// Save stores the state of the run of the given nonce, replacing the
+	// previous one.
+	Save(nonce, state []byte) error
+	// Load returns the last state saved for the given nonce, or ErrNoState.
+	Load(nonce []byte) ([]byte, error)
+}
+
+// encodedState is the encoding of the state of a DistKeyGenerator. The static
+// parameters are in the Config given back at restoration.
+type encodedState struct {
+	Version uint32
+	Nonce   []byte
+	Phase   int32
+	// coefficients of the secret polynomial of the dealer
+	Coefficients   []kyber.Scalar
+	Statuses       []encodedStatus
+	ValidShares    []*share.PriShare
+	Publics        []encodedPublic
+	Evicted        []uint32
+	EvictedHolders []uint32
+	// bundles sent by the node, with their canonical encoding
+	Deals          []byte
+	Responses      []byte
+	Justifications []byte
+	Result         *encodedResult
+}
+
+type encodedResult struct {
+	QUAL []Node
+	Key  []byte
+}
+
+type encodedStatus struct {
+	Dealer uint32
+	Holder uint32
+	Status int32
+}
+
+type encodedPublic struct {
+	Dealer  uint32
+	Commits []kyber.Point
+}
+
+// MarshalState returns the encoding of the current state of the generator,
+// from which RestoreDistKeyGenerator can recreate it. The state contains
+// secret information.
+func (d *DistKeyGenerator) MarshalState() ([]byte, error) {
+	st := &encodedState{
+		Version:        stateVersion,
+		Nonce:          d.c.Nonce,
+		Phase:          int32(d.state),
+		Coefficients:   d.dpriv.Coefficients(),
+		Evicted:        d.evicted,
+		EvictedHolders: d.evictedHolders,
+	}
+	var err error
+	if d.dealBundle != nil {
+		if st.Deals, err = d.dealBundle.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if d.responseBundle != nil {
+		if st.Responses, err = d.responseBundle.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if d.justifBundle != nil {
+		if st.Justifications, err = d.justifBundle.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if d.result != nil {
+		st.Result = &encodedResult{QUAL: d.result.QUAL}
+		if st.Result.Key, err = d.result.Key.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	dealers := make([]uint32, 0, len(*d.statuses))
+	for dealer := range *d.statuses {
+		dealers = append(dealers, dealer)
+	}
+	for _, dealer := range sortIndexes(dealers) {
+		row := (*d.statuses)[dealer]
+		holders := make([]uint32, 0, len(row))
+		for holder := range row {
+			holders = append(holders, holder)
+		}
+		for _, holder := range sortIndexes(holders) {
+			st.Statuses = append(st.Statuses, encodedStatus{
+				Dealer: dealer,
+				Holder: holder,
+				Status: int32(row[holder]),
+			})
+		}
+	}
+	dealers = dealers[:0]
+	for dealer := range d.validShares {
+		dealers = append(dealers, dealer)
+	}
+	for _, dealer := range sortIndexes(dealers) {
+		st.ValidShares = append(st.ValidShares, &share.PriShare{I: dealer, V: d.validShares[dealer]})
+	}
+	dealers = dealers[:0]
+	for dealer := range d.allPublics {
+		dealers = append(dealers, dealer)
+	}
+	for _, dealer := range sortIndexes(dealers) {
+		_, commits := d.allPublics[dealer].Info()
+		st.Publics = append(st.Publics, encodedPublic{Dealer: dealer, Commits: commits})
+	}
+	return protobuf.Encode(st)
+}
+
+// RestoreDistKeyGenerator recreates a DistKeyGenerator from a state returned
+// by MarshalState. The config must be the one the generator was created with,
+// including the same Nonce.
+func RestoreDistKeyGenerator(c *Config, state []byte) (*DistKeyGenerator, error) {
+	st := &encodedState{}
+	constructors := make(protobuf.Constructors)
+	var point kyber.Point
+	var secret kyber.Scalar
+	constructors[reflect.TypeOf(&point).Elem()] = func() interface{} { return c.Suite.Point() }
+	constructors[reflect.TypeOf(&secret).Elem()] = func() interface{} { return c.Suite.Scalar() }
+	if err := protobuf.DecodeWithConstructors(state, st, constructors); err != nil {
+		return nil, fmt.Errorf("dkg: invalid state: %w", err)
+	}
+	if st.Version != stateVersion {
+		return nil, fmt.Errorf("dkg: unknown state version %d", st.Version)
+	}
+	if !bytes.Equal(st.Nonce, c.Nonce) {
+		return nil, errors.New("dkg: state is from a different nonce")
+	}
+
+	d, err := NewDistKeyHandler(c)
+	if err != nil {
+		return nil, err
+	}
+	if st.Phase < int32(InitPhase) || st.Phase > int32(FinishPhase) {
+		return nil, errors.New("dkg: invalid phase in state")
+	}
+	d.state = Phase(st.Phase)
+
+	if d.canIssue {
+		if len(st.Coefficients) != len(d.dpriv.Coefficients()) {
+			return nil, errors.New("dkg: invalid secret polynomial in state")
+		}
+		d.dpriv = share.CoefficientsToPriPoly(d.suite, st.Coefficients)
+		d.dpub = d.dpriv.Commit(d.suite.Point().Base())
+	}
+	for _, s := range st.Statuses {
+		row, ok := (*d.statuses)[s.Dealer]
+		if !ok {
+			return nil, errors.New("dkg: invalid dealer in state")
+		}
+		if _, ok := row[s.Holder]; !ok {
+			return nil, errors.New("dkg: invalid share holder in state")
+		}
+		row[s.Holder] = Status(s.Status)
+	}
+	for _, s := range st.ValidShares {
+		d.validShares[s.I] = s.V
+	}
+	for _, p := range st.Publics {
+		d.allPublics[p.Dealer] = share.NewPubPoly(d.suite, d.suite.Point().Base(), p.Commits)
+	}
+	d.evicted = st.Evicted
+	d.evictedHolders = st.EvictedHolders
+	if len(st.Deals) > 0 {
+		d.dealBundle = new(DealBundle)
+		if err := d.dealBundle.Decode(d.suite, st.Deals); err != nil {
+			return nil, err
+		}
+	}
+	if len(st.Responses) > 0 {
+		d.responseBundle = new(ResponseBundle)
+		if err := d.responseBundle.UnmarshalBinary(st.Responses); err != nil {
+			return nil, err
+		}
+	}
+	if len(st.Justifications) > 0 {
+		d.justifBundle = new(JustificationBundle)
+		if err := d.justifBundle.Decode(d.suite, st.Justifications); err != nil {
+			return nil, err
+		}
+	}
+	if st.Result != nil {
+		d.result = &Result{QUAL: st.Result.QUAL, Key: new(DistKeyShare)}
+		if err := d.result.Key.Decode(d.suite, st.Result.Key); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// sortIndexes sorts the indexes in place so that the encoding of the state is
+// deterministic, and returns them.
+func sortIndexes(indexes []uint32) []uint32 {
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes
+}
+
+// FileStateStore is a StateStore that keeps each state in a file of its
+// directory, named after the nonce. States are written atomically, so a crash
+// while saving leaves the previous state intact.
+type FileStateStore struct {
+	dir string
+	sync.Mutex
+}
+
+// NewFileStateStore returns a StateStore writing in the given directory, which
+// is created if needed with permissions restricted to the current user.
+func NewFileStateStore(dir string) (*FileStateStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStateStore{dir: dir}, nil
+}
+
+func (f *FileStateStore) path(nonce []byte) string {
+	return filepath.Join(f.dir, hex.EncodeToString(nonce)+".dkg")
+}
+
+// Save implements the StateStore interface.
+func (f *FileStateStore) Save(nonce, state []byte) error {
+	f.Lock()
+	defer f.Unlock()
+	tmp, err := os.CreateTemp(f.dir, "state-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(state); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(nonce))
+}
+
+// Load implements the StateStore interface.
+func (f *FileStateStore) Load(nonce []byte) ([]byte, error) {
+	f.Lock()
+	defer f.Unlock()
+	state, err := os.ReadFile(f.path(nonce))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoState
+	}
+	return state, err
+}
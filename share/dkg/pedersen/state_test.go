@@ -0,0 +1,210 @@
+package dkg
+
+import (
+	"testing"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/sign/schnorr"
+)
+
+// restore replaces the generator of each node by the one restored from its
+// state, as if the node restarted.
+func restore(t *testing.T, tns []*TestNode) {
+	for _, n := range tns {
+		state, err := n.dkg.MarshalState()
+		require.NoError(t, err)
+		c := *n.dkg.c
+		d, err := RestoreDistKeyGenerator(&c, state)
+		require.NoError(t, err)
+		n.dkg = d
+	}
+}
+
+func TestDKGRestoreState(t *testing.T) {
+	n := 5
+	thr := 3
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	tns := GenerateTestNodes(suite, n)
+	conf := Config{
+		Suite:     suite,
+		NewNodes:  NodesFromTest(tns),
+		Threshold: thr,
+		Auth:      schnorr.NewScheme(suite),
+	}
+	SetupNodes(tns, &conf)
+	restore(t, tns)
+
+	var deals []*DealBundle
+	for _, node := range tns {
+		d, err := node.dkg.Deals()
+		require.NoError(t, err)
+		deals = append(deals, d)
+	}
+	restore(t, tns)
+	for i, node := range tns {
+		// a restored node can't deal again, but has kept its deals
+		_, err := node.dkg.Deals()
+		require.Error(t, err)
+		require.Equal(t, mustHash(t, deals[i]), mustHash(t, node.dkg.dealBundle))
+	}
+	// the dealer 1 sends an invalid share to the node 2
+	deals[1].Deals[1].EncryptedShare = []byte("invalid share")
+
+	var resps []*ResponseBundle
+	for _, node := range tns {
+		resp, err := node.dkg.ProcessDeals(deals)
+		require.NoError(t, err)
+		if resp != nil {
+			resps = append(resps, resp)
+		}
+	}
+	require.Len(t, resps, 1)
+	restore(t, tns)
+
+	var justifs []*JustificationBundle
+	for _, node := range tns {
+		res, just, err := node.dkg.ProcessResponses(resps)
+		require.NoError(t, err)
+		require.Nil(t, res)
+		if just != nil {
+			justifs = append(justifs, just)
+		}
+	}
+	require.Len(t, justifs, 1)
+	restore(t, tns)
+
+	var results []*Result
+	for _, node := range tns {
+		res, err := node.dkg.ProcessJustifications(justifs)
+		require.NoError(t, err)
+		results = append(results, res)
+	}
+	testResults(t, suite, thr, n, results)
+
+	// the result is part of the state
+	restore(t, tns)
+	for i, node := range tns {
+		require.True(t, results[i].PublicEqual(node.dkg.result))
+		require.True(t, results[i].Key.Share.V.Equal(node.dkg.result.Key.Share.V))
+	}
+}
+
+func TestDKGRestoreStateInvalid(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	tns := GenerateTestNodes(suite, 3)
+	conf := Config{
+		Suite:     suite,
+		NewNodes:  NodesFromTest(tns),
+		Threshold: 2,
+		Auth:      schnorr.NewScheme(suite),
+	}
+	SetupNodes(tns, &conf)
+	state, err := tns[0].dkg.MarshalState()
+	require.NoError(t, err)
+
+	c := *tns[0].dkg.c
+	c.Nonce = GetNonce()
+	_, err = RestoreDistKeyGenerator(&c, state)
+	require.Error(t, err)
+
+	c = *tns[0].dkg.c
+	_, err = RestoreDistKeyGenerator(&c, state[:len(state)/2])
+	require.Error(t, err)
+}
+
+func TestFileStateStore(t *testing.T) {
+	store, err := NewFileStateStore(t.TempDir())
+	require.NoError(t, err)
+	nonce := GetNonce()
+	_, err = store.Load(nonce)
+	require.ErrorIs(t, err, ErrNoState)
+
+	require.NoError(t, store.Save(nonce, []byte("first")))
+	require.NoError(t, store.Save(nonce, []byte("second")))
+	state, err := store.Load(nonce)
+	require.NoError(t, err)
+	require.Equal(t, []byte("second"), state)
+}
+
+func TestProtoResume(t *testing.T) {
+	n := 5
+	thr := 3
+	period := 1 * time.Second
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	tns := GenerateTestNodes(suite, n)
+	network := NewTestNetwork(n)
+	dkgConf := Config{
+		Suite:     suite,
+		NewNodes:  NodesFromTest(tns),
+		Threshold: thr,
+		Auth:      schnorr.NewScheme(suite),
+	}
+	SetupNodes(tns, &dkgConf)
+
+	stores := make([]StateStore, n)
+	start := func(n *TestNode) {
+		clk := clock.NewFakeClock()
+		n.clock = clk
+		n.phaser = NewTimePhaserFunc(func(Phase) {
+			clk.Sleep(period)
+		})
+		c := *n.dkg.c
+		proto, err := NewResumableProtocol(&c, n.board, n.phaser, false, stores[n.Index])
+		require.NoError(t, err)
+		n.proto = proto
+		go n.phaser.Start()
+	}
+	for _, node := range tns {
+		var err error
+		stores[node.Index], err = NewFileStateStore(t.TempDir())
+		require.NoError(t, err)
+		node.board = network.BoardFor(node.Index)
+		start(node)
+	}
+	// all nodes send their deals
+	time.Sleep(100 * time.Millisecond)
+	sent := tns[0].proto.dkg.dealBundle
+	require.NotNil(t, sent)
+
+	// the node 0 restarts and its board delivers again the deals
+	restarted := NewTestBoard(tns[0].Index, n, network)
+	network.boards[0] = restarted
+	tns[0].board = restarted
+	for _, node := range tns[1:] {
+		restarted.newDeals <- *node.proto.dkg.dealBundle
+	}
+	start(tns[0])
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, mustHash(t, sent), mustHash(t, tns[0].proto.dkg.dealBundle))
+
+	var resCh = make(chan OptionResult, n)
+	for _, node := range tns {
+		go func(n *TestNode) { resCh <- <-n.proto.WaitEnd() }(node)
+	}
+	for i := 0; i < 2; i++ {
+		moveTime(tns, period)
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	var results []*Result
+	for optRes := range resCh {
+		require.NoError(t, optRes.Error)
+		results = append(results, optRes.Result)
+		if len(results) == n {
+			break
+		}
+	}
+	testResults(t, suite, thr, n, results)
+	for _, res := range results {
+		require.Len(t, res.QUAL, n)
+	}
+
+	// a node restarting once the protocol is over gets back its result
+	start(tns[1])
+	optRes := <-tns[1].proto.WaitEnd()
+	require.NoError(t, optRes.Error)
+	require.True(t, results[0].PublicEqual(optRes.Result))
+}
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/msm"
 )
 
 // Some error definitions
@@ -337,11 +338,13 @@ func (p *PubPoly) Commit() kyber.Point {
 // Eval computes the public share v = p(i).
 func (p *PubPoly) Eval(i uint32) *PubShare {
 	xi := p.g.Scalar().SetInt64(1 + int64(i)) // x-coordinate of this share
-	v := p.g.Point().Null()
-	for j := p.Threshold() - 1; j >= 0; j-- {
-		v.Mul(xi, v)
-		v.Add(v, p.commits[j])
+	powers := make([]kyber.Scalar, p.Threshold())
+	xj := p.g.Scalar().One()
+	for j := range powers {
+		powers[j] = xj.Clone()
+		xj.Mul(xj, xi)
 	}
+	v := msm.MultiScalarMul(p.g, powers, p.commits)
 	return &PubShare{i, v}
 }
 
@@ -450,14 +453,13 @@ func RecoverCommit(g kyber.Group, shares []*PubShare, t, n int) (kyber.Point, er
 		return nil, errors.New("share: not enough good public shares to reconstruct secret commitment")
 	}
 
-	num := g.Scalar()
 	den := g.Scalar()
 	tmp := g.Scalar()
-	Acc := g.Point().Null()
-	Tmp := g.Point()
+	coeffs := make([]kyber.Scalar, 0, len(x))
+	points := make([]kyber.Point, 0, len(x))
 
 	for i, xi := range x {
-		num.One()
+		num := g.Scalar().One()
 		den.One()
 		for j, xj := range x {
 			if i == j {
@@ -466,11 +468,11 @@ func RecoverCommit(g kyber.Group, shares []*PubShare, t, n int) (kyber.Point, er
 			num.Mul(num, xj)
 			den.Mul(den, tmp.Sub(xj, xi))
 		}
-		Tmp.Mul(num.Div(num, den), y[i])
-		Acc.Add(Acc, Tmp)
+		coeffs = append(coeffs, num.Div(num, den))
+		points = append(points, y[i])
 	}
 
-	return Acc, nil
+	return msm.MultiScalarMul(g, coeffs, points), nil
 }
 
 // RecoverPubPoly reconstructs the full public polynomial from a set of public
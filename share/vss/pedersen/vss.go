@@ -13,7 +13,9 @@ import (
 
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/sign"
 	"go.dedis.ch/kyber/v4/sign/schnorr"
+	"go.dedis.ch/kyber/v4/util/precomp"
 	"go.dedis.ch/protobuf"
 )
 
@@ -516,6 +518,12 @@ type Aggregator struct {
 	dealer    kyber.Point
 	verifiers []kyber.Point
 	commits   []kyber.Point
+	// schnorr verifies the signatures of the responses, reusing a table of
+	// multiples of the base point across all of them
+	schnorr sign.Scheme
+	// commitTables are the tables of multiples of the commitments of the
+	// accepted deal, which verify the shares of that deal
+	commitTables []kyber.PrecomputedPoint
 
 	responses map[uint32]*Response
 	sid       []byte
@@ -538,6 +546,7 @@ func newAggregator(
 		dealer:    dealer,
 		verifiers: verifiers,
 		commits:   commitments,
+		schnorr:   schnorr.NewScheme(suite),
 		t:         t,
 		sid:       sid,
 		responses: make(map[uint32]*Response),
@@ -551,6 +560,7 @@ func NewEmptyAggregator(suite Suite, verifiers []kyber.Point) *Aggregator {
 	return &Aggregator{
 		suite:     suite,
 		verifiers: verifiers,
+		schnorr:   schnorr.NewScheme(suite),
 		responses: make(map[uint32]*Response),
 	}
 }
@@ -567,6 +577,7 @@ func (a *Aggregator) VerifyDeal(d *Deal, inclusion bool) error {
 	}
 	if a.deal == nil {
 		a.commits = d.Commitments
+		a.commitTables = precomputeCommits(a.suite, d.Commitments)
 		a.sid = d.SessionID
 		a.deal = d
 		a.t = int(d.T)
@@ -591,15 +602,50 @@ func (a *Aggregator) VerifyDeal(d *Deal, inclusion bool) error {
 	// compute fi * G
 	fig := a.suite.Point().Base().Mul(fi.V, nil)
 
-	commitPoly := share.NewPubPoly(a.suite, nil, d.Commitments)
-
-	pubShare := commitPoly.Eval(fi.I)
-	if !fig.Equal(pubShare.V) {
+	if !fig.Equal(a.evalCommits(d.Commitments, fi.I)) {
 		return errors.New("vss: share does not verify against commitments in Deal")
 	}
 	return nil
 }
 
+// precomputeCommits returns the tables of multiples of the commitments. As
+// the commitments and the indexes they are evaluated at are public, the
+// tables may run in variable time.
+func precomputeCommits(g kyber.Group, commits []kyber.Point) []kyber.PrecomputedPoint {
+	tables := make([]kyber.PrecomputedPoint, len(commits))
+	for j, c := range commits {
+		tables[j] = precomp.Precompute(g, c)
+		if vt, ok := tables[j].(kyber.AllowsVarTime); ok {
+			vt.AllowVarTime(true)
+		}
+	}
+	return tables
+}
+
+// evalCommits returns the public share of index i of the polynomial committed
+// to by commits, with the tables of the accepted deal when the commitments
+// are the ones of that deal.
+func (a *Aggregator) evalCommits(commits []kyber.Point, i uint32) kyber.Point {
+	if len(commits) != len(a.commitTables) {
+		return share.NewPubPoly(a.suite, nil, commits).Eval(i).V
+	}
+	for j, c := range commits {
+		if !c.Equal(a.commitTables[j].Point()) {
+			return share.NewPubPoly(a.suite, nil, commits).Eval(i).V
+		}
+	}
+
+	// sum_j x^j * commits[j], at x = i + 1 like share.PubPoly.Eval
+	x := a.suite.Scalar().SetInt64(1 + int64(i))
+	xj := a.suite.Scalar().One()
+	v := a.suite.Point().Null()
+	for _, table := range a.commitTables {
+		v.Add(v, table.Mul(xj))
+		xj.Mul(xj, x)
+	}
+	return v
+}
+
 // SetThreshold is used to specify the expected threshold *before* the verifier
 // receives anything. Sometimes, a verifier knows the treshold in advance and
 // should make sure the one it receives from the dealer is consistent. If this
@@ -626,7 +672,7 @@ func (a *Aggregator) verifyResponse(r *Response) error {
 		return errors.New("vss: index out of bounds in response")
 	}
 
-	if err := schnorr.Verify(a.suite, pub, r.Hash(a.suite), r.Signature); err != nil {
+	if err := a.schnorr.Verify(pub, r.Hash(a.suite), r.Signature); err != nil {
 		return err
 	}
 
@@ -499,6 +499,13 @@ func TestVSSAggregatorVerifyDeal(t *testing.T) {
 	err := aggr.VerifyDeal(deal, true)
 	assert.NoError(t, err)
 	assert.NotNil(t, aggr.deal)
+	// the shares of the deal are verified with the tables of its commitments
+	require.Len(t, aggr.commitTables, len(deal.Commitments))
+	for i := range deals {
+		require.True(t, aggr.evalCommits(deal.Commitments, uint32(i)).Equal(
+			suite.Point().Mul(deals[i].SecShare.V, nil)))
+	}
+	assert.NoError(t, aggr.VerifyDeal(deals[1], false))
 
 	// already received deal
 	err = aggr.VerifyDeal(deal, true)
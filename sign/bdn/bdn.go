@@ -19,6 +19,7 @@ import (
 	"go.dedis.ch/kyber/v4/pairing"
 	"go.dedis.ch/kyber/v4/sign"
 	"go.dedis.ch/kyber/v4/sign/bls"
+	"go.dedis.ch/kyber/v4/util/msm"
 	"golang.org/x/crypto/blake2s"
 )
 
@@ -69,6 +70,7 @@ func hashPointToR(pubs []kyber.Point) ([]kyber.Scalar, error) {
 
 type Scheme struct {
 	blsScheme sign.AggregatableScheme
+	batch     *bls.BatchVerifier
 	sigGroup  kyber.Group
 	keyGroup  kyber.Group
 	pairing   func(signature, public, hashedPoint kyber.Point) bool
@@ -84,6 +86,7 @@ func NewSchemeOnG1(suite pairing.Suite) *Scheme {
 	}
 	return &Scheme{
 		blsScheme: bls.NewSchemeOnG1(suite),
+		batch:     bls.NewBatchVerifierOnG1(suite),
 		sigGroup:  sigGroup,
 		keyGroup:  keyGroup,
 		pairing:   pairing,
@@ -100,6 +103,7 @@ func NewSchemeOnG2(suite pairing.Suite) *Scheme {
 	}
 	return &Scheme{
 		blsScheme: bls.NewSchemeOnG2(suite),
+		batch:     bls.NewBatchVerifierOnG2(suite),
 		sigGroup:  sigGroup,
 		keyGroup:  keyGroup,
 		pairing:   pairing,
@@ -126,6 +130,13 @@ func (scheme *Scheme) Verify(x kyber.Point, msg, sig []byte) error {
 	return scheme.blsScheme.Verify(x, msg, sig)
 }
 
+// VerifyBatch checks many independent signatures at once, see
+// bls.BatchVerifier, and returns the indexes of the invalid ones, or nil if
+// they are all valid.
+func (scheme *Scheme) VerifyBatch(entries []bls.BatchEntry) ([]int, error) {
+	return scheme.batch.Verify(entries)
+}
+
 // AggregateSignatures aggregates the signatures using a coefficient for each
 // one of them where c = H(pk) and H: keyGroup -> R with R = {1, ..., 2^128}
 func (scheme *Scheme) AggregateSignatures(sigs [][]byte, mask *sign.Mask) (kyber.Point, error) {
@@ -138,7 +149,8 @@ func (scheme *Scheme) AggregateSignatures(sigs [][]byte, mask *sign.Mask) (kyber
 		return nil, err
 	}
 
-	agg := scheme.sigGroup.Point()
+	points := make([]kyber.Point, len(sigs))
+	scalars := make([]kyber.Scalar, len(sigs))
 	for i, buf := range sigs {
 		peerIndex := mask.IndexOfNthEnabled(i)
 		if peerIndex < 0 {
@@ -153,13 +165,11 @@ func (scheme *Scheme) AggregateSignatures(sigs [][]byte, mask *sign.Mask) (kyber
 			return nil, err
 		}
 
-		sigC := sig.Clone().Mul(coefs[peerIndex], sig)
-		// c+1 because R is in the range [1, 2^128] and not [0, 2^128-1]
-		sigC = sigC.Add(sigC, sig)
-		agg = agg.Add(agg, sigC)
+		points[i] = sig
+		scalars[i] = coefs[peerIndex]
 	}
 
-	return agg, nil
+	return aggregate(scheme.sigGroup, scalars, points), nil
 }
 
 // AggregatePublicKeys aggregates a set of public keys (similarly to
@@ -171,8 +181,9 @@ func (scheme *Scheme) AggregatePublicKeys(mask *sign.Mask) (kyber.Point, error)
 		return nil, err
 	}
 
-	agg := scheme.keyGroup.Point()
-	for i := 0; i < mask.CountEnabled(); i++ {
+	points := make([]kyber.Point, mask.CountEnabled())
+	scalars := make([]kyber.Scalar, mask.CountEnabled())
+	for i := range points {
 		peerIndex := mask.IndexOfNthEnabled(i)
 		if peerIndex < 0 {
 			// this should never happen because of the loop boundary
@@ -180,13 +191,23 @@ func (scheme *Scheme) AggregatePublicKeys(mask *sign.Mask) (kyber.Point, error)
 			return nil, errors.New("couldn't find the index")
 		}
 
-		pub := mask.Publics()[peerIndex]
-		pubC := pub.Clone().Mul(coefs[peerIndex], pub)
-		pubC = pubC.Add(pubC, pub)
-		agg = agg.Add(agg, pubC)
+		points[i] = mask.Publics()[peerIndex]
+		scalars[i] = coefs[peerIndex]
 	}
 
-	return agg, nil
+	return aggregate(scheme.keyGroup, scalars, points), nil
+}
+
+// aggregate returns the sum of (coefs[i]+1) * points[i]. The linear
+// combination is computed with a multi-scalar multiplication, which is fine
+// since both the coefficients and the points are public.
+func aggregate(g kyber.Group, coefs []kyber.Scalar, points []kyber.Point) kyber.Point {
+	agg := msm.MultiScalarMul(g, coefs, points)
+	// c+1 because R is in the range [1, 2^128] and not [0, 2^128-1]
+	for _, p := range points {
+		agg.Add(agg, p)
+	}
+	return agg
 }
 
 // v1 API Deprecated ----------------------------------
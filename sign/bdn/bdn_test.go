@@ -109,6 +109,27 @@ func TestBDN_SubsetSignature(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestBDN_VerifyBatch(t *testing.T) {
+	scheme := NewSchemeOnG1(suite)
+	var entries []bls.BatchEntry
+	for i := 0; i < 5; i++ {
+		private, public := scheme.NewKeyPair(random.New())
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig, err := scheme.Sign(private, msg)
+		require.NoError(t, err)
+		entries = append(entries, bls.BatchEntry{Public: public, Msg: msg, Sig: sig})
+	}
+
+	invalid, err := scheme.VerifyBatch(entries)
+	require.NoError(t, err)
+	require.Nil(t, invalid)
+
+	entries[3].Msg = []byte("another message")
+	invalid, err = scheme.VerifyBatch(entries)
+	require.NoError(t, err)
+	require.Equal(t, []int{3}, invalid)
+}
+
 func TestBDN_RogueAttack(t *testing.T) {
 	msg := []byte("Hello Boneh-Lynn-Shacham")
 	suite := bn256.NewSuite()
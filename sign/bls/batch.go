@@ -0,0 +1,275 @@
+package bls
+
+import (
+	"crypto/cipher"
+	"errors"
+	"runtime"
+	"sort"
+	"sync"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// randomizerSize is the size in bytes of the random exponents of the batch
+// verification: an invalid batch is accepted with probability 2^-64.
+const randomizerSize = 8
+
+// minChunk is the least number of pairings computed by a worker.
+const minChunk = 4
+
+// BatchEntry is a signature of a message under a public key, to be verified
+// in a batch.
+type BatchEntry struct {
+	Public kyber.Point
+	Msg    []byte
+	Sig    []byte
+}
+
+// BatchVerifier verifies many independent BLS signatures at once. The
+// messages need not be distinct: each entry i is weighted by a small random
+// exponent ri, and the batch is valid when
+//
+//	e(r1 * H(m1), X1) * ... * e(rn * H(mn), Xn) == e(r1 * S1 + ... + rn * Sn, B2)
+//
+// for signatures on G1, which all valid entries satisfy and an invalid one
+// satisfies with negligible probability. The pairings are computed by a pool
+// of workers. When the batch is invalid, it is bisected to identify the
+// invalid entries.
+type BatchVerifier struct {
+	sigGroup kyber.Group
+	keyGroup kyber.Group
+	suite    pairing.Suite
+	onG1     bool
+	workers  int
+
+	// hash and signature replace, when set, the hashing of the messages and
+	// the decoding of the signatures of the plain scheme, failing on the
+	// malformed entries
+	hash      func(public kyber.Point, msg []byte) (kyber.Point, error)
+	signature func(sig []byte) (kyber.Point, error)
+
+	mu   sync.Mutex // guards rand
+	rand cipher.Stream
+}
+
+// NewBatchVerifierOnG1 returns a batch verifier for the signatures of
+// NewSchemeOnG1, on G1 with public keys on G2.
+func NewBatchVerifierOnG1(suite pairing.Suite) *BatchVerifier {
+	return &BatchVerifier{
+		sigGroup: suite.G1(),
+		keyGroup: suite.G2(),
+		suite:    suite,
+		onG1:     true,
+		workers:  runtime.GOMAXPROCS(0),
+		rand:     random.New(),
+	}
+}
+
+// NewBatchVerifierOnG2 returns a batch verifier for the signatures of
+// NewSchemeOnG2, on G2 with public keys on G1.
+func NewBatchVerifierOnG2(suite pairing.Suite) *BatchVerifier {
+	return &BatchVerifier{
+		sigGroup: suite.G2(),
+		keyGroup: suite.G1(),
+		suite:    suite,
+		workers:  runtime.GOMAXPROCS(0),
+		rand:     random.New(),
+	}
+}
+
+// SetWorkers sets the number of goroutines of the verification, by default
+// GOMAXPROCS.
+func (b *BatchVerifier) SetWorkers(n int) {
+	if n < 1 {
+		n = 1
+	}
+	b.workers = n
+}
+
+// SetRandom sets the source of the random exponents, by default
+// random.New(). It must be unpredictable to the signers.
+func (b *BatchVerifier) SetRandom(rand cipher.Stream) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rand = rand
+}
+
+// batchItem is a well-formed entry, with its signature and its term on G1,
+// the hash of the message or the public key, weighted by the random exponent.
+type batchItem struct {
+	index  int
+	g1, g2 kyber.Point // pairing of the message and the public key
+	sig    kyber.Point
+}
+
+// Verify verifies the signatures of the entries and returns the indexes of
+// the invalid ones, in increasing order, or nil if they are all valid. The
+// entries whose public key or signature is malformed are invalid. It is safe
+// for concurrent use.
+func (b *BatchVerifier) Verify(entries []BatchEntry) ([]int, error) {
+	if _, ok := b.sigGroup.Point().(kyber.HashablePoint); !ok && b.hash == nil {
+		return nil, errors.New("bls: point needs to implement hashablePoint")
+	}
+
+	rs := b.randomizers(len(entries))
+	items := make([]*batchItem, len(entries))
+	b.parallel(len(entries), func(i int) {
+		items[i] = b.prepare(i, &entries[i], rs[i])
+	})
+
+	var invalid []int
+	var valid []*batchItem
+	for i, item := range items {
+		if item == nil {
+			invalid = append(invalid, i)
+			continue
+		}
+		valid = append(valid, item)
+	}
+	if len(valid) > 0 && !b.check(valid) {
+		invalid = append(invalid, b.bisect(valid)...)
+		sort.Ints(invalid)
+	}
+	return invalid, nil
+}
+
+// randomizers returns n non-zero random exponents of randomizerSize bytes.
+// They are drawn up front as the stream may not be safe for concurrent use.
+func (b *BatchVerifier) randomizers(n int) []kyber.Scalar {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	rs := make([]kyber.Scalar, n)
+	buf := make([]byte, randomizerSize)
+	for i := range rs {
+		for rs[i] == nil || rs[i].Equal(b.sigGroup.Scalar().Zero()) {
+			b.rand.XORKeyStream(buf, make([]byte, randomizerSize))
+			rs[i] = b.sigGroup.Scalar().SetBytes(buf)
+		}
+	}
+	return rs
+}
+
+// prepare returns the weighted terms of the entry, or nil if it is
+// malformed.
+func (b *BatchVerifier) prepare(i int, e *BatchEntry, r kyber.Scalar) *batchItem {
+	if e.Public == nil {
+		return nil
+	}
+	var sig, HM kyber.Point
+	if b.signature != nil {
+		var err error
+		if sig, err = b.signature(e.Sig); err != nil {
+			return nil
+		}
+	} else {
+		sig = b.sigGroup.Point()
+		if err := sig.UnmarshalBinary(e.Sig); err != nil {
+			return nil
+		}
+	}
+	if b.hash != nil {
+		var err error
+		if HM, err = b.hash(e.Public, e.Msg); err != nil {
+			return nil
+		}
+	} else {
+		HM = b.sigGroup.Point().(kyber.HashablePoint).Hash(e.Msg)
+	}
+
+	// the exponent weights the term on G1, whose multiplication is cheaper
+	item := &batchItem{index: i, sig: sig.Mul(r, sig)}
+	if b.onG1 {
+		item.g1, item.g2 = HM.Mul(r, HM), e.Public
+	} else {
+		item.g1, item.g2 = b.keyGroup.Point().Mul(r, e.Public), HM
+	}
+	return item
+}
+
+// check reports whether the batch of the items is valid. The product of the
+// pairings is split among the workers, each of which computes a single
+// final exponentiation.
+func (b *BatchVerifier) check(items []*batchItem) bool {
+	sum := b.sigGroup.Point().Null()
+	g1s := make([]kyber.Point, 0, len(items)+1)
+	g2s := make([]kyber.Point, 0, len(items)+1)
+	for _, item := range items {
+		sum.Add(sum, item.sig)
+		g1s = append(g1s, item.g1)
+		g2s = append(g2s, item.g2)
+	}
+	if b.onG1 {
+		g1s = append(g1s, sum.Neg(sum))
+		g2s = append(g2s, b.keyGroup.Point().Base())
+	} else {
+		g1s = append(g1s, b.keyGroup.Point().Base().Neg(b.keyGroup.Point().Base()))
+		g2s = append(g2s, sum)
+	}
+
+	chunks := (len(g1s) + minChunk - 1) / minChunk
+	if chunks > b.workers {
+		chunks = b.workers
+	}
+	size := (len(g1s) + chunks - 1) / chunks
+	products := make([]kyber.Point, chunks)
+	b.parallel(chunks, func(c int) {
+		start, end := c*size, (c+1)*size
+		if start > len(g1s) {
+			start = len(g1s)
+		}
+		if end > len(g1s) {
+			end = len(g1s)
+		}
+		products[c] = b.suite.MultiPair(g1s[start:end], g2s[start:end])
+	})
+
+	product := b.suite.GT().Point().Null()
+	for _, p := range products {
+		product.Add(product, p)
+	}
+	return product.Equal(b.suite.GT().Point().Null())
+}
+
+// bisect returns the indexes of the invalid items of an invalid batch.
+func (b *BatchVerifier) bisect(items []*batchItem) []int {
+	if len(items) == 1 {
+		return []int{items[0].index}
+	}
+	left, right := items[:len(items)/2], items[len(items)/2:]
+	var invalid []int
+	leftValid := b.check(left)
+	if !leftValid {
+		invalid = append(invalid, b.bisect(left)...)
+	}
+	// the right half is invalid if the left one is valid
+	if leftValid || !b.check(right) {
+		invalid = append(invalid, b.bisect(right)...)
+	}
+	return invalid
+}
+
+// parallel runs f(0), ..., f(n-1) on the workers.
+func (b *BatchVerifier) parallel(n int, f func(int)) {
+	workers := b.workers
+	if workers > n {
+		workers = n
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
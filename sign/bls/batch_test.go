@@ -0,0 +1,100 @@
+package bls
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
+	"go.dedis.ch/kyber/v4/pairing/bn256"
+	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+func batchEntries(t *testing.T, scheme sign.Scheme, n int) []BatchEntry {
+	entries := make([]BatchEntry, n)
+	for i := range entries {
+		private, public := scheme.NewKeyPair(random.New())
+		// the messages need not be distinct
+		msg := []byte(fmt.Sprintf("message %d", i%3))
+		sig, err := scheme.Sign(private, msg)
+		require.NoError(t, err)
+		entries[i] = BatchEntry{Public: public, Msg: msg, Sig: sig}
+	}
+	return entries
+}
+
+// shiftSig returns the signature plus delta.
+func shiftSig(t *testing.T, p kyber.Point, sig []byte, delta kyber.Point) []byte {
+	require.NoError(t, p.UnmarshalBinary(sig))
+	buf, err := p.Add(p, delta).MarshalBinary()
+	require.NoError(t, err)
+	return buf
+}
+
+func TestBatchVerifier(t *testing.T) {
+	for _, suite := range []pairing.Suite{bn256.NewSuite(), kilic.NewBLS12381Suite()} {
+		for _, onG1 := range []bool{true, false} {
+			scheme, verifier := NewSchemeOnG1(suite), NewBatchVerifierOnG1(suite)
+			if !onG1 {
+				scheme, verifier = NewSchemeOnG2(suite), NewBatchVerifierOnG2(suite)
+			}
+			entries := batchEntries(t, scheme, 13)
+
+			for _, workers := range []int{1, 4} {
+				verifier.SetWorkers(workers)
+				invalid, err := verifier.Verify(entries)
+				require.NoError(t, err)
+				require.Nil(t, invalid)
+			}
+
+			invalid, err := verifier.Verify(nil)
+			require.NoError(t, err)
+			require.Nil(t, invalid)
+
+			// a signature of another message, a signature under another key,
+			// a malformed signature and a missing key
+			bad := append([]BatchEntry{}, entries...)
+			bad[2].Msg = []byte("another message")
+			bad[7].Public = entries[8].Public
+			bad[9].Sig = []byte("not a signature")
+			bad[12].Public = nil
+			invalid, err = verifier.Verify(bad)
+			require.NoError(t, err)
+			require.Equal(t, []int{2, 7, 9, 12}, invalid)
+
+			// two invalid signatures whose sum is valid
+			sigGroup := suite.G1()
+			if !onG1 {
+				sigGroup = suite.G2()
+			}
+			delta := sigGroup.Point().Pick(random.New())
+			shifted := append([]BatchEntry{}, entries[:4]...)
+			shifted[1].Sig = shiftSig(t, sigGroup.Point(), entries[1].Sig, delta)
+			shifted[3].Sig = shiftSig(t, sigGroup.Point(), entries[3].Sig, delta.Clone().Neg(delta))
+			invalid, err = verifier.Verify(shifted)
+			require.NoError(t, err)
+			require.Equal(t, []int{1, 3}, invalid)
+		}
+	}
+}
+
+func BenchmarkBatchVerifier(b *testing.B) {
+	suite := bn256.NewSuite()
+	scheme := NewSchemeOnG1(suite)
+	entries := make([]BatchEntry, 64)
+	for i := range entries {
+		private, public := scheme.NewKeyPair(random.New())
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig, _ := scheme.Sign(private, msg)
+		entries[i] = BatchEntry{Public: public, Msg: msg, Sig: sig}
+	}
+	verifier := NewBatchVerifierOnG1(suite)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = verifier.Verify(entries)
+	}
+}
@@ -122,6 +122,9 @@ func (s *scheme) AggregatePublicKeys(Xs ...kyber.Point) kyber.Point {
 // see: https://crypto.stackexchange.com/questions/56288/is-bls-signature-scheme-strongly-unforgeable/56290
 // for a description of why each message must be unique.
 func BatchVerify(suite pairing.Suite, publics []kyber.Point, msgs [][]byte, sig []byte) error {
+	if len(publics) != len(msgs) {
+		return fmt.Errorf("bls: %d public keys for %d messages", len(publics), len(msgs))
+	}
 	if !distinct(msgs) {
 		return fmt.Errorf("bls: error, messages must be distinct")
 	}
@@ -131,24 +134,21 @@ func BatchVerify(suite pairing.Suite, publics []kyber.Point, msgs [][]byte, sig
 		return err
 	}
 
-	var aggregatedLeft kyber.Point
+	// e(H(m_1), X_1) * ... * e(H(m_n), X_n) * e(-sig, G2) must be one
+	g1s := make([]kyber.Point, 0, len(msgs)+1)
+	g2s := make([]kyber.Point, 0, len(msgs)+1)
 	for i := range msgs {
 		hashable, ok := suite.G1().Point().(kyber.HashablePoint)
 		if !ok {
 			return errors.New("bls: point needs to implement hashablePoint")
 		}
-		hm := hashable.Hash(msgs[i])
-		pair := suite.Pair(hm, publics[i])
-
-		if i == 0 {
-			aggregatedLeft = pair
-		} else {
-			aggregatedLeft.Add(aggregatedLeft, pair)
-		}
+		g1s = append(g1s, hashable.Hash(msgs[i]))
+		g2s = append(g2s, publics[i])
 	}
+	g1s = append(g1s, s.Neg(s))
+	g2s = append(g2s, suite.G2().Point().Base())
 
-	right := suite.Pair(s, suite.G2().Point().Base())
-	if !aggregatedLeft.Equal(right) {
+	if !suite.PairingCheck(g1s, g2s) {
 		return errors.New("bls: invalid signature")
 	}
 	return nil
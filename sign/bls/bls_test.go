@@ -170,6 +170,12 @@ func TestBLSFailBatchVerify(t *testing.T) {
 			t.Fatal("bls: verification succeeded unexpectedly")
 		}
 	})
+
+	t.Run("fails with a missing public key", func(t *testing.T) {
+		aggregatedSig, err := scheme.AggregateSignatures(sig1, sig2)
+		require.Nil(t, err)
+		require.Error(t, BatchVerify(suite, []kyber.Point{public1}, [][]byte{msg1, msg2}, aggregatedSig))
+	})
 }
 
 func BenchmarkBLSKeyCreation(b *testing.B) {
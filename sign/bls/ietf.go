@@ -0,0 +1,402 @@
+package bls
+
+import (
+	"crypto/cipher"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Mode is the way an IETF BLS scheme defends aggregate signatures against
+// rogue public key attacks, as defined in section 3 of
+// draft-irtf-cfrg-bls-signature-05.
+type Mode int
+
+const (
+	// Basic requires all the messages of an aggregate signature to be
+	// distinct.
+	Basic Mode = iota
+	// MessageAugmentation prepends the public key of the signer to every
+	// signed message.
+	MessageAugmentation
+	// ProofOfPossession requires every public key to come with a proof that
+	// its owner knows the matching private key, see PopProve and PopVerify.
+	ProofOfPossession
+)
+
+func (m Mode) tag() string {
+	switch m {
+	case Basic:
+		return "NUL_"
+	case MessageAugmentation:
+		return "AUG_"
+	case ProofOfPossession:
+		return "POP_"
+	default:
+		panic("bls: unknown mode")
+	}
+}
+
+// keyGenSalt is the initial salt of KeyGen.
+const keyGenSalt = "BLS-SIG-KEYGEN-SALT-"
+
+// bls12381Order is the order r of the groups of BLS12-381.
+var bls12381Order, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// checkBLS12381 panics if suite is not a BLS12-381 suite, the only curve of
+// the ciphersuites and of their domain separation tags. The suites are told
+// apart by the order of their scalars.
+func checkBLS12381(suite pairing.Suite) {
+	s := suite.G1().Scalar().One()
+	buf, err := s.Neg(s).MarshalBinary()
+	if err != nil {
+		panic("bls: " + err.Error())
+	}
+	if s.ByteOrder() == kyber.LittleEndian {
+		for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+			buf[i], buf[j] = buf[j], buf[i]
+		}
+	}
+	order := new(big.Int).SetBytes(buf)
+	if order.Add(order, big.NewInt(1)).Cmp(bls12381Order) != 0 {
+		panic("bls: the IETF ciphersuites require a BLS12-381 suite")
+	}
+}
+
+var errNotPop = errors.New("bls: proofs of possession require the ProofOfPossession mode")
+
+// IETFScheme implements the BLS signature ciphersuites over BLS12-381 of
+// draft-irtf-cfrg-bls-signature-05, which is also the signature scheme of the
+// Ethereum consensus layer when used with ProofOfPossession on G2. The points
+// of the suite must implement kyber.HashablePointWithDST.
+type IETFScheme struct {
+	suite    pairing.Suite
+	sigGroup kyber.Group
+	keyGroup kyber.Group
+	onG1     bool
+	mode     Mode
+	dst      []byte
+	popDST   []byte
+	// pair computes the pairing of a public key and a signature group element
+	pair func(key, sig kyber.Point) kyber.Point
+}
+
+// NewIETFSchemeOnG1 returns the minimal-signature-size ciphersuite of the
+// given mode, with signatures on G1 and public keys on G2. It panics if suite
+// is not a BLS12-381 suite.
+func NewIETFSchemeOnG1(suite pairing.Suite, mode Mode) *IETFScheme {
+	checkBLS12381(suite)
+	return &IETFScheme{
+		suite:    suite,
+		sigGroup: suite.G1(),
+		keyGroup: suite.G2(),
+		onG1:     true,
+		mode:     mode,
+		dst:      []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_" + mode.tag()),
+		popDST:   []byte("BLS_POP_BLS12381G1_XMD:SHA-256_SSWU_RO_" + mode.tag()),
+		pair: func(key, sig kyber.Point) kyber.Point {
+			return suite.Pair(sig, key)
+		},
+	}
+}
+
+// NewIETFSchemeOnG2 returns the minimal-pubkey-size ciphersuite of the given
+// mode, with signatures on G2 and public keys on G1. It panics if suite is not
+// a BLS12-381 suite.
+func NewIETFSchemeOnG2(suite pairing.Suite, mode Mode) *IETFScheme {
+	checkBLS12381(suite)
+	return &IETFScheme{
+		suite:    suite,
+		sigGroup: suite.G2(),
+		keyGroup: suite.G1(),
+		mode:     mode,
+		dst:      []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_" + mode.tag()),
+		popDST:   []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_" + mode.tag()),
+		pair: func(key, sig kyber.Point) kyber.Point {
+			return suite.Pair(key, sig)
+		},
+	}
+}
+
+// CiphersuiteID returns the identifier of the ciphersuite, which is also the
+// domain separation tag used to hash messages.
+func (s *IETFScheme) CiphersuiteID() string {
+	return string(s.dst)
+}
+
+// KeyGen deterministically derives a key pair from the secret input keying
+// material ikm, which must be at least 32 bytes long, and the optional
+// keyInfo.
+func (s *IETFScheme) KeyGen(ikm, keyInfo []byte) (kyber.Scalar, kyber.Point, error) {
+	if len(ikm) < 32 {
+		return nil, nil, errors.New("bls: input keying material must be at least 32 bytes long")
+	}
+
+	// L = ceil((3 * ceil(log2(r))) / 16)
+	const L = 48
+	ikm = append(append([]byte{}, ikm...), 0)
+	info := append(append([]byte{}, keyInfo...), 0, L)
+
+	salt := []byte(keyGenSalt)
+	sk := s.keyGroup.Scalar().Zero()
+	zero := s.keyGroup.Scalar().Zero()
+	for sk.Equal(zero) {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+
+		okm := make([]byte, L)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, ikm, salt, info), okm); err != nil {
+			return nil, nil, err
+		}
+		if sk.ByteOrder() == kyber.LittleEndian {
+			for i, j := 0, len(okm)-1; i < j; i, j = i+1, j-1 {
+				okm[i], okm[j] = okm[j], okm[i]
+			}
+		}
+		sk.SetBytes(okm)
+	}
+	return sk, s.keyGroup.Point().Mul(sk, nil), nil
+}
+
+// NewKeyPair creates a new key pair from the given source of randomness.
+func (s *IETFScheme) NewKeyPair(random cipher.Stream) (kyber.Scalar, kyber.Point) {
+	secret := s.keyGroup.Scalar().Pick(random)
+	public := s.keyGroup.Point().Mul(secret, nil)
+	return secret, public
+}
+
+// KeyValidate returns an error if the public key is the identity element or
+// lies outside of the prime order subgroup.
+func (s *IETFScheme) KeyValidate(public kyber.Point) error {
+	if public.Equal(s.keyGroup.Point().Null()) {
+		return errors.New("bls: public key is the identity element")
+	}
+	if sub, ok := public.(kyber.SubGroupElement); ok && !sub.IsInCorrectGroup() {
+		return errors.New("bls: public key is not in the correct subgroup")
+	}
+	return nil
+}
+
+// Sign creates a signature on msg with the private key.
+func (s *IETFScheme) Sign(private kyber.Scalar, msg []byte) ([]byte, error) {
+	if s.mode == MessageAugmentation {
+		public := s.keyGroup.Point().Mul(private, nil)
+		var err error
+		if msg, err = augment(public, msg); err != nil {
+			return nil, err
+		}
+	}
+	return s.coreSign(private, msg, s.dst)
+}
+
+// Verify checks the signature sig of msg under the public key.
+func (s *IETFScheme) Verify(public kyber.Point, msg, sig []byte) error {
+	if s.mode == MessageAugmentation {
+		var err error
+		if msg, err = augment(public, msg); err != nil {
+			return err
+		}
+	}
+	return s.coreVerify(public, msg, sig, s.dst)
+}
+
+// NewBatchVerifier returns a verifier of many independent signatures of the
+// scheme at once, see BatchVerifier. It checks the public keys and the
+// signatures like Verify.
+func (s *IETFScheme) NewBatchVerifier() *BatchVerifier {
+	b := NewBatchVerifierOnG2(s.suite)
+	if s.onG1 {
+		b = NewBatchVerifierOnG1(s.suite)
+	}
+	b.hash = func(public kyber.Point, msg []byte) (kyber.Point, error) {
+		if err := s.KeyValidate(public); err != nil {
+			return nil, err
+		}
+		if s.mode == MessageAugmentation {
+			var err error
+			if msg, err = augment(public, msg); err != nil {
+				return nil, err
+			}
+		}
+		return s.hash(msg, s.dst)
+	}
+	b.signature = s.unmarshalSignature
+	return b
+}
+
+// AggregateSignatures aggregates the given signatures into a single one.
+func (s *IETFScheme) AggregateSignatures(sigs ...[]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("bls: no signature to aggregate")
+	}
+	agg := s.sigGroup.Point().Null()
+	for _, buf := range sigs {
+		sig, err := s.unmarshalSignature(buf)
+		if err != nil {
+			return nil, err
+		}
+		agg.Add(agg, sig)
+	}
+	return agg.MarshalBinary()
+}
+
+// AggregatePublicKeys sums the given public keys. Such an aggregate is only
+// meaningful with FastAggregateVerify in the ProofOfPossession mode.
+func (s *IETFScheme) AggregatePublicKeys(Xs ...kyber.Point) kyber.Point {
+	agg := s.keyGroup.Point().Null()
+	for _, X := range Xs {
+		agg.Add(agg, X)
+	}
+	return agg
+}
+
+// AggregateVerify checks the aggregate signature sig of the messages msgs
+// signed by the corresponding public keys. In the Basic mode, the messages
+// must all be distinct.
+func (s *IETFScheme) AggregateVerify(publics []kyber.Point, msgs [][]byte, sig []byte) error {
+	if len(publics) != len(msgs) {
+		return errors.New("bls: number of public keys and messages must match")
+	}
+	if len(publics) == 0 {
+		return errors.New("bls: no message to verify")
+	}
+	if s.mode == Basic && !distinct(msgs) {
+		return errors.New("bls: messages must be distinct")
+	}
+
+	sigPoint, err := s.unmarshalSignature(sig)
+	if err != nil {
+		return err
+	}
+
+	left := s.suite.GT().Point().Null()
+	for i, public := range publics {
+		if err := s.KeyValidate(public); err != nil {
+			return err
+		}
+		msg := msgs[i]
+		if s.mode == MessageAugmentation {
+			if msg, err = augment(public, msg); err != nil {
+				return err
+			}
+		}
+		hm, err := s.hash(msg, s.dst)
+		if err != nil {
+			return err
+		}
+		left.Add(left, s.pair(public, hm))
+	}
+
+	right := s.pair(s.keyGroup.Point().Base(), sigPoint)
+	if !left.Equal(right) {
+		return errors.New("bls: invalid signature")
+	}
+	return nil
+}
+
+// PopProve returns a proof of possession of the private key, to be published
+// along with the public key. It is only available in the ProofOfPossession
+// mode.
+func (s *IETFScheme) PopProve(private kyber.Scalar) ([]byte, error) {
+	if s.mode != ProofOfPossession {
+		return nil, errNotPop
+	}
+	buf, err := s.keyGroup.Point().Mul(private, nil).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return s.coreSign(private, buf, s.popDST)
+}
+
+// PopVerify checks the proof of possession of the private key matching the
+// public key. It is only available in the ProofOfPossession mode.
+func (s *IETFScheme) PopVerify(public kyber.Point, proof []byte) error {
+	if s.mode != ProofOfPossession {
+		return errNotPop
+	}
+	buf, err := public.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return s.coreVerify(public, buf, proof, s.popDST)
+}
+
+// FastAggregateVerify checks the aggregate signature sig of the same message
+// by all the public keys, whose proofs of possession must have been verified
+// beforehand. It is only available in the ProofOfPossession mode.
+func (s *IETFScheme) FastAggregateVerify(publics []kyber.Point, msg, sig []byte) error {
+	if s.mode != ProofOfPossession {
+		return errNotPop
+	}
+	if len(publics) == 0 {
+		return errors.New("bls: no public key to verify")
+	}
+	for _, public := range publics {
+		if err := s.KeyValidate(public); err != nil {
+			return err
+		}
+	}
+	return s.coreVerify(s.AggregatePublicKeys(publics...), msg, sig, s.dst)
+}
+
+func (s *IETFScheme) coreSign(private kyber.Scalar, msg, dst []byte) ([]byte, error) {
+	if private.Equal(s.keyGroup.Scalar().Zero()) {
+		return nil, errors.New("bls: private key must not be zero")
+	}
+	hm, err := s.hash(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return hm.Mul(private, hm).MarshalBinary()
+}
+
+func (s *IETFScheme) coreVerify(public kyber.Point, msg, sig, dst []byte) error {
+	sigPoint, err := s.unmarshalSignature(sig)
+	if err != nil {
+		return err
+	}
+	if err := s.KeyValidate(public); err != nil {
+		return err
+	}
+	hm, err := s.hash(msg, dst)
+	if err != nil {
+		return err
+	}
+	if !s.pair(public, hm).Equal(s.pair(s.keyGroup.Point().Base(), sigPoint)) {
+		return errors.New("bls: invalid signature")
+	}
+	return nil
+}
+
+func (s *IETFScheme) hash(msg, dst []byte) (kyber.Point, error) {
+	hashable, ok := s.sigGroup.Point().(kyber.HashablePointWithDST)
+	if !ok {
+		return nil, errors.New("bls: point needs to implement HashablePointWithDST")
+	}
+	return hashable.Hash2(msg, dst), nil
+}
+
+func (s *IETFScheme) unmarshalSignature(sig []byte) (kyber.Point, error) {
+	sigPoint := s.sigGroup.Point()
+	if err := sigPoint.UnmarshalBinary(sig); err != nil {
+		return nil, err
+	}
+	if sub, ok := sigPoint.(kyber.SubGroupElement); ok && !sub.IsInCorrectGroup() {
+		return nil, errors.New("bls: signature is not in the correct subgroup")
+	}
+	return sigPoint, nil
+}
+
+// augment prepends the encoding of the public key to msg.
+func augment(public kyber.Point, msg []byte) ([]byte, error) {
+	buf, err := public.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, msg...), nil
+}
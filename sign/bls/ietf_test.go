@@ -0,0 +1,191 @@
+package bls
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/circl"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
+	"go.dedis.ch/kyber/v4/pairing/bn254"
+	"go.dedis.ch/kyber/v4/pairing/bn256"
+	"go.dedis.ch/kyber/v4/util/random"
+	"gopkg.in/yaml.v3"
+)
+
+var ietfSuites = map[string]pairing.Suite{
+	"kilic": kilic.NewBLS12381Suite(),
+	"circl": circl.NewSuiteBLS12381(),
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	buf, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	require.NoError(t, err)
+	return buf
+}
+
+func TestIETFEthSignVectors(t *testing.T) {
+	type Test struct {
+		Input struct {
+			Privkey string `yaml:"privkey"`
+			Message string `yaml:"message"`
+		}
+		Output *string `yaml:"output"`
+	}
+	paths, err := filepath.Glob("testdata/eth/sign/*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for name, suite := range ietfSuites {
+		scheme := NewIETFSchemeOnG2(suite, ProofOfPossession)
+		for _, path := range paths {
+			t.Run(name+"/"+filepath.Base(path), func(t *testing.T) {
+				buf, err := os.ReadFile(path)
+				require.NoError(t, err)
+				test := Test{}
+				require.NoError(t, yaml.Unmarshal(buf, &test))
+
+				private := suite.G1().Scalar().SetBytes(decodeHex(t, test.Input.Privkey))
+				msg := decodeHex(t, test.Input.Message)
+				sig, err := scheme.Sign(private, msg)
+				if test.Output == nil {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, decodeHex(t, *test.Output), sig)
+
+				public := suite.G1().Point().Mul(private, nil)
+				require.NoError(t, scheme.Verify(public, msg, sig))
+			})
+		}
+	}
+}
+
+func TestIETFCiphersuiteID(t *testing.T) {
+	suite := kilic.NewBLS12381Suite()
+	require.Equal(t, "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_",
+		NewIETFSchemeOnG2(suite, ProofOfPossession).CiphersuiteID())
+	require.Equal(t, "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_",
+		NewIETFSchemeOnG1(suite, Basic).CiphersuiteID())
+	require.Equal(t, "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_",
+		NewIETFSchemeOnG2(suite, MessageAugmentation).CiphersuiteID())
+}
+
+func TestIETFKeyGen(t *testing.T) {
+	suite := kilic.NewBLS12381Suite()
+	scheme := NewIETFSchemeOnG2(suite, ProofOfPossession)
+
+	ikm := make([]byte, 32)
+	_, _, err := scheme.KeyGen(ikm[:31], nil)
+	require.Error(t, err)
+
+	private, public, err := scheme.KeyGen(ikm, nil)
+	require.NoError(t, err)
+	require.True(t, public.Equal(suite.G1().Point().Mul(private, nil)))
+
+	private2, _, err := scheme.KeyGen(ikm, nil)
+	require.NoError(t, err)
+	require.True(t, private.Equal(private2))
+
+	private3, _, err := scheme.KeyGen(ikm, []byte("info"))
+	require.NoError(t, err)
+	require.False(t, private.Equal(private3))
+}
+
+func TestIETFModes(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	for name, suite := range ietfSuites {
+		for _, newScheme := range []func(pairing.Suite, Mode) *IETFScheme{NewIETFSchemeOnG1, NewIETFSchemeOnG2} {
+			for _, mode := range []Mode{Basic, MessageAugmentation, ProofOfPossession} {
+				scheme := newScheme(suite, mode)
+				t.Run(name+"/"+scheme.CiphersuiteID(), func(t *testing.T) {
+					testIETFScheme(t, scheme, msg)
+				})
+			}
+		}
+	}
+}
+
+func TestIETFRequiresBLS12381(t *testing.T) {
+	for _, suite := range []pairing.Suite{bn256.NewSuite(), bn254.NewSuite()} {
+		require.Panics(t, func() { NewIETFSchemeOnG1(suite, Basic) })
+		require.Panics(t, func() { NewIETFSchemeOnG2(suite, ProofOfPossession) })
+	}
+}
+
+func testIETFScheme(t *testing.T, scheme *IETFScheme, msg []byte) {
+	private1, public1 := scheme.NewKeyPair(random.New())
+	private2, public2 := scheme.NewKeyPair(random.New())
+
+	sig1, err := scheme.Sign(private1, msg)
+	require.NoError(t, err)
+	require.NoError(t, scheme.Verify(public1, msg, sig1))
+	require.Error(t, scheme.Verify(public2, msg, sig1))
+	require.Error(t, scheme.Verify(public1, []byte("other"), sig1))
+	require.Error(t, scheme.Verify(scheme.keyGroup.Point().Null(), msg, sig1))
+
+	// signatures of the same message
+	sig2, err := scheme.Sign(private2, msg)
+	require.NoError(t, err)
+	agg, err := scheme.AggregateSignatures(sig1, sig2)
+	require.NoError(t, err)
+	err = scheme.AggregateVerify([]kyber.Point{public1, public2}, [][]byte{msg, msg}, agg)
+	if scheme.mode == Basic {
+		require.Error(t, err)
+	} else {
+		require.NoError(t, err)
+	}
+
+	// signatures of distinct messages
+	msg2 := []byte("other")
+	sig2, err = scheme.Sign(private2, msg2)
+	require.NoError(t, err)
+	agg, err = scheme.AggregateSignatures(sig1, sig2)
+	require.NoError(t, err)
+	require.NoError(t, scheme.AggregateVerify([]kyber.Point{public1, public2}, [][]byte{msg, msg2}, agg))
+	require.Error(t, scheme.AggregateVerify([]kyber.Point{public2, public1}, [][]byte{msg, msg2}, agg))
+	require.Error(t, scheme.AggregateVerify([]kyber.Point{public1}, [][]byte{msg, msg2}, agg))
+
+	// swapped signatures have a valid aggregate, but not a valid batch
+	invalid, err := scheme.NewBatchVerifier().Verify([]BatchEntry{
+		{Public: public1, Msg: msg, Sig: sig1},
+		{Public: public2, Msg: msg2, Sig: sig2},
+		{Public: public1, Msg: msg, Sig: sig2},
+		{Public: public2, Msg: msg2, Sig: sig1},
+		{Public: scheme.keyGroup.Point().Null(), Msg: msg, Sig: sig1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 3, 4}, invalid)
+
+	if scheme.mode != ProofOfPossession {
+		_, err := scheme.PopProve(private1)
+		require.Error(t, err)
+		require.Error(t, scheme.FastAggregateVerify([]kyber.Point{public1}, msg, sig1))
+		return
+	}
+
+	proof, err := scheme.PopProve(private1)
+	require.NoError(t, err)
+	require.NoError(t, scheme.PopVerify(public1, proof))
+	require.Error(t, scheme.PopVerify(public2, proof))
+	// a proof of possession is not a signature of the public key
+	buf, err := public1.MarshalBinary()
+	require.NoError(t, err)
+	sig, err := scheme.Sign(private1, buf)
+	require.NoError(t, err)
+	require.Error(t, scheme.PopVerify(public1, sig))
+
+	sig2, err = scheme.Sign(private2, msg)
+	require.NoError(t, err)
+	agg, err = scheme.AggregateSignatures(sig1, sig2)
+	require.NoError(t, err)
+	require.NoError(t, scheme.FastAggregateVerify([]kyber.Point{public1, public2}, msg, agg))
+	require.Error(t, scheme.FastAggregateVerify([]kyber.Point{public1}, msg, agg))
+	require.Error(t, scheme.FastAggregateVerify(nil, msg, agg))
+}
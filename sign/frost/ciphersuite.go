@@ -0,0 +1,180 @@
+package frost
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/p256"
+	"go.dedis.ch/kyber/v4/internal/xmd"
+)
+
+// Ciphersuite is the prime order group and the hash functions H1 to H5 that
+// instantiate FROST, as defined in RFC 9591, section 6. Scalars are
+// serialized with their MarshalBinary method.
+type Ciphersuite interface {
+	kyber.Group
+
+	// ContextString returns the ASCII context string of the ciphersuite.
+	ContextString() string
+
+	// SerializeElement returns the canonical encoding of a point, or an
+	// error if the point is the identity element.
+	SerializeElement(p kyber.Point) ([]byte, error)
+
+	// DeserializeElement decodes a point serialized by SerializeElement,
+	// returning an error if the encoding is invalid or not canonical, or if
+	// the point is the identity element or not in the prime order subgroup.
+	DeserializeElement(buf []byte) (kyber.Point, error)
+
+	// H1 derives binding factors.
+	H1(m []byte) kyber.Scalar
+	// H2 derives the challenge of the Schnorr signature.
+	H2(m []byte) kyber.Scalar
+	// H3 derives nonces.
+	H3(m []byte) kyber.Scalar
+	// H4 hashes the message.
+	H4(m []byte) []byte
+	// H5 hashes the list of commitments.
+	H5(m []byte) []byte
+}
+
+var errIdentity = errors.New("frost: cannot serialize the identity element")
+
+type ed25519Suite struct {
+	edwards25519.Curve
+}
+
+// NewEd25519SHA512 returns the FROST(Ed25519, SHA-512) ciphersuite, whose
+// signatures can be verified as regular Ed25519 signatures.
+func NewEd25519SHA512() Ciphersuite {
+	return &ed25519Suite{}
+}
+
+func (s *ed25519Suite) ContextString() string { return "FROST-ED25519-SHA512-v1" }
+
+func (s *ed25519Suite) SerializeElement(p kyber.Point) ([]byte, error) {
+	if p.Equal(s.Point().Null()) {
+		return nil, errIdentity
+	}
+	return p.MarshalBinary()
+}
+
+// DeserializeElement also rejects the points of the other subgroups of
+// edwards25519, whose cofactor is 8, checking that [L]P is the identity.
+func (s *ed25519Suite) DeserializeElement(buf []byte) (kyber.Point, error) {
+	p := s.Point()
+	if err := p.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	if err := checkCanonical(s, p, buf); err != nil {
+		return nil, err
+	}
+	// [L]P = [L-1]P + P
+	q := s.Point().Mul(s.Scalar().SetInt64(-1), p)
+	if !q.Add(q, p).Equal(s.Point().Null()) {
+		return nil, errors.New("frost: point not in the prime order subgroup")
+	}
+	return p, nil
+}
+
+func (s *ed25519Suite) hash(tag string, m []byte) []byte {
+	h := sha512.New()
+	h.Write([]byte(s.ContextString() + tag))
+	h.Write(m)
+	return h.Sum(nil)
+}
+
+func (s *ed25519Suite) H1(m []byte) kyber.Scalar { return s.Scalar().SetBytes(s.hash("rho", m)) }
+
+// H2 has no prefix, for the compatibility with Ed25519 verification.
+func (s *ed25519Suite) H2(m []byte) kyber.Scalar {
+	h := sha512.Sum512(m)
+	return s.Scalar().SetBytes(h[:])
+}
+
+func (s *ed25519Suite) H3(m []byte) kyber.Scalar { return s.Scalar().SetBytes(s.hash("nonce", m)) }
+func (s *ed25519Suite) H4(m []byte) []byte       { return s.hash("msg", m) }
+func (s *ed25519Suite) H5(m []byte) []byte       { return s.hash("com", m) }
+
+// checkCanonical returns an error if p is the identity element or if buf is
+// not its canonical encoding.
+func checkCanonical(s Ciphersuite, p kyber.Point, buf []byte) error {
+	enc, err := s.SerializeElement(p)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(enc, buf) {
+		return errors.New("frost: non canonical point encoding")
+	}
+	return nil
+}
+
+type p256Suite struct {
+	kyber.Group
+}
+
+// NewP256SHA256 returns the FROST(P-256, SHA-256) ciphersuite.
+func NewP256SHA256() Ciphersuite {
+	return &p256Suite{p256.NewBlakeSHA256P256()}
+}
+
+func (s *p256Suite) ContextString() string { return "FROST-P256-SHA256-v1" }
+
+// SerializeElement returns the SEC1 compressed encoding of the point.
+func (s *p256Suite) SerializeElement(p kyber.Point) ([]byte, error) {
+	if p.Equal(s.Point().Null()) {
+		return nil, errIdentity
+	}
+	buf, err := p.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	// buf is the uncompressed encoding 0x04 || x || y
+	coordLen := (len(buf) - 1) / 2
+	out := make([]byte, 1+coordLen)
+	out[0] = 2 | buf[len(buf)-1]&1
+	copy(out[1:], buf[1:1+coordLen])
+	return out, nil
+}
+
+func (s *p256Suite) DeserializeElement(buf []byte) (kyber.Point, error) {
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), buf)
+	if x == nil {
+		return nil, errors.New("frost: invalid compressed point")
+	}
+	p := s.Point()
+	if err := p.UnmarshalBinary(elliptic.Marshal(elliptic.P256(), x, y)); err != nil {
+		return nil, err
+	}
+	return p, checkCanonical(s, p, buf)
+}
+
+// hashToScalar implements hash_to_field from RFC 9380 with
+// expand_message_xmd and SHA-256, for a single element of the scalar field.
+func (s *p256Suite) hashToScalar(tag string, m []byte) kyber.Scalar {
+	order := s.Scalar().GroupOrder()
+	u, err := xmd.HashToField(sha256.New, m, []byte(s.ContextString()+tag), order, 128, 1)
+	if err != nil {
+		// only happens with invalid parameters, which are fixed here
+		panic(err)
+	}
+	return s.Scalar().SetBytes(u[0].Bytes())
+}
+
+func (s *p256Suite) hash(tag string, m []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(s.ContextString() + tag))
+	h.Write(m)
+	return h.Sum(nil)
+}
+
+func (s *p256Suite) H1(m []byte) kyber.Scalar { return s.hashToScalar("rho", m) }
+func (s *p256Suite) H2(m []byte) kyber.Scalar { return s.hashToScalar("chal", m) }
+func (s *p256Suite) H3(m []byte) kyber.Scalar { return s.hashToScalar("nonce", m) }
+func (s *p256Suite) H4(m []byte) []byte       { return s.hash("msg", m) }
+func (s *p256Suite) H5(m []byte) []byte       { return s.hash("com", m) }
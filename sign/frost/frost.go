@@ -0,0 +1,389 @@
+// Package frost implements FROST, the Flexible Round-Optimized Schnorr
+// Threshold signatures of RFC 9591.
+//
+// The signers hold shares of a secret key, typically generated with the
+// share/dkg/pedersen package, and the matching public polynomial. Signing
+// takes two rounds, coordinated by any party:
+//
+//  1. Each signer calls Signer.Commit and sends the Commitment to the
+//     coordinator, keeping the Nonces secret. Commitments do not depend on
+//     the message, so they can be preprocessed in batches.
+//  2. The coordinator picks at least t commitments, sorts them by index and
+//     sends them with the message to the chosen signers, who each return a
+//     signature share from Signer.Sign.
+//
+// The coordinator then combines the shares with Aggregate, which identifies
+// the misbehaving signers if the resulting signature is invalid. Each Nonces
+// can be used for a single signature only.
+package frost
+
+import (
+	"crypto/cipher"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// Commitment is the public counterpart of a pair of nonces, produced by the
+// signer of the given share index in the first round.
+type Commitment struct {
+	Index   uint32
+	Hiding  kyber.Point
+	Binding kyber.Point
+}
+
+// Nonces is the secret pair of nonces of a commitment. It is erased once used
+// to sign.
+type Nonces struct {
+	hiding     kyber.Scalar
+	binding    kyber.Scalar
+	commitment *Commitment
+}
+
+// Commitment returns the commitment to the nonces.
+func (n *Nonces) Commitment() *Commitment {
+	return n.commitment
+}
+
+// InvalidSharesError is returned by Aggregate when some signature shares are
+// invalid, so that their signers can be excluded from the next attempts.
+type InvalidSharesError struct {
+	// Indices are the share indices of the misbehaving signers.
+	Indices []uint32
+}
+
+func (e *InvalidSharesError) Error() string {
+	return fmt.Sprintf("frost: invalid signature shares from %v", e.Indices)
+}
+
+// Signer holds the share of the secret key of a participant.
+type Signer struct {
+	suite Ciphersuite
+	share *share.PriShare
+	pub   *share.PubPoly
+}
+
+// NewSigner returns a signer for the given private share, which must match
+// the public polynomial of the group.
+func NewSigner(suite Ciphersuite, priShare *share.PriShare, pub *share.PubPoly) (*Signer, error) {
+	if !pub.Check(priShare) {
+		return nil, errors.New("frost: private share does not match the public polynomial")
+	}
+	return &Signer{suite: suite, share: priShare, pub: pub}, nil
+}
+
+// Index returns the share index of the signer.
+func (s *Signer) Index() uint32 {
+	return s.share.I
+}
+
+// Commit runs the first round of the protocol, generating fresh nonces from
+// the given source of randomness, or crypto/rand if it is nil, hedged with
+// the secret share.
+func (s *Signer) Commit(rand cipher.Stream) *Nonces {
+	if rand == nil {
+		rand = random.New()
+	}
+	hiding := s.nonce(rand)
+	binding := s.nonce(rand)
+	return &Nonces{
+		hiding:  hiding,
+		binding: binding,
+		commitment: &Commitment{
+			Index:   s.share.I,
+			Hiding:  s.suite.Point().Mul(hiding, nil),
+			Binding: s.suite.Point().Mul(binding, nil),
+		},
+	}
+}
+
+// Preprocess runs the first round of the protocol n times ahead of signing.
+func (s *Signer) Preprocess(n int, rand cipher.Stream) []*Nonces {
+	nonces := make([]*Nonces, n)
+	for i := range nonces {
+		nonces[i] = s.Commit(rand)
+	}
+	return nonces
+}
+
+func (s *Signer) nonce(rand cipher.Stream) kyber.Scalar {
+	buf := make([]byte, 32)
+	random.Bytes(buf, rand)
+	secret, _ := s.share.V.MarshalBinary()
+	return s.suite.H3(append(buf, secret...))
+}
+
+// Sign runs the second round of the protocol, returning the signature share
+// of msg for the given commitments, which must include the commitment of the
+// nonces. The nonces are erased and cannot be used again.
+func (s *Signer) Sign(nonces *Nonces, msg []byte, commitments []*Commitment) (*share.PriShare, error) {
+	if nonces.hiding == nil {
+		return nil, errors.New("frost: nonces already used")
+	}
+	if len(commitments) < s.pub.Threshold() {
+		return nil, errors.New("frost: not enough commitments")
+	}
+	found := false
+	for _, c := range commitments {
+		if c.Index == s.share.I {
+			if !c.Hiding.Equal(nonces.commitment.Hiding) || !c.Binding.Equal(nonces.commitment.Binding) {
+				return nil, errors.New("frost: commitment does not match the nonces")
+			}
+			found = true
+		}
+	}
+	if !found {
+		return nil, errors.New("frost: commitment of the signer is missing")
+	}
+
+	factors, err := bindingFactors(s.suite, s.pub.Commit(), commitments, msg)
+	if err != nil {
+		return nil, err
+	}
+	R := groupCommitment(s.suite, commitments, factors)
+	c, err := challenge(s.suite, R, s.pub.Commit(), msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// z_i = d_i + e_i * rho_i + lambda_i * s_i * c
+	lambda := lagrangeCoefficient(s.suite, s.share.I, commitments)
+	z := s.suite.Scalar().Mul(lambda, s.share.V)
+	z.Mul(z, c)
+	z.Add(z, s.suite.Scalar().Mul(nonces.binding, factors[s.share.I]))
+	z.Add(z, nonces.hiding)
+
+	nonces.hiding.Zero()
+	nonces.binding.Zero()
+	nonces.hiding, nonces.binding = nil, nil
+	return &share.PriShare{I: s.share.I, V: z}, nil
+}
+
+// VerifyShare checks the signature share of msg produced by one of the
+// signers for the given commitments against the public polynomial.
+func VerifyShare(suite Ciphersuite, pub *share.PubPoly, msg []byte, commitments []*Commitment,
+	sigShare *share.PriShare) error {
+	factors, err := bindingFactors(suite, pub.Commit(), commitments, msg)
+	if err != nil {
+		return err
+	}
+	R := groupCommitment(suite, commitments, factors)
+	c, err := challenge(suite, R, pub.Commit(), msg)
+	if err != nil {
+		return err
+	}
+	return verifyShare(suite, pub, commitments, factors, c, sigShare)
+}
+
+func verifyShare(suite Ciphersuite, pub *share.PubPoly, commitments []*Commitment,
+	factors map[uint32]kyber.Scalar, c kyber.Scalar, sigShare *share.PriShare) error {
+	var commitment *Commitment
+	for _, cm := range commitments {
+		if cm.Index == sigShare.I {
+			commitment = cm
+		}
+	}
+	if commitment == nil {
+		return errors.New("frost: no commitment for the signature share")
+	}
+
+	// z_i * G == D_i + E_i * rho_i + PK_i * (c * lambda_i)
+	lambda := lagrangeCoefficient(suite, sigShare.I, commitments)
+	right := suite.Point().Mul(suite.Scalar().Mul(c, lambda), pub.Eval(sigShare.I).V)
+	right.Add(right, suite.Point().Mul(factors[sigShare.I], commitment.Binding))
+	right.Add(right, commitment.Hiding)
+	if !suite.Point().Mul(sigShare.V, nil).Equal(right) {
+		return errors.New("frost: invalid signature share")
+	}
+	return nil
+}
+
+// Aggregate combines the signature shares of msg, one per commitment, into a
+// signature under the public key of the group. If the signature is invalid,
+// it checks every share and returns an *InvalidSharesError naming the
+// signers at fault.
+func Aggregate(suite Ciphersuite, pub *share.PubPoly, msg []byte, commitments []*Commitment,
+	sigShares []*share.PriShare) ([]byte, error) {
+	if len(commitments) < pub.Threshold() {
+		return nil, errors.New("frost: not enough commitments")
+	}
+	if len(sigShares) != len(commitments) {
+		return nil, errors.New("frost: number of signature shares and commitments must match")
+	}
+	factors, err := bindingFactors(suite, pub.Commit(), commitments, msg)
+	if err != nil {
+		return nil, err
+	}
+	R := groupCommitment(suite, commitments, factors)
+
+	z := suite.Scalar().Zero()
+	for _, sigShare := range sigShares {
+		z.Add(z, sigShare.V)
+	}
+	sig, err := encodeSignature(suite, R, z)
+	if err != nil {
+		return nil, err
+	}
+	if Verify(suite, pub.Commit(), msg, sig) == nil {
+		return sig, nil
+	}
+
+	c, err := challenge(suite, R, pub.Commit(), msg)
+	if err != nil {
+		return nil, err
+	}
+	culprits := &InvalidSharesError{}
+	for _, sigShare := range sigShares {
+		if verifyShare(suite, pub, commitments, factors, c, sigShare) != nil {
+			culprits.Indices = append(culprits.Indices, sigShare.I)
+		}
+	}
+	if len(culprits.Indices) == 0 {
+		return nil, errors.New("frost: invalid signature")
+	}
+	return nil, culprits
+}
+
+// Verify checks the signature of msg under the public key of the group. The
+// signature is the serialized commitment R followed by the serialized
+// scalar z.
+func Verify(suite Ciphersuite, public kyber.Point, msg, sig []byte) error {
+	R, z, err := decodeSignature(suite, sig)
+	if err != nil {
+		return err
+	}
+	c, err := challenge(suite, R, public, msg)
+	if err != nil {
+		return err
+	}
+	// z * G == R + c * PK
+	right := suite.Point().Mul(c, public)
+	right.Add(right, R)
+	if !suite.Point().Mul(z, nil).Equal(right) {
+		return errors.New("frost: invalid signature")
+	}
+	return nil
+}
+
+func encodeSignature(suite Ciphersuite, R kyber.Point, z kyber.Scalar) ([]byte, error) {
+	buf, err := suite.SerializeElement(R)
+	if err != nil {
+		return nil, err
+	}
+	zBuf, err := z.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, zBuf...), nil
+}
+
+func decodeSignature(suite Ciphersuite, sig []byte) (kyber.Point, kyber.Scalar, error) {
+	rLen := len(sig) - suite.ScalarLen()
+	if rLen <= 0 {
+		return nil, nil, errors.New("frost: signature too short")
+	}
+	R, err := suite.DeserializeElement(sig[:rLen])
+	if err != nil {
+		return nil, nil, err
+	}
+	z := suite.Scalar()
+	if err := z.UnmarshalBinary(sig[rLen:]); err != nil {
+		return nil, nil, err
+	}
+	return R, z, nil
+}
+
+// bindingFactors returns the binding factor of each commitment by index,
+// after checking that the commitments are sorted by strictly increasing
+// index.
+func bindingFactors(suite Ciphersuite, public kyber.Point, commitments []*Commitment,
+	msg []byte) (map[uint32]kyber.Scalar, error) {
+	if len(commitments) == 0 {
+		return nil, errors.New("frost: no commitment")
+	}
+	var list []byte
+	for i, c := range commitments {
+		if i > 0 && c.Index <= commitments[i-1].Index {
+			return nil, errors.New("frost: commitments must be sorted by distinct index")
+		}
+		id, err := identifier(suite, c.Index).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		hiding, err := suite.SerializeElement(c.Hiding)
+		if err != nil {
+			return nil, err
+		}
+		binding, err := suite.SerializeElement(c.Binding)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, id...)
+		list = append(list, hiding...)
+		list = append(list, binding...)
+	}
+
+	publicBuf, err := suite.SerializeElement(public)
+	if err != nil {
+		return nil, err
+	}
+	prefix := append(publicBuf, suite.H4(msg)...)
+	prefix = append(prefix, suite.H5(list)...)
+
+	factors := make(map[uint32]kyber.Scalar, len(commitments))
+	for _, c := range commitments {
+		id, _ := identifier(suite, c.Index).MarshalBinary()
+		input := append(append([]byte{}, prefix...), id...)
+		factors[c.Index] = suite.H1(input)
+	}
+	return factors, nil
+}
+
+// groupCommitment returns R, the sum of D_i + E_i * rho_i.
+func groupCommitment(suite Ciphersuite, commitments []*Commitment, factors map[uint32]kyber.Scalar) kyber.Point {
+	R := suite.Point().Null()
+	for _, c := range commitments {
+		R.Add(R, c.Hiding)
+		R.Add(R, suite.Point().Mul(factors[c.Index], c.Binding))
+	}
+	return R
+}
+
+func challenge(suite Ciphersuite, R, public kyber.Point, msg []byte) (kyber.Scalar, error) {
+	rBuf, err := suite.SerializeElement(R)
+	if err != nil {
+		return nil, err
+	}
+	publicBuf, err := suite.SerializeElement(public)
+	if err != nil {
+		return nil, err
+	}
+	input := append(rBuf, publicBuf...)
+	return suite.H2(append(input, msg...)), nil
+}
+
+// identifier returns the FROST identifier of a share index, i.e. the point at
+// which the secret polynomial is evaluated.
+func identifier(suite Ciphersuite, index uint32) kyber.Scalar {
+	return suite.Scalar().SetInt64(int64(index) + 1)
+}
+
+// lagrangeCoefficient returns the Lagrange coefficient at 0 of the signer of
+// the given index among the signers of the commitments.
+func lagrangeCoefficient(suite Ciphersuite, index uint32, commitments []*Commitment) kyber.Scalar {
+	xi := identifier(suite, index)
+	num := suite.Scalar().One()
+	den := suite.Scalar().One()
+	for _, c := range commitments {
+		if c.Index == index {
+			continue
+		}
+		xj := identifier(suite, c.Index)
+		num.Mul(num, xj)
+		den.Mul(den, suite.Scalar().Sub(xj, xi))
+	}
+	return num.Div(num, den)
+}
@@ -0,0 +1,344 @@
+package frost
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+const n, t = 5, 3
+
+var msg = []byte("Hello FROST")
+
+func newSigners(tt *testing.T, suite Ciphersuite) ([]*Signer, *share.PubPoly) {
+	poly := share.NewPriPoly(suite, t, nil, random.New())
+	pub := poly.Commit(nil)
+	signers := make([]*Signer, n)
+	for i, priShare := range poly.Shares(n) {
+		var err error
+		signers[i], err = NewSigner(suite, priShare, pub)
+		require.NoError(tt, err)
+	}
+	return signers, pub
+}
+
+// sign runs both rounds with the given signers, returning the commitments
+// and the signature shares.
+func sign(tt *testing.T, signers []*Signer) ([]*Commitment, []*share.PriShare) {
+	nonces := make([]*Nonces, len(signers))
+	commitments := make([]*Commitment, len(signers))
+	for i, s := range signers {
+		nonces[i] = s.Commit(nil)
+		commitments[i] = nonces[i].Commitment()
+	}
+	sigShares := make([]*share.PriShare, len(signers))
+	for i, s := range signers {
+		var err error
+		sigShares[i], err = s.Sign(nonces[i], msg, commitments)
+		require.NoError(tt, err)
+	}
+	return commitments, sigShares
+}
+
+func TestFROST(tt *testing.T) {
+	for _, suite := range []Ciphersuite{NewEd25519SHA512(), NewP256SHA256()} {
+		tt.Run(suite.ContextString(), func(tt *testing.T) {
+			signers, pub := newSigners(tt, suite)
+			chosen := []*Signer{signers[0], signers[2], signers[4]}
+			commitments, sigShares := sign(tt, chosen)
+			for _, sigShare := range sigShares {
+				require.NoError(tt, VerifyShare(suite, pub, msg, commitments, sigShare))
+			}
+
+			sig, err := Aggregate(suite, pub, msg, commitments, sigShares)
+			require.NoError(tt, err)
+			require.NoError(tt, Verify(suite, pub.Commit(), msg, sig))
+			require.Error(tt, Verify(suite, pub.Commit(), []byte("other"), sig))
+			require.Error(tt, Verify(suite, pub.Commit(), msg, sig[:suite.ScalarLen()]))
+
+			// all the signers
+			commitments, sigShares = sign(tt, signers)
+			sig, err = Aggregate(suite, pub, msg, commitments, sigShares)
+			require.NoError(tt, err)
+			require.NoError(tt, Verify(suite, pub.Commit(), msg, sig))
+		})
+	}
+}
+
+func TestFROSTEd25519Compatibility(tt *testing.T) {
+	suite := NewEd25519SHA512()
+	signers, pub := newSigners(tt, suite)
+	commitments, sigShares := sign(tt, signers[1:4])
+	sig, err := Aggregate(suite, pub, msg, commitments, sigShares)
+	require.NoError(tt, err)
+
+	public, err := pub.Commit().MarshalBinary()
+	require.NoError(tt, err)
+	require.True(tt, ed25519.Verify(public, msg, sig))
+}
+
+func TestFROSTIdentifiableAbort(tt *testing.T) {
+	suite := NewP256SHA256()
+	signers, pub := newSigners(tt, suite)
+	commitments, sigShares := sign(tt, signers[:4])
+
+	sigShares[1].V = suite.Scalar().Pick(random.New())
+	sigShares[3].V = suite.Scalar().Pick(random.New())
+	require.Error(tt, VerifyShare(suite, pub, msg, commitments, sigShares[1]))
+	require.NoError(tt, VerifyShare(suite, pub, msg, commitments, sigShares[2]))
+
+	_, err := Aggregate(suite, pub, msg, commitments, sigShares)
+	var invalid *InvalidSharesError
+	require.True(tt, errors.As(err, &invalid))
+	require.Equal(tt, []uint32{signers[1].Index(), signers[3].Index()}, invalid.Indices)
+}
+
+func TestFROSTInvalidRounds(tt *testing.T) {
+	suite := NewEd25519SHA512()
+	signers, pub := newSigners(tt, suite)
+
+	nonces := signers[0].Preprocess(2, nil)
+	others := []*Nonces{signers[1].Commit(nil), signers[2].Commit(nil)}
+	commitments := []*Commitment{nonces[0].Commitment(), others[0].Commitment(), others[1].Commitment()}
+
+	// not enough signers
+	_, err := signers[0].Sign(nonces[0], msg, commitments[:2])
+	require.Error(tt, err)
+	// unsorted commitments
+	_, err = signers[0].Sign(nonces[0], msg, []*Commitment{commitments[1], commitments[0], commitments[2]})
+	require.Error(tt, err)
+	// duplicate commitments
+	_, err = signers[0].Sign(nonces[0], msg, []*Commitment{commitments[0], commitments[1], commitments[1]})
+	require.Error(tt, err)
+	// commitment of other nonces
+	_, err = signers[0].Sign(nonces[1], msg, commitments)
+	require.Error(tt, err)
+	// missing commitment
+	_, err = signers[3].Sign(signers[3].Commit(nil), msg, commitments)
+	require.Error(tt, err)
+
+	_, err = signers[0].Sign(nonces[0], msg, commitments)
+	require.NoError(tt, err)
+	// nonces cannot be reused
+	_, err = signers[0].Sign(nonces[0], msg, commitments)
+	require.Error(tt, err)
+
+	// shares must match the public polynomial
+	_, err = NewSigner(suite, &share.PriShare{I: 0, V: suite.Scalar().One()}, pub)
+	require.Error(tt, err)
+}
+
+func TestSerializeElement(tt *testing.T) {
+	for _, suite := range []Ciphersuite{NewEd25519SHA512(), NewP256SHA256()} {
+		for i := 0; i < 10; i++ {
+			p := suite.Point().Pick(random.New())
+			buf, err := suite.SerializeElement(p)
+			require.NoError(tt, err)
+			q, err := suite.DeserializeElement(buf)
+			require.NoError(tt, err)
+			require.True(tt, p.Equal(q))
+		}
+
+		_, err := suite.SerializeElement(suite.Point().Null())
+		require.Error(tt, err)
+		_, err = suite.DeserializeElement([]byte{1, 2, 3})
+		require.Error(tt, err)
+	}
+
+	// the identity of edwards25519 decodes but is rejected
+	suite := NewEd25519SHA512()
+	identity, err := suite.Point().Null().MarshalBinary()
+	require.NoError(tt, err)
+	_, err = suite.DeserializeElement(identity)
+	require.Error(tt, err)
+
+	// points of small order, and points with a small order component
+	order2, order4 := make([]byte, 32), make([]byte, 32)
+	for i := range order2 {
+		order2[i] = 0xff
+	}
+	order2[0], order2[31] = 0xec, 0x7f
+	for _, buf := range [][]byte{order2, order4} {
+		_, err = suite.DeserializeElement(buf)
+		require.Error(tt, err)
+
+		torsion := suite.Point()
+		require.NoError(tt, torsion.UnmarshalBinary(buf))
+		p := suite.Point().Pick(random.New())
+		mixed, err := p.Add(p, torsion).MarshalBinary()
+		require.NoError(tt, err)
+		_, err = suite.DeserializeElement(mixed)
+		require.Error(tt, err)
+	}
+}
+
+// fixedStream returns the given bytes as a key stream.
+type fixedStream struct {
+	buf []byte
+}
+
+func (f *fixedStream) XORKeyStream(dst, src []byte) {
+	for i := range dst {
+		dst[i] = src[i] ^ f.buf[i]
+	}
+	f.buf = f.buf[len(dst):]
+}
+
+// katParticipant is the signer of a test vector, with the randomness of its
+// nonces and the values it produces.
+type katParticipant struct {
+	index          uint32
+	share          string
+	randomness     string // hiding || binding nonce randomness
+	hiding         string
+	binding        string
+	bindingFactor  string
+	signatureShare string
+}
+
+// katVector is a test vector of RFC 9591, Appendix E, with a threshold of 2
+// out of 3 signers and the participants 1 and 3.
+type katVector struct {
+	suite        Ciphersuite
+	secret       string
+	coefficient  string
+	public       string
+	msg          string
+	participants []katParticipant
+	sig          string
+}
+
+var katVectors = []katVector{
+	{
+		suite:       NewEd25519SHA512(),
+		secret:      "7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304",
+		coefficient: "178199860edd8c62f5212ee91eff1295d0d670ab4ed4506866bae57e7030b204",
+		public:      "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673",
+		msg:         "74657374",
+		participants: []katParticipant{
+			{
+				index: 1,
+				share: "929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509",
+				randomness: "0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec" +
+					"69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+				hiding:         "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3",
+				binding:        "67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932",
+				bindingFactor:  "f2cb9d7dd9beff688da6fcc83fa89046b3479417f47f55600b106760eb3b5603",
+				signatureShare: "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+			},
+			{
+				index: 3,
+				share: "d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02",
+				randomness: "86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f" +
+					"13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+				hiding:         "cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91",
+				binding:        "7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552",
+				bindingFactor:  "b087686bf35a13f3dc78e780a34b0fe8a77fef1b9938c563f5573d71d8d7890f",
+				signatureShare: "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+			},
+		},
+		sig: "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe" +
+			"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b",
+	},
+	{
+		suite:       NewP256SHA256(),
+		secret:      "8ba9bba2e0fd8c4767154d35a0b7562244a4aaf6f36c8fb8735fa48b301bd8de",
+		coefficient: "80f25e6c0709353e46bfbe882a11bdbb1f8097e46340eb8673b7e14556e6c3a4",
+		public:      "023a309ad94e9fe8a7ba45dfc58f38bf091959d3c99cfbd02b4dc00585ec45ab70",
+		msg:         "74657374",
+		participants: []katParticipant{
+			{
+				index: 1,
+				share: "0c9c1a0fe806c184add50bbdcac913dda73e482daf95dcb9f35dbb0d8a9f7731",
+				randomness: "ec4c891c85fee802a9d757a67d1252e7f4e5efb8a538991ac18fbd0e06fb6fd3" +
+					"9334e29d09061223f69a09421715a347e4e6deba77444c8f42b0c833f80f4ef9",
+				hiding:         "0213b3e6298bf8ad46fd5e9389519a8665d63d98f4ec6a1fcca434e809d2d8070e",
+				binding:        "02188ff1390bf69374d7b272e454b1878ef10a6b6ea3ff36f114b300b4dbd5233b",
+				bindingFactor:  "7925f0d4693f204e6e59233e92227c7124664a99739d2c06b81cf64ddf90559e",
+				signatureShare: "400308eaed7a2ddee02a265abe6a1cfe04d946ee8720768899619cfabe7a3aeb",
+			},
+			{
+				index: 3,
+				share: "0e80d6e8f6192c003b5488ce1eec8f5429587d48cf001541e713b2d53c09d928",
+				randomness: "c0451c5a0a5480d6c1f860e5db7d655233dca2669fd90ff048454b8ce983367b" +
+					"2ba5f7793ae700e40e78937a82f407dd35e847e33d1e607b5c7eb6ed2a8ed799",
+				hiding:         "033ac9a5fe4a8b57316ba1c34e8a6de453033b750e8984924a984eb67a11e73a3f",
+				binding:        "03a7a2480ee16199262e648aea3acab628a53e9b8c1945078f2ddfbdc98b7df369",
+				bindingFactor:  "e10d24a8a403723bcb6f9bb4c537f316593683b472f7a89f166630dde11822c4",
+				signatureShare: "561da3c179edbb0502d941bb3e3ace3c37d122aaa46fb54499f15f3a3331de44",
+			},
+		},
+		sig: "026d8d434874f87bdb7bc0dfd239b2c00639044f9dcb195e9a04426f70bfa4b70d" +
+			"9620acac6767e8e3e3036815fca4eb3a3caa69992b902bcd3352fc34f1ac192f",
+	},
+}
+
+func decodeHex(tt *testing.T, s string) []byte {
+	buf, err := hex.DecodeString(s)
+	require.NoError(tt, err)
+	return buf
+}
+
+func encodeHex(tt *testing.T, m interface{ MarshalBinary() ([]byte, error) }) string {
+	buf, err := m.MarshalBinary()
+	require.NoError(tt, err)
+	return hex.EncodeToString(buf)
+}
+
+func TestFROSTVectors(tt *testing.T) {
+	for _, v := range katVectors {
+		tt.Run(v.suite.ContextString(), func(tt *testing.T) {
+			suite := v.suite
+			secret, coefficient := suite.Scalar(), suite.Scalar()
+			require.NoError(tt, secret.UnmarshalBinary(decodeHex(tt, v.secret)))
+			require.NoError(tt, coefficient.UnmarshalBinary(decodeHex(tt, v.coefficient)))
+			poly := share.CoefficientsToPriPoly(suite, []kyber.Scalar{secret, coefficient})
+			pub := poly.Commit(nil)
+			public, err := suite.SerializeElement(pub.Commit())
+			require.NoError(tt, err)
+			require.Equal(tt, v.public, hex.EncodeToString(public))
+
+			msg := decodeHex(tt, v.msg)
+			signers := make([]*Signer, len(v.participants))
+			nonces := make([]*Nonces, len(v.participants))
+			commitments := make([]*Commitment, len(v.participants))
+			for i, p := range v.participants {
+				// the participant identifiers are the share indices plus one
+				priShare := poly.Eval(p.index - 1)
+				require.Equal(tt, p.share, encodeHex(tt, priShare.V))
+				signers[i], err = NewSigner(suite, priShare, pub)
+				require.NoError(tt, err)
+
+				nonces[i] = signers[i].Commit(&fixedStream{decodeHex(tt, p.randomness)})
+				commitments[i] = nonces[i].Commitment()
+				hiding, err := suite.SerializeElement(commitments[i].Hiding)
+				require.NoError(tt, err)
+				require.Equal(tt, p.hiding, hex.EncodeToString(hiding))
+				binding, err := suite.SerializeElement(commitments[i].Binding)
+				require.NoError(tt, err)
+				require.Equal(tt, p.binding, hex.EncodeToString(binding))
+			}
+
+			factors, err := bindingFactors(suite, pub.Commit(), commitments, msg)
+			require.NoError(tt, err)
+			sigShares := make([]*share.PriShare, len(v.participants))
+			for i, p := range v.participants {
+				require.Equal(tt, p.bindingFactor, encodeHex(tt, factors[p.index-1]))
+				sigShares[i], err = signers[i].Sign(nonces[i], msg, commitments)
+				require.NoError(tt, err)
+				require.Equal(tt, p.signatureShare, encodeHex(tt, sigShares[i].V))
+			}
+
+			sig, err := Aggregate(suite, pub, msg, commitments, sigShares)
+			require.NoError(tt, err)
+			require.Equal(tt, v.sig, hex.EncodeToString(sig))
+			require.NoError(tt, Verify(suite, pub.Commit(), msg, decodeHex(tt, v.sig)))
+		})
+	}
+}
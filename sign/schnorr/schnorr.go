@@ -17,9 +17,11 @@ import (
 	"crypto/sha512"
 	"errors"
 	"fmt"
+	"sync"
 
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/util/precomp"
 )
 
 // Suite represents the set of functionalities needed by the package schnorr.
@@ -30,10 +32,13 @@ type Suite interface {
 
 type Scheme struct {
 	s Suite
+
+	baseOnce sync.Once
+	base     kyber.PrecomputedPoint
 }
 
 func NewScheme(s Suite) sign.Scheme {
-	return &Scheme{s}
+	return &Scheme{s: s}
 }
 
 func (s *Scheme) NewKeyPair(random cipher.Stream) (kyber.Scalar, kyber.Point) {
@@ -46,8 +51,27 @@ func (s *Scheme) Sign(private kyber.Scalar, msg []byte) ([]byte, error) {
 	return Sign(s.s, private, msg)
 }
 
+// Verify checks the signature like the Verify function, using a table of
+// multiples of the base point built on the first call.
 func (s *Scheme) Verify(public kyber.Point, msg, sig []byte) error {
-	return Verify(s.s, public, msg, sig)
+	PBuf, err := public.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error unmarshalling public key: %w", err)
+	}
+	return verifyWithChecks(s.s, s.precomputedBase(), PBuf, msg, sig)
+}
+
+// precomputedBase returns the table of multiples of the base point of the
+// scheme. It is only ever multiplied by the public part of signatures, so
+// variable time is allowed on it.
+func (s *Scheme) precomputedBase() kyber.PrecomputedPoint {
+	s.baseOnce.Do(func() {
+		s.base = precomp.Precompute(s.s, s.s.Point().Base())
+		if vt, ok := s.base.(kyber.AllowsVarTime); ok {
+			vt.AllowVarTime(true)
+		}
+	})
+	return s.base
 }
 
 // Sign creates a Sign signature from a msg and a private key. This
@@ -87,6 +111,12 @@ func Sign(s Suite, private kyber.Scalar, msg []byte) ([]byte, error) {
 // additional checks around the canonicality and ensures the public key
 // does not have a small order when using `edwards25519` group.
 func VerifyWithChecks(g kyber.Group, pub, msg, sig []byte) error {
+	return verifyWithChecks(g, nil, pub, msg, sig)
+}
+
+// verifyWithChecks implements VerifyWithChecks, using base if not nil to
+// multiply the base point.
+func verifyWithChecks(g kyber.Group, base kyber.PrecomputedPoint, pub, msg, sig []byte) error {
 	type scalarCanCheckCanonical interface {
 		IsCanonical(b []byte) bool
 	}
@@ -145,7 +175,12 @@ func VerifyWithChecks(g kyber.Group, pub, msg, sig []byte) error {
 	}
 
 	// compute S = g^s
-	S := g.Point().Mul(s, nil)
+	var S kyber.Point
+	if base != nil {
+		S = base.Mul(s)
+	} else {
+		S = g.Point().Mul(s, nil)
+	}
 	// compute RAh = R + A^h
 	Ah := g.Point().Mul(h, public)
 	RAs := g.Point().Add(R, Ah)
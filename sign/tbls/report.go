@@ -0,0 +1,171 @@
+package tbls
+
+import (
+	"errors"
+
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/sign/bls"
+)
+
+// Verdict is the outcome of the checks of a partial signature by
+// RecoverWithReport.
+type Verdict int
+
+const (
+	// Unverified partials are well-formed but were not needed to recover the
+	// signature.
+	Unverified Verdict = iota
+	// Valid partials were verified, or used to recover a valid signature.
+	Valid
+	// Malformed partials cannot be decoded.
+	Malformed
+	// BadIndex partials have an index out of the range of the n shares.
+	BadIndex
+	// Invalid partials do not verify against the public share of their
+	// index.
+	Invalid
+	// Duplicate partials have the index of a valid partial used before them.
+	Duplicate
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Unverified:
+		return "unverified"
+	case Valid:
+		return "valid"
+	case Malformed:
+		return "malformed"
+	case BadIndex:
+		return "bad index"
+	case Invalid:
+		return "invalid"
+	case Duplicate:
+		return "duplicate"
+	default:
+		return "unknown"
+	}
+}
+
+// partial is a well-formed partial signature at the position pos of the
+// partials given to RecoverWithReport.
+type partial struct {
+	pos   int
+	share *share.PubShare
+	sig   []byte
+}
+
+// RecoverWithReport recovers the full BLS signature like Recover, and returns
+// the verdicts on the partial signatures, in the order of sigs.
+//
+// It optimistically recovers the signature from the first t well-formed
+// partials of distinct indexes without verifying them. Only if the recovered
+// signature is invalid, it verifies all the partials in a batch to identify
+// the invalid ones and recovers the signature from t valid ones. The verdicts
+// are also returned with the error when there are not enough valid partials.
+func (s *scheme) RecoverWithReport(public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, []Verdict, error) {
+	verdicts := make([]Verdict, len(sigs))
+	var partials []partial
+	for k, sig := range sigs {
+		i, err := s.IndexOf(sig)
+		if err != nil {
+			verdicts[k] = Malformed
+			continue
+		}
+		sh := SigShare(sig)
+		point := s.sigGroup.Point()
+		if err := point.UnmarshalBinary(sh.Value()); err != nil {
+			verdicts[k] = Malformed
+			continue
+		}
+		if i >= n {
+			verdicts[k] = BadIndex
+			continue
+		}
+		partials = append(partials, partial{pos: k, share: &share.PubShare{I: uint32(i), V: point}, sig: sh.Value()})
+	}
+
+	if first := distinctIndexes(partials, t); len(first) == t {
+		if sig, err := s.recoverPartials(public, msg, first, t, n); err == nil {
+			markValid(verdicts, partials, first)
+			return sig, verdicts, nil
+		}
+	}
+
+	entries := make([]bls.BatchEntry, len(partials))
+	for k, p := range partials {
+		entries[k] = bls.BatchEntry{Public: public.Eval(p.share.I).V, Msg: msg, Sig: p.sig}
+	}
+	invalid, err := s.batch.Verify(entries)
+	if err != nil {
+		return nil, verdicts, err
+	}
+	var valid []partial
+	for k, p := range partials {
+		if len(invalid) > 0 && invalid[0] == k {
+			invalid = invalid[1:]
+			verdicts[p.pos] = Invalid
+			continue
+		}
+		valid = append(valid, p)
+	}
+	valid = distinctIndexes(valid, len(valid))
+	markValid(verdicts, partials, valid)
+	if len(valid) < t {
+		return nil, verdicts, errors.New("not enough valid partial signatures")
+	}
+	sig, err := s.recoverPartials(public, msg, valid[:t], t, n)
+	return sig, verdicts, err
+}
+
+// recoverPartials recovers the signature from the partials and checks it.
+func (s *scheme) recoverPartials(public *share.PubPoly, msg []byte, partials []partial, t, n int) ([]byte, error) {
+	shares := make([]*share.PubShare, len(partials))
+	for k, p := range partials {
+		shares[k] = p.share
+	}
+	commit, err := share.RecoverCommit(s.sigGroup, shares, t, n)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := commit.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.VerifyRecovered(public.Commit(), msg, sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// distinctIndexes returns the first limit partials of distinct indexes.
+func distinctIndexes(partials []partial, limit int) []partial {
+	seen := make(map[uint32]bool)
+	var distinct []partial
+	for _, p := range partials {
+		if len(distinct) == limit {
+			break
+		}
+		if seen[p.share.I] {
+			continue
+		}
+		seen[p.share.I] = true
+		distinct = append(distinct, p)
+	}
+	return distinct
+}
+
+// markValid marks the used partials as valid, and the other partials of
+// their indexes that are not invalid as duplicates.
+func markValid(verdicts []Verdict, partials, used []partial) {
+	usedIndexes := make(map[uint32]bool)
+	for _, p := range used {
+		verdicts[p.pos] = Valid
+		usedIndexes[p.share.I] = true
+	}
+	for _, p := range partials {
+		if usedIndexes[p.share.I] && verdicts[p.pos] == Unverified {
+			verdicts[p.pos] = Duplicate
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package tbls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/pairing/bn256"
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+func TestRecoverWithReport(t *testing.T) {
+	suite := bn256.NewSuite()
+	scheme := NewThresholdSchemeOnG1(suite)
+	msg := []byte("Hello threshold Boneh-Lynn-Shacham")
+	n, th := 7, 4
+
+	priPoly := share.NewPriPoly(suite.G2(), th, nil, random.New())
+	pubPoly := priPoly.Commit(suite.G2().Point().Base())
+	shares := priPoly.Shares(n)
+	signPartial := func(x *share.PriShare) []byte {
+		sig, err := scheme.Sign(x, msg)
+		require.NoError(t, err)
+		return sig
+	}
+	var partials [][]byte
+	for _, x := range shares {
+		partials = append(partials, signPartial(x))
+	}
+
+	t.Run("all valid", func(t *testing.T) {
+		sig, verdicts, err := scheme.RecoverWithReport(pubPoly, msg, partials, th, n)
+		require.NoError(t, err)
+		require.NoError(t, scheme.VerifyRecovered(pubPoly.Commit(), msg, sig))
+		require.Equal(t, []Verdict{Valid, Valid, Valid, Valid, Unverified, Unverified, Unverified}, verdicts)
+	})
+
+	t.Run("invalid partials", func(t *testing.T) {
+		fake := share.NewPriPoly(suite.G2(), th, nil, random.New()).Shares(n)
+		outOfRange := &share.PriShare{I: uint32(n + 2), V: shares[0].V}
+		sigs := [][]byte{
+			[]byte("ain't no sunshine"),
+			partials[0],
+			signPartial(fake[1]),
+			partials[1],
+			signPartial(outOfRange),
+			partials[2],
+			partials[0],
+			partials[3],
+			partials[4],
+		}
+		sig, verdicts, err := scheme.RecoverWithReport(pubPoly, msg, sigs, th, n)
+		require.NoError(t, err)
+		require.NoError(t, scheme.VerifyRecovered(pubPoly.Commit(), msg, sig))
+		require.Equal(t, []Verdict{Malformed, Valid, Invalid, Valid, BadIndex, Valid, Duplicate, Valid, Valid}, verdicts)
+		require.Equal(t, "bad index", verdicts[4].String())
+	})
+
+	t.Run("not enough valid partials", func(t *testing.T) {
+		fake := share.NewPriPoly(suite.G2(), th, nil, random.New()).Shares(n)
+		sigs := [][]byte{partials[0], signPartial(fake[1]), partials[2], partials[3], {1, 2, 3}}
+		_, verdicts, err := scheme.RecoverWithReport(pubPoly, msg, sigs, th, n)
+		require.Error(t, err)
+		require.Equal(t, []Verdict{Valid, Invalid, Valid, Valid, Malformed}, verdicts)
+	})
+}
@@ -46,25 +46,35 @@ func (s *SigShare) Value() []byte {
 type scheme struct {
 	keyGroup kyber.Group
 	sigGroup kyber.Group
+	batch    *bls.BatchVerifier
 	sign.Scheme
 }
 
+// ThresholdScheme is the sign.ThresholdScheme of this package, whose
+// recovery can also report the invalid partial signatures.
+type ThresholdScheme interface {
+	sign.ThresholdScheme
+	RecoverWithReport(public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, []Verdict, error)
+}
+
 // NewThresholdSchemeOnG1 returns a treshold scheme that computes bls signatures
 // on G1
-func NewThresholdSchemeOnG1(suite pairing.Suite) sign.ThresholdScheme {
+func NewThresholdSchemeOnG1(suite pairing.Suite) ThresholdScheme {
 	return &scheme{
 		keyGroup: suite.G2(),
 		sigGroup: suite.G1(),
+		batch:    bls.NewBatchVerifierOnG1(suite),
 		Scheme:   bls.NewSchemeOnG1(suite),
 	}
 }
 
 // NewThresholdSchemeOnG2 returns a treshold scheme that computes bls signatures
 // on G2
-func NewThresholdSchemeOnG2(suite pairing.Suite) sign.ThresholdScheme {
+func NewThresholdSchemeOnG2(suite pairing.Suite) ThresholdScheme {
 	return &scheme{
 		keyGroup: suite.G1(),
 		sigGroup: suite.G2(),
+		batch:    bls.NewBatchVerifierOnG2(suite),
 		Scheme:   bls.NewSchemeOnG2(suite),
 	}
 }
@@ -116,25 +126,28 @@ func (s *scheme) VerifyRecovered(public kyber.Point, msg, sig []byte) error {
 // shared public key X. The shared public key can be computed by evaluating the
 // public sharing polynomial at index 0.
 func (s *scheme) Recover(public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, error) {
-	var pubShares []*share.PubShare
+	var candidates []SigShare
 	for _, sig := range sigs {
-		sh := SigShare(sig)
-		i, err := sh.Index()
-		if err != nil {
+		if _, err := SigShare(sig).Index(); err != nil {
 			continue
 		}
-		idx := uint32(i)
-		if err = s.Scheme.Verify(public.Eval(idx).V, msg, sh.Value()); err != nil {
-			continue
-		}
-		point := s.sigGroup.Point()
-		if err := point.UnmarshalBinary(sh.Value()); err != nil {
-			continue
+		candidates = append(candidates, sig)
+	}
+
+	// the partials are verified in batches of as many as are still missing,
+	// so that the valid ones cost a single verification together
+	var pubShares []*share.PubShare
+	for len(pubShares) < t && len(candidates) > 0 {
+		batch := candidates
+		if missing := t - len(pubShares); len(batch) > missing {
+			batch = batch[:missing]
 		}
-		pubShares = append(pubShares, &share.PubShare{I: idx, V: point})
-		if len(pubShares) >= t {
-			break
+		candidates = candidates[len(batch):]
+		valid, err := s.verifyPartials(public, msg, batch)
+		if err != nil {
+			return nil, err
 		}
+		pubShares = append(pubShares, valid...)
 	}
 	if len(pubShares) < t {
 		return nil, errors.New("not enough valid partial signatures")
@@ -149,3 +162,32 @@ func (s *scheme) Recover(public *share.PubPoly, msg []byte, sigs [][]byte, t, n
 	}
 	return sig, nil
 }
+
+// verifyPartials batch verifies the partial signatures, whose index is
+// well-formed, and returns the valid ones.
+func (s *scheme) verifyPartials(public *share.PubPoly, msg []byte, partials []SigShare) ([]*share.PubShare, error) {
+	entries := make([]bls.BatchEntry, len(partials))
+	for k, sh := range partials {
+		i, _ := sh.Index()
+		entries[k] = bls.BatchEntry{Public: public.Eval(uint32(i)).V, Msg: msg, Sig: sh.Value()}
+	}
+	invalid, err := s.batch.Verify(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var valid []*share.PubShare
+	for k, sh := range partials {
+		if len(invalid) > 0 && invalid[0] == k {
+			invalid = invalid[1:]
+			continue
+		}
+		i, _ := sh.Index()
+		point := s.sigGroup.Point()
+		if err := point.UnmarshalBinary(sh.Value()); err != nil {
+			continue
+		}
+		valid = append(valid, &share.PubShare{I: uint32(i), V: point})
+	}
+	return valid, nil
+}
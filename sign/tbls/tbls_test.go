@@ -7,6 +7,7 @@ import (
 	"go.dedis.ch/kyber/v4/internal/test"
 	"go.dedis.ch/kyber/v4/pairing/bn256"
 	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/util/random"
 	"go.dedis.ch/kyber/v4/xof/blake2xb"
 )
 
@@ -56,3 +57,32 @@ func TestBN256(t *testing.T) {
 	scheme := NewThresholdSchemeOnG1(suite)
 	test.ThresholdTest(t, suite.G2(), scheme)
 }
+
+func TestRecoverInvalidPartials(t *testing.T) {
+	suite := bn256.NewSuite()
+	scheme := NewThresholdSchemeOnG1(suite)
+	msg := []byte("Hello threshold Boneh-Lynn-Shacham")
+	n, th := 7, 4
+
+	priPoly := share.NewPriPoly(suite.G2(), th, nil, random.New())
+	pubPoly := priPoly.Commit(suite.G2().Point().Base())
+	fakePoly := share.NewPriPoly(suite.G2(), th, nil, random.New())
+	var sigShares [][]byte
+	for i, x := range priPoly.Shares(n) {
+		if i == 0 || i == 2 {
+			x = fakePoly.Shares(n)[i]
+		}
+		sig, err := scheme.Sign(x, msg)
+		require.NoError(t, err)
+		sigShares = append(sigShares, sig)
+	}
+	sigShares = append([][]byte{{1, 2, 3}, {1}}, sigShares...)
+
+	sig, err := scheme.Recover(pubPoly, msg, sigShares, th, n)
+	require.NoError(t, err)
+	require.NoError(t, scheme.VerifyRecovered(pubPoly.Commit(), msg, sig))
+
+	// only 3 valid partials
+	_, err = scheme.Recover(pubPoly, msg, sigShares[:7], th, n)
+	require.Error(t, err)
+}
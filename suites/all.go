@@ -3,6 +3,8 @@ package suites
 import (
 	"go.dedis.ch/kyber/v4/group/edwards25519"
 	"go.dedis.ch/kyber/v4/group/p256"
+	"go.dedis.ch/kyber/v4/group/ristretto255"
+	"go.dedis.ch/kyber/v4/group/secp256k1"
 	"go.dedis.ch/kyber/v4/pairing/bls12381/circl"
 	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
 	"go.dedis.ch/kyber/v4/pairing/bn254"
@@ -12,7 +14,8 @@ import (
 func init() {
 	// Those are variable time suites that shouldn't be used
 	// in production environment when possible
-	register(p256.NewBlakeSHA256P256())
+	register(p256.NewBlakeSHA384P384())
+	register(p256.NewBlakeSHA512P521())
 	register(p256.NewBlakeSHA256QR512())
 	register(bn256.NewSuiteG1())
 	register(bn256.NewSuiteG2())
@@ -21,7 +24,10 @@ func init() {
 	register(bn254.NewSuite())
 	register(circl.NewSuiteBLS12381())
 	register(kilic.NewSuiteBLS12381())
-	// This is a constant time implementation that should be
+	// These are constant time implementations that should be
 	// used as much as possible
 	register(edwards25519.NewBlakeSHA256Ed25519())
+	register(ristretto255.NewBlakeSHA256Ristretto255())
+	register(secp256k1.NewBlakeSHA256Secp256k1())
+	register(p256.NewBlakeSHA256P256())
 }
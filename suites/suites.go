@@ -1,7 +1,8 @@
 // Package suites allows callers to look up Kyber suites by name.
 //
-// Currently, only the "ed25519" suite is available with a constant
-// time implementation and the other ones use variable time algorithms.
+// Currently, only the "ed25519", "ristretto255", "secp256k1" and "P256"
+// suites are available with a constant time implementation and the other
+// ones use variable time algorithms.
 package suites
 
 import (
@@ -24,6 +25,15 @@ var suites = map[string]Suite{}
 
 var requireConstTime = false
 
+// constTimeSuites are the names of the suites implemented with constant time
+// algorithms.
+var constTimeSuites = map[string]bool{
+	"ed25519":      true,
+	"ristretto255": true,
+	"secp256k1":    true,
+	"p256":         true,
+}
+
 // register is called by suites to make themselves known to Kyber.
 func register(s Suite) {
 	suites[strings.ToLower(s.String())] = s
@@ -36,7 +46,7 @@ var ErrUnknownSuite = errors.New("unknown suite")
 // Find looks up a suite by name.
 func Find(name string) (Suite, error) {
 	if s, ok := suites[strings.ToLower(name)]; ok {
-		if requireConstTime && strings.ToLower(s.String()) != "ed25519" {
+		if requireConstTime && !constTimeSuites[strings.ToLower(s.String())] {
 			return nil, errors.New(
 				"requested suite exists but is not implemented " +
 					"with constant time algorithms as required by " +
@@ -63,7 +73,8 @@ func MustFind(name string) Suite {
 // Once constant time implementations are required, there is no way to
 // turn it back off (by design).
 //
-// At this time, the only constant time crypto suite is "Ed25519".
+// At this time, the only constant time crypto suites are "Ed25519",
+// "Ristretto255", "secp256k1" and "P256".
 func RequireConstantTime() {
 	requireConstTime = true
 }
@@ -9,10 +9,14 @@ import (
 func TestSuites_Find(t *testing.T) {
 	ss := []string{
 		"ed25519",
+		"ristretto255",
+		"secp256k1",
 		"bn256.G1",
 		"bn256.G2",
 		"bn256.GT",
 		"P256",
+		"P384",
+		"P521",
 		"Residue512",
 	}
 
@@ -37,4 +41,20 @@ func TestSuites_ConstTime(t *testing.T) {
 	s, err = Find("ed25519")
 	require.NoError(t, err)
 	require.NotNil(t, s)
+
+	s, err = Find("ristretto255")
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	s, err = Find("secp256k1")
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	s, err = Find("P256")
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	s, err = Find("P384")
+	require.Error(t, err)
+	require.Nil(t, s)
 }
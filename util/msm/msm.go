@@ -0,0 +1,168 @@
+// Package msm implements multi-scalar multiplication, i.e. the computation of
+// s_1*P_1 + s_2*P_2 + ... + s_n*P_n, for any kyber.Group.
+//
+// Groups can provide their own optimized implementation by implementing
+// kyber.MultiScalarMultiplier on their points; MultiScalarMul uses it when it
+// is available and falls back to a generic implementation of Pippenger's
+// bucket method on top of the kyber.Point interface otherwise.
+//
+// All the algorithms of this package run in variable time: they must only be
+// used with public Scalars and Points.
+package msm
+
+import (
+	"math"
+
+	"go.dedis.ch/kyber/v4"
+)
+
+// maxWindow is the largest window width considered by WindowSize.
+const maxWindow = 16
+
+// MultiScalarMul returns the sum of scalars[i] * points[i] computed in the
+// group g. It panics if the two slices have different lengths.
+func MultiScalarMul(g kyber.Group, scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	if m, ok := g.Point().(kyber.MultiScalarMultiplier); ok {
+		return m.MultiScalarMul(scalars, points)
+	}
+	return Pippenger(g, scalars, points)
+}
+
+// Pippenger returns the sum of scalars[i] * points[i] computed with
+// Pippenger's bucket method, using only the kyber.Point interface of the
+// group g. It panics if the two slices have different lengths.
+func Pippenger(g kyber.Group, scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	CheckLengths(scalars, points)
+
+	acc := g.Point().Null()
+	if len(points) == 0 {
+		return acc
+	}
+
+	c := WindowSize(len(points), 8*g.ScalarLen())
+	digits := Digits(scalars, c)
+	// a nil bucket stands for the neutral element, which avoids paying
+	// for additions with the identity
+	buckets := make([]kyber.Point, 1<<c-1)
+	empty := true
+
+	for w := len(digits[0]) - 1; w >= 0; w-- {
+		if !empty {
+			for i := 0; i < c; i++ {
+				acc.Add(acc, acc)
+			}
+		}
+
+		for i := range buckets {
+			buckets[i] = nil
+		}
+		for i, d := range digits {
+			k := d[w]
+			if k == 0 {
+				continue
+			}
+			if buckets[k-1] == nil {
+				buckets[k-1] = points[i].Clone()
+			} else {
+				buckets[k-1].Add(buckets[k-1], points[i])
+			}
+		}
+
+		// window = sum_k k * bucket[k-1], computed with running sums
+		var sum, window kyber.Point
+		for k := len(buckets) - 1; k >= 0; k-- {
+			if buckets[k] != nil {
+				if sum == nil {
+					sum = buckets[k]
+				} else {
+					sum.Add(sum, buckets[k])
+				}
+			}
+			if sum != nil {
+				if window == nil {
+					window = sum.Clone()
+				} else {
+					window.Add(window, sum)
+				}
+			}
+		}
+
+		if window != nil {
+			acc.Add(acc, window)
+			empty = false
+		}
+	}
+	return acc
+}
+
+// CheckLengths panics if the number of scalars and points of a
+// multi-scalar multiplication do not match.
+func CheckLengths(scalars []kyber.Scalar, points []kyber.Point) {
+	if len(scalars) != len(points) {
+		panic("msm: number of scalars and points must be equal")
+	}
+}
+
+// WindowSize returns the window width in bits, i.e. the base 2 logarithm of
+// the number of buckets, minimizing the number of group operations of
+// Pippenger's algorithm for n terms with scalars of the given bit length.
+func WindowSize(n, bits int) int {
+	best, bestCost := 1, math.MaxInt
+	for c := 1; c <= maxWindow; c++ {
+		windows := (bits + c - 1) / c
+		// each window costs one addition per term, two per bucket to sum
+		// them up and c doublings
+		cost := windows * (n + 1<<(c+1) + c)
+		if cost < bestCost {
+			best, bestCost = c, cost
+		}
+	}
+	return best
+}
+
+// Digits decomposes each scalar in base 2^c, least significant digit first.
+// All the returned slices have the same length, which is large enough to
+// hold the digits of the largest scalar encoding.
+func Digits(scalars []kyber.Scalar, c int) [][]uint32 {
+	if c < 1 || c > maxWindow {
+		panic("msm: invalid window size")
+	}
+
+	encoded := make([][]byte, len(scalars))
+	size := 0
+	for i, s := range scalars {
+		buf, _ := s.MarshalBinary()
+		if s.ByteOrder() == kyber.BigEndian {
+			// reverse into a fresh slice, buf may be shared with the scalar
+			le := make([]byte, len(buf))
+			for j := range buf {
+				le[j] = buf[len(buf)-1-j]
+			}
+			buf = le
+		}
+		encoded[i] = buf
+		if len(buf) > size {
+			size = len(buf)
+		}
+	}
+
+	windows := (8*size + c - 1) / c
+	mask := uint32(1)<<c - 1
+	digits := make([][]uint32, len(scalars))
+	for i, buf := range encoded {
+		d := make([]uint32, windows)
+		for w := range d {
+			bit := w * c
+			// gather the (at most 24) bits overlapping the window
+			var v uint32
+			for j := 0; j < 3; j++ {
+				if idx := bit/8 + j; idx < len(buf) {
+					v |= uint32(buf[idx]) << (8 * j)
+				}
+			}
+			d[w] = (v >> (bit % 8)) & mask
+		}
+		digits[i] = d
+	}
+	return digits
+}
@@ -0,0 +1,112 @@
+package msm_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/group/p256"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/circl"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
+	"go.dedis.ch/kyber/v4/pairing/bn254"
+	"go.dedis.ch/kyber/v4/pairing/bn256"
+	"go.dedis.ch/kyber/v4/util/msm"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+func TestDigits(t *testing.T) {
+	order := big.NewInt(1)
+	order.Lsh(order, 255)
+	scalars := []kyber.Scalar{
+		mod.NewInt64(0, order),
+		mod.NewInt64(1, order),
+		mod.NewIntBytes([]byte{0xde, 0xad, 0xbe, 0xef, 0x01}, order, kyber.BigEndian),
+		mod.NewIntBytes([]byte{0xde, 0xad, 0xbe, 0xef, 0x01}, order, kyber.LittleEndian),
+		edwards25519.NewBlakeSHA256Ed25519().Scalar().Pick(random.New()),
+	}
+
+	for c := 1; c <= 16; c++ {
+		digits := msm.Digits(scalars, c)
+		for i, s := range scalars {
+			v := new(big.Int)
+			for w := len(digits[i]) - 1; w >= 0; w-- {
+				require.Less(t, digits[i][w], uint32(1)<<c)
+				v.Lsh(v, uint(c))
+				v.Add(v, big.NewInt(int64(digits[i][w])))
+			}
+
+			buf, err := s.MarshalBinary()
+			require.NoError(t, err)
+			if s.ByteOrder() == kyber.LittleEndian {
+				for l, r := 0, len(buf)-1; l < r; l, r = l+1, r-1 {
+					buf[l], buf[r] = buf[r], buf[l]
+				}
+			}
+			require.Zero(t, new(big.Int).SetBytes(buf).Cmp(v), "scalar %d, window %d", i, c)
+		}
+	}
+}
+
+func TestPippenger(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	for _, n := range []int{0, 1, 7, 64} {
+		scalars := make([]kyber.Scalar, n)
+		points := make([]kyber.Point, n)
+		expected := suite.Point().Null()
+		for i := range points {
+			scalars[i] = suite.Scalar().Pick(suite.RandomStream())
+			points[i] = suite.Point().Pick(suite.RandomStream())
+			expected.Add(expected, suite.Point().Mul(scalars[i], points[i]))
+		}
+		require.True(t, expected.Equal(msm.Pippenger(suite, scalars, points)))
+		require.True(t, expected.Equal(msm.MultiScalarMul(suite, scalars, points)))
+	}
+}
+
+// TestAliasing checks the native implementations when the receiver is one of
+// the points, as in P.MultiScalarMul(s, []kyber.Point{P, Q}).
+func TestAliasing(t *testing.T) {
+	bn254Suite, bn256Suite := bn254.NewSuite(), bn256.NewSuite()
+	circlSuite, kilicSuite := circl.NewSuiteBLS12381(), kilic.NewBLS12381Suite()
+	groups := []kyber.Group{
+		edwards25519.NewBlakeSHA256Ed25519(),
+		p256.NewBlakeSHA256P256(),
+		p256.NewBlakeSHA384P384(),
+		bn254Suite.G1(), bn254Suite.G2(),
+		bn256Suite.G1(), bn256Suite.G2(),
+		circlSuite.G1(), circlSuite.G2(),
+		kilicSuite.G1(), kilicSuite.G2(),
+	}
+	for _, g := range groups {
+		// edwards25519 switches to the bucket method from 128 terms
+		for _, n := range []int{2, 130} {
+			if n > 2 && g.String() != "Ed25519" {
+				continue
+			}
+			scalars := make([]kyber.Scalar, n)
+			points := make([]kyber.Point, n)
+			expected := g.Point().Null()
+			for i := range points {
+				scalars[i] = g.Scalar().Pick(random.New())
+				points[i] = g.Point().Pick(random.New())
+				expected.Add(expected, g.Point().Mul(scalars[i], points[i]))
+			}
+			P := points[0]
+			P.(kyber.MultiScalarMultiplier).MultiScalarMul(scalars, points)
+			require.True(t, expected.Equal(P), "%s with %d terms", g.String(), n)
+		}
+	}
+}
+
+func TestLengthMismatch(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	require.Panics(t, func() {
+		msm.MultiScalarMul(suite, []kyber.Scalar{suite.Scalar()}, nil)
+	})
+	require.Panics(t, func() {
+		msm.Pippenger(suite, nil, []kyber.Point{suite.Point()})
+	})
+}
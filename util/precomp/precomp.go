@@ -0,0 +1,97 @@
+// Package precomp implements fixed-base precomputation for any kyber.Group:
+// a table of multiples of a Point is built once, and then used to multiply
+// that Point by many Scalars with additions only.
+//
+// Groups can provide their own optimized tables by implementing
+// kyber.Precomputable on their points; Precompute uses them when they are
+// available and falls back to a generic Table otherwise.
+package precomp
+
+import (
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/msm"
+)
+
+// Window is the width in bits of the digits used to index the tables.
+const Window = 4
+
+// Precompute returns a PrecomputedPoint for p in the group g, using the
+// native implementation of the group if there is one, and a generic Table
+// otherwise.
+func Precompute(g kyber.Group, p kyber.Point) kyber.PrecomputedPoint {
+	if pc, ok := p.(kyber.Precomputable); ok {
+		return pc.Precompute()
+	}
+	return NewTable(g, p)
+}
+
+var _ kyber.PrecomputedPoint = &Table{}
+var _ kyber.AllowsVarTime = &Table{}
+
+// Table is a generic kyber.PrecomputedPoint built on top of the kyber.Point
+// interface. Its lookups and additions depend on the Scalar, so the table is
+// only used once variable time has been allowed with AllowVarTime(true);
+// until then Mul simply falls back to the Mul of the Point.
+type Table struct {
+	g     kyber.Group
+	base  kyber.Point
+	table [][]kyber.Point
+}
+
+// NewTable returns a generic Table for p in the group g.
+func NewTable(g kyber.Group, p kyber.Point) *Table {
+	return &Table{g: g, base: p.Clone()}
+}
+
+// AllowVarTime builds the table of multiples of the point when varTime is
+// true, and drops it otherwise. It must be called before the Table is
+// shared between goroutines.
+func (t *Table) AllowVarTime(varTime bool) {
+	if !varTime {
+		t.table = nil
+		return
+	}
+	if t.table != nil {
+		return
+	}
+
+	windows := (8*t.g.ScalarLen() + Window - 1) / Window
+	t.table = make([][]kyber.Point, windows)
+	b := t.base.Clone()
+	for i := range t.table {
+		// table[i][d-1] = d * 2^(Window*i) * base
+		row := make([]kyber.Point, 1<<Window-1)
+		row[0] = b.Clone()
+		for d := 1; d < len(row); d++ {
+			row[d] = t.g.Point().Add(row[d-1], b)
+		}
+		t.table[i] = row
+		b = t.g.Point().Add(row[len(row)-1], b)
+	}
+}
+
+// Point returns a copy of the precomputed point.
+func (t *Table) Point() kyber.Point {
+	return t.base.Clone()
+}
+
+// Mul returns a new Point set to s times the precomputed point.
+func (t *Table) Mul(s kyber.Scalar) kyber.Point {
+	if t.table == nil {
+		return t.g.Point().Mul(s, t.base)
+	}
+
+	digits := msm.Digits([]kyber.Scalar{s}, Window)[0]
+	if len(digits) > len(t.table) {
+		// the encoding of s is larger than the scalars of the group
+		return t.g.Point().Mul(s, t.base)
+	}
+
+	acc := t.g.Point().Null()
+	for i, d := range digits {
+		if d != 0 {
+			acc.Add(acc, t.table[i][d-1])
+		}
+	}
+	return acc
+}
@@ -0,0 +1,44 @@
+package precomp_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/p256"
+	"go.dedis.ch/kyber/v4/util/precomp"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+func TestPrecomputeNative(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	pp := precomp.Precompute(suite, suite.Point().Base())
+	_, generic := pp.(*precomp.Table)
+	require.False(t, generic)
+
+	s := suite.Scalar().Pick(random.New())
+	require.True(t, suite.Point().Mul(s, nil).Equal(pp.Mul(s)))
+}
+
+func TestTable(t *testing.T) {
+	suite := p256.NewBlakeSHA256P256()
+	p := suite.Point().Pick(random.New())
+	pp := precomp.Precompute(suite, p)
+	table, ok := pp.(*precomp.Table)
+	require.True(t, ok)
+
+	for _, varTime := range []bool{false, true, false} {
+		table.AllowVarTime(varTime)
+		for i := 0; i < 10; i++ {
+			s := suite.Scalar().Pick(random.New())
+			require.True(t, suite.Point().Mul(s, p).Equal(table.Mul(s)))
+		}
+	}
+
+	// the table must not depend on the point it was built from
+	p.Null()
+	table.AllowVarTime(true)
+	s := suite.Scalar().Pick(random.New())
+	require.True(t, table.Point().Mul(s, table.Point()).Equal(table.Mul(s)))
+	require.False(t, table.Mul(s).Equal(suite.Point().Null()))
+}
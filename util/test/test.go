@@ -8,6 +8,8 @@ import (
 
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/util/key"
+	"go.dedis.ch/kyber/v4/util/msm"
+	"go.dedis.ch/kyber/v4/util/precomp"
 	"go.dedis.ch/kyber/v4/util/random"
 )
 
@@ -320,6 +322,68 @@ func testEncodingDecoding(t *testing.T, g kyber.Group, ptmp kyber.Point, stmp ky
 	}
 }
 
+// MultiScalarMulTest checks that msm.MultiScalarMul agrees with a sequence
+// of Mul and Add operations, including on the edge cases of zero scalars,
+// neutral elements and repeated points.
+func MultiScalarMulTest(t *testing.T, g kyber.Group, rand cipher.Stream) {
+	for _, n := range []int{0, 1, 5, 17} {
+		scalars := make([]kyber.Scalar, n)
+		points := make([]kyber.Point, n)
+		// consecutive points differ by a random step, which is cheaper
+		// than picking them all at random in the slowest groups
+		step := g.Point().Pick(rand)
+		prev := g.Point().Pick(rand)
+		for i := range points {
+			scalars[i] = g.Scalar().Pick(rand)
+			points[i] = g.Point().Add(prev, step)
+			prev = points[i]
+		}
+		if n > 4 {
+			scalars[1].Zero()
+			scalars[2].One().Neg(scalars[2])
+			points[3].Null()
+			points[4].Set(points[0])
+		}
+
+		expected := g.Point().Null()
+		for i := range points {
+			expected.Add(expected, g.Point().Mul(scalars[i], points[i]))
+		}
+		require.True(t, expected.Equal(msm.MultiScalarMul(g, scalars, points)),
+			"multi-scalar multiplication mismatch for %d terms", n)
+		require.True(t, expected.Equal(msm.Pippenger(g, scalars, points)),
+			"generic multi-scalar multiplication mismatch for %d terms", n)
+	}
+}
+
+// PrecomputedPointTest checks that the multiplications of a
+// precomputed point agree with the Mul of the point, both in constant time
+// and, when the implementation allows it, in variable time.
+func PrecomputedPointTest(t *testing.T, g kyber.Group, rand cipher.Stream) {
+	p := g.Point().Pick(rand)
+	pp := precomp.Precompute(g, p)
+	require.True(t, p.Equal(pp.Point()), "precomputed point differs from its base")
+
+	scalars := []kyber.Scalar{
+		g.Scalar().Zero(),
+		g.Scalar().One(),
+		g.Scalar().Neg(g.Scalar().One()),
+		g.Scalar().Pick(rand),
+	}
+	check := func() {
+		for _, s := range scalars {
+			require.True(t, g.Point().Mul(s, p).Equal(pp.Mul(s)),
+				"precomputed multiplication mismatch for %v", s)
+		}
+	}
+
+	check()
+	if vt, ok := pp.(kyber.AllowsVarTime); ok {
+		vt.AllowVarTime(true)
+		check()
+	}
+}
+
 // Apply a generic set of validation tests to a cryptographic Group,
 // using a given source of [pseudo-]randomness.
 //
@@ -399,6 +463,8 @@ func testGroup(t *testing.T, g kyber.Group, rand cipher.Stream) []kyber.Point {
 	testPointClone(t, g, rand)
 	testScalarSet(t, g, rand)
 	testScalarClone(t, g, rand)
+	MultiScalarMulTest(t, g, rand)
+	PrecomputedPointTest(t, g, rand)
 
 	return points
 }
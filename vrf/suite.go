@@ -0,0 +1,201 @@
+package vrf
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"hash"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/p256"
+)
+
+// Suite is an ECVRF ciphersuite of RFC 9381: a prime order group, or a
+// subgroup of a curve, with the encodings, the encode_to_curve and the nonce
+// generation of the suite.
+type Suite interface {
+	kyber.Group
+	// Name returns the name of the suite, like ECVRF-P256-SHA256-TAI.
+	Name() string
+
+	suiteString() byte
+	hash() hash.Hash
+	cofactor() int64
+	encodePoint(p kyber.Point) ([]byte, error)
+	decodePoint(buf []byte) (kyber.Point, error)
+	encodeToCurve(pk, alpha []byte) (kyber.Point, error)
+	secretKey(sk []byte) (x kyber.Scalar, nonceKey []byte, err error)
+	nonce(x kyber.Scalar, nonceKey, h []byte) kyber.Scalar
+}
+
+type edwards25519Suite struct {
+	*edwards25519.SuiteEd25519
+}
+
+// NewEdwards25519SHA512ELL2 returns the ECVRF-EDWARDS25519-SHA512-ELL2 suite,
+// whose secret keys are the 32-byte seeds of Ed25519.
+func NewEdwards25519SHA512ELL2() Suite {
+	return &edwards25519Suite{edwards25519.NewBlakeSHA256Ed25519()}
+}
+
+func (s *edwards25519Suite) Name() string      { return "ECVRF-EDWARDS25519-SHA512-ELL2" }
+func (s *edwards25519Suite) suiteString() byte { return 0x04 }
+func (s *edwards25519Suite) hash() hash.Hash   { return sha512.New() }
+func (s *edwards25519Suite) cofactor() int64   { return 8 }
+
+func (s *edwards25519Suite) encodePoint(p kyber.Point) ([]byte, error) {
+	return p.MarshalBinary()
+}
+
+// decodePoint decodes a point, rejecting the non-canonical encodings.
+func (s *edwards25519Suite) decodePoint(buf []byte) (kyber.Point, error) {
+	p := s.Point()
+	if err := p.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	if !p.(interface{ IsCanonical([]byte) bool }).IsCanonical(buf) {
+		return nil, errors.New("vrf: non canonical point encoding")
+	}
+	return p, nil
+}
+
+func (s *edwards25519Suite) encodeToCurve(pk, alpha []byte) (kyber.Point, error) {
+	dst := append([]byte("ECVRF_edwards25519_XMD:SHA-512_ELL2_NU_"), s.suiteString())
+	m := append(append([]byte{}, pk...), alpha...)
+	return s.Point().(kyber.EncodablePoint).EncodeToCurve(m, dst), nil
+}
+
+// secretKey derives the secret scalar of Ed25519 from the seed, as in
+// RFC 8032, section 5.1.5. The second half of the hash of the seed is the
+// key of the nonce generation.
+func (s *edwards25519Suite) secretKey(sk []byte) (kyber.Scalar, []byte, error) {
+	if len(sk) != 32 {
+		return nil, nil, errors.New("vrf: invalid Ed25519 secret key length")
+	}
+	h := sha512.Sum512(sk)
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	return s.Scalar().SetBytes(h[:32]), h[32:], nil
+}
+
+// nonce implements the nonce generation of RFC 8032, section 5.1.6.
+func (s *edwards25519Suite) nonce(_ kyber.Scalar, nonceKey, h []byte) kyber.Scalar {
+	H := sha512.New()
+	H.Write(nonceKey)
+	H.Write(h)
+	return s.Scalar().SetBytes(H.Sum(nil))
+}
+
+type p256Suite struct {
+	*p256.Suite128
+}
+
+// NewP256SHA256TAI returns the ECVRF-P256-SHA256-TAI suite, whose secret keys
+// are big-endian integers of 32 bytes.
+func NewP256SHA256TAI() Suite {
+	return &p256Suite{p256.NewBlakeSHA256P256()}
+}
+
+func (s *p256Suite) Name() string      { return "ECVRF-P256-SHA256-TAI" }
+func (s *p256Suite) suiteString() byte { return 0x01 }
+func (s *p256Suite) hash() hash.Hash   { return sha256.New() }
+func (s *p256Suite) cofactor() int64   { return 1 }
+
+// encodePoint returns the SEC1 compressed encoding of the point.
+func (s *p256Suite) encodePoint(p kyber.Point) ([]byte, error) {
+	if p.Equal(s.Point().Null()) {
+		return nil, errors.New("vrf: cannot encode the point at infinity")
+	}
+	buf, err := p.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	// buf is the uncompressed encoding 0x04 || x || y
+	coordLen := (len(buf) - 1) / 2
+	out := make([]byte, 1+coordLen)
+	out[0] = 2 | buf[len(buf)-1]&1
+	copy(out[1:], buf[1:1+coordLen])
+	return out, nil
+}
+
+func (s *p256Suite) decodePoint(buf []byte) (kyber.Point, error) {
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), buf)
+	if x == nil {
+		return nil, errors.New("vrf: invalid compressed point")
+	}
+	p := s.Point()
+	if err := p.UnmarshalBinary(elliptic.Marshal(elliptic.P256(), x, y)); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// encodeToCurve implements the try-and-increment method of RFC 9381,
+// section 5.4.1.1, which runs in variable time, on public inputs.
+func (s *p256Suite) encodeToCurve(pk, alpha []byte) (kyber.Point, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		h := s.hash()
+		h.Write([]byte{s.suiteString(), 0x01})
+		h.Write(pk)
+		h.Write(alpha)
+		h.Write([]byte{byte(ctr), 0x00})
+		if p, err := s.decodePoint(h.Sum([]byte{0x02})); err == nil {
+			return p, nil
+		}
+	}
+	return nil, errors.New("vrf: no valid point found")
+}
+
+func (s *p256Suite) secretKey(sk []byte) (kyber.Scalar, []byte, error) {
+	x := s.Scalar()
+	if err := x.UnmarshalBinary(sk); err != nil {
+		return nil, nil, err
+	}
+	if x.Equal(s.Scalar().Zero()) {
+		return nil, nil, errors.New("vrf: invalid zero secret key")
+	}
+	return x, nil, nil
+}
+
+// nonce implements the deterministic nonce generation of RFC 6979,
+// section 3.2, with SHA-256 and the message h.
+func (s *p256Suite) nonce(x kyber.Scalar, _, h []byte) kyber.Scalar {
+	q := s.Scalar().GroupOrder()
+	xb, _ := x.MarshalBinary()
+	h1 := sha256.Sum256(h)
+	// bits2octets(h1): the order and the hash have the same bit length
+	hb := new(big.Int).SetBytes(h1[:])
+	hb.Mod(hb, q)
+	hOctets := hb.FillBytes(make([]byte, len(xb)))
+
+	V := make([]byte, sha256.Size)
+	K := make([]byte, sha256.Size)
+	for i := range V {
+		V[i] = 0x01
+	}
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(sha256.New, key)
+		for _, d := range data {
+			m.Write(d)
+		}
+		return m.Sum(nil)
+	}
+	K = mac(K, V, []byte{0x00}, xb, hOctets)
+	V = mac(K, V)
+	K = mac(K, V, []byte{0x01}, xb, hOctets)
+	V = mac(K, V)
+	for {
+		V = mac(K, V)
+		k := new(big.Int).SetBytes(V)
+		if k.Sign() > 0 && k.Cmp(q) < 0 {
+			return s.Scalar().SetBytes(V)
+		}
+		K = mac(K, V, []byte{0x00})
+		V = mac(K, V)
+	}
+}
@@ -0,0 +1,211 @@
+// Package vrf implements the elliptic curve verifiable random functions
+// (ECVRF) of RFC 9381, with the suites ECVRF-EDWARDS25519-SHA512-ELL2 and
+// ECVRF-P256-SHA256-TAI.
+//
+// The holder of a secret key computes with Prove the proof pi of an input
+// alpha. The output beta, which ProofToHash derives from the proof, is
+// pseudorandom and unique for the key and the input, and anyone knowing the
+// public key can check it with Verify.
+package vrf
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+)
+
+// cLen is the length in bytes of the challenges, for both suites.
+const cLen = 16
+
+// ErrInvalidProof is returned by Verify when the proof is not valid for the
+// public key and the input.
+var ErrInvalidProof = errors.New("vrf: invalid proof")
+
+// SecretKey is an ECVRF secret key.
+type SecretKey struct {
+	x        kyber.Scalar
+	nonceKey []byte
+	// Public is the public key, the secret scalar times the base point.
+	Public kyber.Point
+}
+
+// NewSecretKey returns the key of the secret key string sk of the suite: the
+// 32-byte seed for ECVRF-EDWARDS25519-SHA512-ELL2, or the big-endian scalar
+// for ECVRF-P256-SHA256-TAI.
+func NewSecretKey(suite Suite, sk []byte) (*SecretKey, error) {
+	x, nonceKey, err := suite.secretKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretKey{
+		x:        x,
+		nonceKey: nonceKey,
+		Public:   suite.Point().Mul(x, nil),
+	}, nil
+}
+
+// GenerateKey returns a random secret key, with its secret key string.
+func GenerateKey(suite Suite, rand cipher.Stream) (*SecretKey, []byte) {
+	for {
+		sk := make([]byte, suite.ScalarLen())
+		rand.XORKeyStream(sk, sk)
+		if key, err := NewSecretKey(suite, sk); err == nil {
+			return key, sk
+		}
+	}
+}
+
+// Prove returns the proof pi_string of the input alpha with the secret key.
+func Prove(suite Suite, sk *SecretKey, alpha []byte) ([]byte, error) {
+	pk, err := suite.encodePoint(sk.Public)
+	if err != nil {
+		return nil, err
+	}
+	H, err := suite.encodeToCurve(pk, alpha)
+	if err != nil {
+		return nil, err
+	}
+	hString, err := suite.encodePoint(H)
+	if err != nil {
+		return nil, err
+	}
+
+	gamma := suite.Point().Mul(sk.x, H)
+	k := suite.nonce(sk.x, sk.nonceKey, hString)
+	U := suite.Point().Mul(k, nil)
+	V := suite.Point().Mul(k, H)
+	c, err := challenge(suite, sk.Public, H, gamma, U, V)
+	if err != nil {
+		return nil, err
+	}
+
+	// s = k + c*x
+	s := suite.Scalar().Mul(suite.Scalar().SetBytes(c), sk.x)
+	s.Add(s, k)
+
+	pi, err := suite.encodePoint(gamma)
+	if err != nil {
+		return nil, err
+	}
+	sString, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	pi = append(pi, c...)
+	return append(pi, sString...), nil
+}
+
+// Verify checks that pi is a valid proof of the input alpha for the public
+// key, and returns the output beta_string of the proof if it is.
+func Verify(suite Suite, public kyber.Point, alpha, pi []byte) ([]byte, error) {
+	// validate_key: reject the public keys of small order
+	cofactor := suite.Scalar().SetInt64(suite.cofactor())
+	if suite.Point().Mul(cofactor, public).Equal(suite.Point().Null()) {
+		return nil, errors.New("vrf: invalid public key")
+	}
+
+	gamma, c, s, err := decodeProof(suite, pi)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := suite.encodePoint(public)
+	if err != nil {
+		return nil, err
+	}
+	H, err := suite.encodeToCurve(pk, alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	// U = s*B - c*Y and V = s*H - c*Gamma
+	cs := suite.Scalar().SetBytes(c)
+	U := suite.Point().Mul(s, nil)
+	U.Sub(U, suite.Point().Mul(cs, public))
+	V := suite.Point().Mul(s, H)
+	V.Sub(V, suite.Point().Mul(cs, gamma))
+
+	expected, err := challenge(suite, public, H, gamma, U, V)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(c, expected) != 1 {
+		return nil, ErrInvalidProof
+	}
+	return proofToHash(suite, gamma)
+}
+
+// ProofToHash returns the output beta_string of the proof pi. It does not
+// verify the proof, which must come from Prove or have been checked with
+// Verify.
+func ProofToHash(suite Suite, pi []byte) ([]byte, error) {
+	gamma, _, _, err := decodeProof(suite, pi)
+	if err != nil {
+		return nil, err
+	}
+	return proofToHash(suite, gamma)
+}
+
+func proofToHash(suite Suite, gamma kyber.Point) ([]byte, error) {
+	cofactor := suite.Scalar().SetInt64(suite.cofactor())
+	buf, err := suite.encodePoint(suite.Point().Mul(cofactor, gamma))
+	if err != nil {
+		return nil, err
+	}
+	h := suite.hash()
+	h.Write([]byte{suite.suiteString(), 0x03})
+	h.Write(buf)
+	h.Write([]byte{0x00})
+	return h.Sum(nil), nil
+}
+
+// challenge returns the challenge string of the points, truncated to cLen
+// bytes.
+func challenge(suite Suite, points ...kyber.Point) ([]byte, error) {
+	h := suite.hash()
+	h.Write([]byte{suite.suiteString(), 0x02})
+	for _, p := range points {
+		buf, err := suite.encodePoint(p)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(buf)
+	}
+	h.Write([]byte{0x00})
+	return h.Sum(nil)[:cLen], nil
+}
+
+// decodeProof splits the proof into the point Gamma, the challenge string and
+// the scalar s, which must be reduced.
+func decodeProof(suite Suite, pi []byte) (kyber.Point, []byte, kyber.Scalar, error) {
+	base, err := suite.encodePoint(suite.Point().Base())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ptLen, qLen := len(base), suite.ScalarLen()
+	if len(pi) != ptLen+cLen+qLen {
+		return nil, nil, nil, errors.New("vrf: invalid proof length")
+	}
+	gamma, err := suite.decodePoint(pi[:ptLen])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c := pi[ptLen : ptLen+cLen]
+
+	sString := pi[ptLen+cLen:]
+	s := suite.Scalar()
+	v := make([]byte, qLen)
+	copy(v, sString)
+	if s.ByteOrder() == kyber.LittleEndian {
+		for i, j := 0, len(v)-1; i < j; i, j = i+1, j-1 {
+			v[i], v[j] = v[j], v[i]
+		}
+	}
+	if new(big.Int).SetBytes(v).Cmp(s.GroupOrder()) >= 0 {
+		return nil, nil, nil, errors.New("vrf: invalid proof scalar")
+	}
+	s.SetBytes(sString)
+	return gamma, c, s, nil
+}
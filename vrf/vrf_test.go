@@ -0,0 +1,136 @@
+package vrf
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+type vector struct {
+	sk, pk, alpha, pi, beta string
+}
+
+// RFC 9381, appendices B.1 and B.3.
+var vectors = []struct {
+	suite   Suite
+	vectors []vector
+}{
+	{
+		NewP256SHA256TAI(),
+		[]vector{
+			{
+				"c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+				"0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6",
+				"73616d706c65",
+				"035b5c726e8c0e2c488a107c600578ee75cb702343c153cb1eb8dec77f4b5071b4" +
+					"a53f0a46f018bc2c56e58d383f2305e0975972c26feea0eb122fe7893c15af37" +
+					"6b33edf7de17c6ea056d4d82de6bc02f",
+				"a3ad7b0ef73d8fc6655053ea22f9bede8c743f08bbed3d38821f0e16474b505e",
+			},
+			{
+				"c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+				"0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6",
+				"74657374",
+				"034dac60aba508ba0c01aa9be80377ebd7562c4a52d74722e0abae7dc3080ddb56" +
+					"c19e067b15a8a8174905b13617804534214f935b94c2287f797e393eb0816969" +
+					"d864f37625b443f30f1a5a33f2b3c854",
+				"a284f94ceec2ff4b3794629da7cbafa49121972671b466cab4ce170aa365f26d",
+			},
+		},
+	},
+	{
+		NewEdwards25519SHA512ELL2(),
+		[]vector{
+			{
+				"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+				"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+				"72",
+				"47b327393ff2dd81336f8a2ef10339112401253b3c714eeda879f12c509072ef" +
+					"055b48372bb82efbdce8e10c8cb9a2f9d60e93908f93df1623ad78a86a028d6b" +
+					"c064dbfc75a6a57379ef855dc6733801",
+				"38561d6b77b71d30eb97a062168ae12b667ce5c28caccdf76bc88e093e463598" +
+					"7cd96814ce55b4689b3dd2947f80e59aac7b7675f8083865b46c89b2ce9cc735",
+			},
+			{
+				"c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+				"fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+				"af82",
+				"926e895d308f5e328e7aa159c06eddbe56d06846abf5d98c2512235eaa57fdce" +
+					"35b46edfc655bc828d44ad09d1150f31374e7ef73027e14760d42e77341fe054" +
+					"67bb286cc2c9d7fde29120a0b2320d04",
+				"121b7f9b9aaaa29099fc04a94ba52784d44eac976dd1a3cca458733be5cd090a" +
+					"7b5fbd148444f17f8daf1fb55cb04b1ae85a626e30a54b4b0f8abf4a43314a58",
+			},
+		},
+	},
+}
+
+func TestVectors(t *testing.T) {
+	for _, sv := range vectors {
+		suite := sv.suite
+		for _, v := range sv.vectors {
+			sk, err := NewSecretKey(suite, decodeHex(t, v.sk))
+			require.NoError(t, err)
+			pk, err := suite.encodePoint(sk.Public)
+			require.NoError(t, err)
+			require.Equal(t, v.pk, hex.EncodeToString(pk), suite.Name())
+
+			alpha := decodeHex(t, v.alpha)
+			pi, err := Prove(suite, sk, alpha)
+			require.NoError(t, err)
+			require.Equal(t, v.pi, hex.EncodeToString(pi), suite.Name())
+
+			beta, err := ProofToHash(suite, pi)
+			require.NoError(t, err)
+			require.Equal(t, v.beta, hex.EncodeToString(beta), suite.Name())
+
+			beta, err = Verify(suite, sk.Public, alpha, pi)
+			require.NoError(t, err)
+			require.Equal(t, v.beta, hex.EncodeToString(beta), suite.Name())
+		}
+	}
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	for _, suite := range []Suite{NewP256SHA256TAI(), NewEdwards25519SHA512ELL2()} {
+		sk, _ := GenerateKey(suite, random.New())
+		alpha := []byte("leader election, round 12")
+		pi, err := Prove(suite, sk, alpha)
+		require.NoError(t, err)
+		beta, err := Verify(suite, sk.Public, alpha, pi)
+		require.NoError(t, err)
+
+		// the output is unique for the key and the input
+		pi2, err := Prove(suite, sk, alpha)
+		require.NoError(t, err)
+		beta2, err := ProofToHash(suite, pi2)
+		require.NoError(t, err)
+		require.Equal(t, beta, beta2)
+
+		_, err = Verify(suite, sk.Public, []byte("leader election, round 13"), pi)
+		require.Error(t, err, suite.Name())
+
+		other, _ := GenerateKey(suite, random.New())
+		_, err = Verify(suite, other.Public, alpha, pi)
+		require.Error(t, err, suite.Name())
+
+		for i := range pi {
+			bad := append([]byte{}, pi...)
+			bad[i] ^= 1
+			_, err = Verify(suite, sk.Public, alpha, bad)
+			require.Error(t, err, "%s: byte %d", suite.Name(), i)
+		}
+		_, err = Verify(suite, sk.Public, alpha, pi[1:])
+		require.Error(t, err, suite.Name())
+		_, err = Verify(suite, suite.Point().Null(), alpha, pi)
+		require.Error(t, err, suite.Name())
+	}
+}